@@ -0,0 +1,209 @@
+package gotwi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrDryRun is returned by a call made with WithDryRun, after the request has been prepared but not sent.
+var ErrDryRun = errors.New("dry run: the request was not sent")
+
+// CallOption changes how a single API call is made.
+// It is accepted by Do and the endpoint functions, and can be attached to a context with WithCallOptions.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	header      http.Header
+	timeout     time.Duration
+	contentType string
+
+	accessToken      string
+	oauthToken       string
+	oauthTokenSecret string
+
+	dryRun  bool
+	inspect func(*http.Request)
+
+	strictDecoding bool
+}
+
+// WithHeader adds a header to the request.
+// The Authorization header is always set by the client, so it cannot be overwritten with this option.
+func WithHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = http.Header{}
+		}
+		o.header.Add(key, value)
+	}
+}
+
+// WithTimeout sets the timeout of the call, including reading the response body.
+// It does not apply to streaming endpoints, whose connections are kept open.
+func WithTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = d
+	}
+}
+
+// WithContentType sets the Content-Type header of the request.
+// The default is "application/json;charset=UTF-8".
+// It takes precedence over the value of the "Content-Type" key of the context,
+// which is still read for compatibility but deprecated.
+func WithContentType(contentType string) CallOption {
+	return func(o *callOptions) {
+		o.contentType = contentType
+	}
+}
+
+// WithAccessToken makes the call with the OAuth 2.0 access token instead of the credentials of the client,
+// e.g. a user access token obtained with OAuth 2.0 Authorization Code with PKCE.
+func WithAccessToken(token string) CallOption {
+	return func(o *callOptions) {
+		o.accessToken = token
+		o.oauthToken = ""
+		o.oauthTokenSecret = ""
+	}
+}
+
+// WithOAuth1Token makes the call in the OAuth 1.0a user context of another user.
+// The request is signed with the API key of the client, so the client must have been created with NewClient.
+func WithOAuth1Token(token, tokenSecret string) CallOption {
+	return func(o *callOptions) {
+		o.oauthToken = token
+		o.oauthTokenSecret = tokenSecret
+		o.accessToken = ""
+	}
+}
+
+// WithDryRun prepares and signs the request without sending it, and the call returns ErrDryRun.
+// If inspect is not nil, it is called with the request before returning.
+func WithDryRun(inspect func(req *http.Request)) CallOption {
+	return func(o *callOptions) {
+		o.dryRun = true
+		o.inspect = inspect
+	}
+}
+
+// WithStrictDecoding makes the call fail with *resources.UnknownFieldsError
+// when the response has fields that are not defined in the types of gotwi, instead of keeping them in Extra.
+// It is meant for debugging, to find the types that need to be updated.
+func WithStrictDecoding() CallOption {
+	return func(o *callOptions) {
+		o.strictDecoding = true
+	}
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns a copy of ctx that carries the options,
+// so that they are applied to the calls made with it, e.g. by IClient.CallAPI.
+// The options are added after the ones already attached to ctx.
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+
+	merged := make([]CallOption, 0, len(opts))
+	if parent, ok := ctx.Value(callOptionsKey{}).([]CallOption); ok {
+		merged = append(merged, parent...)
+	}
+	merged = append(merged, opts...)
+
+	return context.WithValue(ctx, callOptionsKey{}, merged)
+}
+
+func callOptionsFrom(ctx context.Context) *callOptions {
+	o := &callOptions{}
+	if ctx == nil {
+		return o
+	}
+
+	opts, _ := ctx.Value(callOptionsKey{}).([]CallOption)
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	return o
+}
+
+// withCallTimeout returns a context with the timeout of the call options, if any.
+func withCallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d := callOptionsFrom(ctx).timeout; d > 0 {
+		return context.WithTimeout(ctx, d)
+	}
+	return ctx, func() {}
+}
+
+// credentials returns the IClient whose credentials are used to authenticate the request.
+func (o *callOptions) credentials(c IClient) (IClient, error) {
+	switch {
+	case o.accessToken != "":
+		return &overriddenCredentials{
+			IClient:              c,
+			authenticationMethod: AuthenMethodOAuth2BearerToken,
+			accessToken:          o.accessToken,
+		}, nil
+	case o.oauthToken != "" || o.oauthTokenSecret != "":
+		if o.oauthToken == "" || o.oauthTokenSecret == "" {
+			return nil, fmt.Errorf("OAuthToken and OAuthTokenSecret is required for using %s.", AuthenMethodOAuth1UserContext)
+		}
+		s, ok := c.(interface{ APIKeySecret() string })
+		if !ok || s.APIKeySecret() == "" || c.OAuthConsumerKey() == "" {
+			return nil, errors.New("the client has no API key to sign the request with another OAuth token")
+		}
+		return &overriddenCredentials{
+			IClient:              c,
+			authenticationMethod: AuthenMethodOAuth1UserContext,
+			oauthToken:           o.oauthToken,
+			signingKey: fmt.Sprintf("%s&%s",
+				url.QueryEscape(s.APIKeySecret()),
+				url.QueryEscape(o.oauthTokenSecret)),
+		}, nil
+	}
+
+	return c, nil
+}
+
+// overriddenCredentials replaces the credentials of an IClient for a single call.
+type overriddenCredentials struct {
+	IClient
+	authenticationMethod AuthenticationMethod
+	accessToken          string
+	oauthToken           string
+	signingKey           string
+}
+
+func (c *overriddenCredentials) AuthenticationMethod() AuthenticationMethod {
+	return c.authenticationMethod
+}
+
+func (c *overriddenCredentials) AccessToken() string {
+	return c.accessToken
+}
+
+func (c *overriddenCredentials) OAuthToken() string {
+	return c.oauthToken
+}
+
+func (c *overriddenCredentials) SigningKey() string {
+	return c.signingKey
+}
+
+// dryRun returns ErrDryRun if the call is a dry run, after passing req to the inspect function.
+func dryRun(ctx context.Context, req *http.Request) error {
+	o := callOptionsFrom(ctx)
+	if !o.dryRun {
+		return nil
+	}
+	if o.inspect != nil {
+		o.inspect(req)
+	}
+	return ErrDryRun
+}
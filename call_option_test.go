@@ -0,0 +1,218 @@
+package gotwi_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/resources"
+	tweetlookuptypes "github.com/michimani/gotwi/tweet/tweetlookup/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CallOptions_DryRun(t *testing.T) {
+	oauth1Client, err := gotwi.NewClient(&gotwi.NewClientInput{
+		AuthenticationMethod: gotwi.AuthenMethodOAuth1UserContext,
+		OAuthToken:           "client-token",
+		OAuthTokenSecret:     "client-token-secret",
+		APIKey:               "api-key",
+		APIKeySecret:         "api-key-secret",
+	})
+	assert.NoError(t, err)
+
+	bearerClient, err := gotwi.NewClientWithAccessToken(&gotwi.NewClientWithAccessTokenInput{
+		AccessToken: "client-access-token",
+	})
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name          string
+		client        *gotwi.Client
+		ctx           context.Context
+		opts          []gotwi.CallOption
+		wantErr       bool
+		expectAuth    string
+		expectHeader  http.Header
+		expectContent string
+	}{
+		{
+			name:          "ok: default",
+			client:        bearerClient,
+			expectAuth:    "Bearer client-access-token",
+			expectContent: "application/json;charset=UTF-8",
+		},
+		{
+			name:   "ok: headers and content type",
+			client: bearerClient,
+			opts: []gotwi.CallOption{
+				gotwi.WithHeader("X-Test", "a"),
+				gotwi.WithHeader("X-Test", "b"),
+				gotwi.WithHeader("Authorization", "ignored"),
+				gotwi.WithContentType("text/plain"),
+			},
+			expectAuth:    "Bearer client-access-token",
+			expectHeader:  http.Header{"X-Test": {"a", "b"}},
+			expectContent: "text/plain",
+		},
+		{
+			name:          "ok: deprecated content type in the context",
+			client:        bearerClient,
+			ctx:           context.WithValue(context.Background(), "Content-Type", "text/csv"),
+			expectAuth:    "Bearer client-access-token",
+			expectContent: "text/csv",
+		},
+		{
+			name:          "ok: content type option takes precedence over the context",
+			client:        bearerClient,
+			ctx:           context.WithValue(context.Background(), "Content-Type", "text/csv"),
+			opts:          []gotwi.CallOption{gotwi.WithContentType("text/plain")},
+			expectAuth:    "Bearer client-access-token",
+			expectContent: "text/plain",
+		},
+		{
+			name:          "ok: another access token",
+			client:        oauth1Client,
+			opts:          []gotwi.CallOption{gotwi.WithAccessToken("user-access-token")},
+			expectAuth:    "Bearer user-access-token",
+			expectContent: "application/json;charset=UTF-8",
+		},
+		{
+			name:          "ok: another OAuth 1.0a token",
+			client:        oauth1Client,
+			opts:          []gotwi.CallOption{gotwi.WithOAuth1Token("user-token", "user-token-secret")},
+			expectAuth:    `oauth_token="user-token"`,
+			expectContent: "application/json;charset=UTF-8",
+		},
+		{
+			name:    "ng: OAuth 1.0a token without secret",
+			client:  oauth1Client,
+			opts:    []gotwi.CallOption{gotwi.WithOAuth1Token("user-token", "")},
+			wantErr: true,
+		},
+		{
+			name:    "ng: OAuth 1.0a token with a client that has no API key",
+			client:  bearerClient,
+			opts:    []gotwi.CallOption{gotwi.WithOAuth1Token("user-token", "user-token-secret")},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			ctx := c.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			var req *http.Request
+			opts := append(c.opts, gotwi.WithDryRun(func(r *http.Request) { req = r }))
+			out, err := gotwi.Do[*gotwi.MockResponse](ctx, c.client, http.MethodGet, "https://api.x.com/2/test", &gotwi.CustomInput{}, opts...)
+			asst.Error(err)
+			asst.Nil(out)
+			if c.wantErr {
+				asst.NotErrorIs(err, gotwi.ErrDryRun)
+				asst.Nil(req)
+				return
+			}
+
+			asst.ErrorIs(err, gotwi.ErrDryRun)
+			if !asst.NotNil(req) {
+				return
+			}
+			asst.Contains(req.Header.Get("Authorization"), c.expectAuth)
+			asst.Equal(c.expectContent, req.Header.Get("Content-Type"))
+			for k, v := range c.expectHeader {
+				asst.Equal(v, req.Header.Values(k))
+			}
+		})
+	}
+}
+
+func Test_CallOptions_Timeout(t *testing.T) {
+	asst := assert.New(t)
+
+	client, err := gotwi.NewClientWithAccessToken(&gotwi.NewClientWithAccessTokenInput{
+		AccessToken: "token",
+		HTTPClient: &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			}),
+		},
+	})
+	asst.NoError(err)
+
+	start := time.Now()
+	_, err = gotwi.Do[*gotwi.MockResponse](context.Background(), client, http.MethodGet, "https://api.x.com/2/test", &gotwi.CustomInput{}, gotwi.WithTimeout(50*time.Millisecond))
+	asst.ErrorIs(err, context.DeadlineExceeded)
+	asst.Less(time.Since(start), 5*time.Second)
+}
+
+func Test_WithCallOptions(t *testing.T) {
+	asst := assert.New(t)
+
+	client, err := gotwi.NewClientWithAccessToken(&gotwi.NewClientWithAccessTokenInput{
+		AccessToken: "token",
+	})
+	asst.NoError(err)
+
+	var req *http.Request
+	ctx := gotwi.WithCallOptions(context.Background(), gotwi.WithHeader("X-Parent", "1"))
+	ctx = gotwi.WithCallOptions(ctx, gotwi.WithDryRun(func(r *http.Request) { req = r }))
+
+	err = client.CallAPI(ctx, "https://api.x.com/2/test", http.MethodPost, &gotwi.CustomInput{}, &gotwi.MockResponse{})
+	asst.ErrorIs(err, gotwi.ErrDryRun)
+	if asst.NotNil(req) {
+		asst.Equal("1", req.Header.Get("X-Parent"))
+		asst.True(strings.HasPrefix(req.Header.Get("Authorization"), "Bearer "))
+	}
+}
+
+func Test_CallOptions_StrictDecoding(t *testing.T) {
+	asst := assert.New(t)
+
+	client, err := gotwi.NewClientWithAccessToken(&gotwi.NewClientWithAccessTokenInput{
+		AccessToken: "token",
+		HTTPClient: &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {"application/json;charset=UTF-8"}},
+					Body:       io.NopCloser(strings.NewReader(`{"data":{"id":"1","text":"hello","new_field":1}}`)),
+					Request:    req,
+				}, nil
+			}),
+		},
+	})
+	asst.NoError(err)
+
+	const endpoint = "https://api.x.com/2/tweets/:id"
+	in := &gotwi.CustomInput{PathParameters: map[string]string{"id": "1"}}
+
+	// unknown fields are kept by default
+	res, err := gotwi.Do[*tweetlookuptypes.GetOutput](context.Background(), client, http.MethodGet, endpoint, in)
+	asst.NoError(err)
+	raw, ok := res.Data.Extra.Get("new_field")
+	asst.True(ok)
+	asst.Equal(json.RawMessage(`1`), raw)
+
+	_, err = gotwi.Do[*tweetlookuptypes.GetOutput](context.Background(), client, http.MethodGet, endpoint, in, gotwi.WithStrictDecoding())
+	var ufe *resources.UnknownFieldsError
+	if asst.ErrorAs(err, &ufe) {
+		asst.Equal("Tweet", ufe.Resource)
+		asst.Equal([]string{"new_field"}, ufe.Fields)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -35,6 +36,9 @@ func (a AuthenticationMethod) Valid() bool {
 
 type NewClientInput struct {
 	HTTPClient           *http.Client
+	StreamHTTPClient     *http.Client
+	StreamIdleTimeout    time.Duration
+	Gzip                 bool
 	AuthenticationMethod AuthenticationMethod
 	OAuthToken           string
 	OAuthTokenSecret     string
@@ -44,9 +48,12 @@ type NewClientInput struct {
 }
 
 type NewClientWithAccessTokenInput struct {
-	HTTPClient  *http.Client
-	AccessToken string
-	Debug       bool
+	HTTPClient        *http.Client
+	StreamHTTPClient  *http.Client
+	StreamIdleTimeout time.Duration
+	Gzip              bool
+	AccessToken       string
+	Debug             bool
 }
 
 type IClient interface {
@@ -62,6 +69,10 @@ type IClient interface {
 
 type Client struct {
 	Client               *http.Client
+	StreamHTTPClient     *http.Client
+	streamIdleTimeout    time.Duration
+	gzip                 bool
+	transferMetrics      *TransferMetrics
 	authenticationMethod AuthenticationMethod
 	accessToken          string
 	oauthToken           string
@@ -72,18 +83,97 @@ type Client struct {
 	debug                bool
 }
 
+// ClientResponse is the metadata of the HTTP response of an API call.
 type ClientResponse struct {
 	StatusCode int
 	Status     string
-	Error      *resources.Non2XXError
-	Body       []byte
-	Response   util.Response
+
+	// Error is the error returned by the API when the status is not 2XX.
+	Error *resources.Non2XXError
+
+	// Body is the raw response body, after decompression.
+	Body []byte
+
+	// Response is the decoded response body.
+	Response util.Response
+
+	Header http.Header
+
+	// RateLimitInfo is resolved from the x-rate-limit-* headers. It is nil if the headers are absent.
+	RateLimitInfo *util.RateLimitInformation
+
+	// TransactionID is the value of the x-transaction-id header, which identifies the request for X support.
+	TransactionID string
+
+	// AccessLevel is the value of the x-access-level header, e.g. "read", "read-write".
+	AccessLevel string
+
+	// Duration is the time from sending the request until the whole response body is read.
+	Duration time.Duration
+}
+
+func newClientResponse(res *http.Response, i util.Response) *ClientResponse {
+	cr := &ClientResponse{
+		StatusCode:    res.StatusCode,
+		Status:        res.Status,
+		Response:      i,
+		Header:        res.Header,
+		TransactionID: res.Header.Get("x-transaction-id"),
+		AccessLevel:   res.Header.Get("x-access-level"),
+	}
+
+	if len(util.HeaderValues(util.RATE_LIMIT_LIMIT_HEADER_KEY, res.Header)) > 0 {
+		if rri, err := util.GetRateLimitInformation(res); err == nil {
+			cr.RateLimitInfo = rri
+		}
+	}
+
+	return cr
+}
+
+type responseCaptureKey struct{}
+
+// WithResponseCapture returns a context that makes Client.CallAPI store the metadata of the HTTP response into cr.
+// It allows getting the metadata from the function of any endpoint, e.g. tweetlookup.List.
+func WithResponseCapture(ctx context.Context, cr *ClientResponse) context.Context {
+	return context.WithValue(ctx, responseCaptureKey{}, cr)
+}
+
+// ResponseCaptureFrom returns the ClientResponse attached to ctx by WithResponseCapture, or nil.
+// It is for IClient implementations that wrap Client and call CallAPIWithResponse.
+func ResponseCaptureFrom(ctx context.Context) *ClientResponse {
+	cr, _ := ctx.Value(responseCaptureKey{}).(*ClientResponse)
+	return cr
 }
 
 var defaultHTTPClient = &http.Client{
 	Timeout: time.Duration(30) * time.Second,
 }
 
+// DefaultStreamIdleTimeout is the default duration after which a stream is closed if no data is received.
+// The streaming endpoints send a keep-alive signal every 20 seconds.
+const DefaultStreamIdleTimeout = time.Duration(60) * time.Second
+
+// defaultStreamHTTPClient is the http.Client for streaming endpoints.
+// It has no overall timeout, because a stream is kept open as long as possible.
+// Instead, the idle timeout of StreamClient closes a stream that has stalled.
+// Compression is not disabled, so the transport requests gzip and decompresses it transparently.
+var defaultStreamHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   time.Duration(30) * time.Second,
+			KeepAlive: time.Duration(30) * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		TLSHandshakeTimeout:   time.Duration(10) * time.Second,
+		ResponseHeaderTimeout: time.Duration(30) * time.Second,
+		ExpectContinueTimeout: time.Duration(1) * time.Second,
+		IdleConnTimeout:       time.Duration(90) * time.Second,
+		DisableCompression:    false,
+	},
+}
+
 func NewClient(in *NewClientInput) (*Client, error) {
 	if in == nil {
 		return nil, fmt.Errorf("NewClientInput is nil.")
@@ -95,16 +185,20 @@ func NewClient(in *NewClientInput) (*Client, error) {
 
 	c := Client{
 		Client:               defaultHTTPClient,
+		StreamHTTPClient:     defaultStreamHTTPClient,
+		streamIdleTimeout:    resolveStreamIdleTimeout(in.StreamIdleTimeout),
 		authenticationMethod: in.AuthenticationMethod,
 		apiKeyOverride:       in.APIKey,
 		apiKeySecretOverride: in.APIKeySecret,
 		debug:                in.Debug,
 	}
 
-	if in.HTTPClient != nil {
-		c.Client = in.HTTPClient
+	if in.Gzip {
+		c.enableGzip()
 	}
 
+	c.setHTTPClients(in.HTTPClient, in.StreamHTTPClient)
+
 	if err := c.authorize(in.OAuthToken, in.OAuthTokenSecret); err != nil {
 		return nil, err
 	}
@@ -123,18 +217,59 @@ func NewClientWithAccessToken(in *NewClientWithAccessTokenInput) (*Client, error
 
 	c := Client{
 		Client:               defaultHTTPClient,
+		StreamHTTPClient:     defaultStreamHTTPClient,
+		streamIdleTimeout:    resolveStreamIdleTimeout(in.StreamIdleTimeout),
 		authenticationMethod: AuthenMethodOAuth2BearerToken,
 		accessToken:          in.AccessToken,
 		debug:                in.Debug,
 	}
 
-	if in.HTTPClient != nil {
-		c.Client = in.HTTPClient
+	if in.Gzip {
+		c.enableGzip()
 	}
 
+	c.setHTTPClients(in.HTTPClient, in.StreamHTTPClient)
+
 	return &c, nil
 }
 
+// setHTTPClients sets the http.Client for REST endpoints and the one for streaming endpoints.
+// If only httpClient is specified, it is also used for streaming endpoints.
+func (c *Client) setHTTPClients(httpClient, streamHTTPClient *http.Client) {
+	if httpClient != nil {
+		c.Client = httpClient
+		c.StreamHTTPClient = httpClient
+	}
+
+	if streamHTTPClient != nil {
+		c.StreamHTTPClient = streamHTTPClient
+	}
+}
+
+// enableGzip makes the client request gzip-compressed responses and record TransferMetrics.
+func (c *Client) enableGzip() {
+	c.gzip = true
+	c.transferMetrics = &TransferMetrics{}
+}
+
+// TransferMetrics returns the byte counts of response bodies received with gzip enabled.
+// It returns nil if gzip is not enabled.
+func (c *Client) TransferMetrics() *TransferMetrics {
+	return c.transferMetrics
+}
+
+// resolveStreamIdleTimeout returns DefaultStreamIdleTimeout if d is 0.
+// A negative value disables the idle timeout.
+func resolveStreamIdleTimeout(d time.Duration) time.Duration {
+	if d == 0 {
+		return DefaultStreamIdleTimeout
+	}
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
 func (c *Client) authorize(oauthToken, oauthTokenSecret string) error {
 	apiKey := c.APIKey()
 	apiKeySecret := c.APIKeySecret()
@@ -210,6 +345,14 @@ func (c *Client) APIKeySecret() string {
 	return os.Getenv(APIKeySecretEnvName)
 }
 
+// streamHTTPClient returns the http.Client for streaming endpoints.
+func (c *Client) streamHTTPClient() *http.Client {
+	if c.StreamHTTPClient != nil {
+		return c.StreamHTTPClient
+	}
+	return c.Client
+}
+
 func (c *Client) OAuthToken() string {
 	return c.oauthToken
 }
@@ -239,11 +382,26 @@ func (c *Client) SetSigningKey(v string) {
 }
 
 func (c *Client) CallAPI(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+	if capture := ResponseCaptureFrom(ctx); capture != nil {
+		cr, err := c.CallAPIWithResponse(ctx, endpoint, method, p, i)
+		if cr != nil {
+			*capture = *cr
+		}
+		return err
+	}
+
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
+
 	req, err := prepare(ctx, endpoint, method, p, c)
 	if err != nil {
 		return wrapErr(err)
 	}
 
+	if err := dryRun(ctx, req); err != nil {
+		return wrapErr(err)
+	}
+
 	non200err, err := c.Exec(req, i)
 	if err != nil {
 		return wrapErr(err)
@@ -256,12 +414,52 @@ func (c *Client) CallAPI(ctx context.Context, endpoint, method string, p util.Pa
 	return nil
 }
 
+// CallAPIWithResponse calls the API like CallAPI, and also returns the metadata of the HTTP response.
+// When the API returns a non-2XX status or the response body cannot be decoded,
+// the ClientResponse is returned together with the error, with the raw body in ClientResponse.Body.
+func (c *Client) CallAPIWithResponse(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) (*ClientResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
+
+	req, err := prepare(ctx, endpoint, method, p, c)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	if err := dryRun(ctx, req); err != nil {
+		return nil, wrapErr(err)
+	}
+
+	cr, err := c.exec(req, i, true)
+	if err != nil {
+		return cr, wrapErr(err)
+	}
+
+	if cr.Error != nil {
+		return cr, wrapWithAPIErr(cr.Error)
+	}
+
+	return cr, nil
+}
+
 var okCodes map[int]struct{} = map[int]struct{}{
 	http.StatusOK:      {},
 	http.StatusCreated: {},
 }
 
 func (c *Client) Exec(req *http.Request, i util.Response) (*resources.Non2XXError, error) {
+	cr, err := c.exec(req, i, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return cr.Error, nil
+}
+
+// exec sends the request and decodes the response into i.
+// If keepBody is true, the raw response body is kept in the returned ClientResponse.
+// Once the response is received, the ClientResponse is returned even if the body cannot be decoded.
+func (c *Client) exec(req *http.Request, i util.Response, keepBody bool) (*ClientResponse, error) {
 	var jsonStr string
 	if req.Body != nil {
 		bodyBytes, err := io.ReadAll(req.Body)
@@ -272,40 +470,65 @@ func (c *Client) Exec(req *http.Request, i util.Response) (*resources.Non2XXErro
 		req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 	}
 
+	if c.gzip {
+		setAcceptEncoding(req)
+	}
+
+	start := time.Now()
 	res, err := c.Client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
 
+	if c.gzip {
+		decodeResponseBody(res, c.transferMetrics)
+	}
+
+	cr := newClientResponse(res, i)
+
 	if c.debug {
 		fmt.Printf("------DEBUG------\n[request url]\n%v\n[request header]\n%v\n\n[request body]\n%s\n------DEBUG END------\n", req.URL, req.Header, jsonStr)
 	}
+
+	rawBody := new(bytes.Buffer)
+	if keepBody || c.debug {
+		res.Body = &readCloser{Reader: io.TeeReader(res.Body, rawBody), closer: res.Body}
+	}
+	finish := func() {
+		if keepBody {
+			// read the rest of the body that the decoder did not consume
+			io.Copy(io.Discard, res.Body)
+			cr.Body = rawBody.Bytes()
+		}
+		cr.Duration = time.Since(start)
+	}
+
 	if _, ok := okCodes[res.StatusCode]; !ok {
 		non200err, err := resolveNon2XXResponse(res)
 		if err != nil {
-			return nil, err
+			finish()
+			return cr, err
 		}
-		return non200err, nil
+		cr.Error = non200err
+		finish()
+		return cr, nil
 	}
 
-	var tr io.Reader
-	debugBuf := new(bytes.Buffer)
-	if c.debug {
-		tr = io.TeeReader(res.Body, debugBuf)
-	} else {
-		tr = res.Body
+	jerr := json.NewDecoder(res.Body).Decode(i)
+	if jerr == nil && callOptionsFrom(req.Context()).strictDecoding {
+		jerr = resources.CheckUnknownFields(i)
 	}
-
-	jerr := json.NewDecoder(tr).Decode(i)
 	if c.debug {
-		fmt.Printf("------DEBUG------\n[request url]\n%v\n[response header]\n%v\n[response body]\n%s\n------DEBUG END------\n", req.URL, res.Header, debugBuf.String())
+		fmt.Printf("------DEBUG------\n[request url]\n%v\n[response header]\n%v\n[response body]\n%s\n------DEBUG END------\n", req.URL, res.Header, rawBody.String())
 	}
 	if jerr != nil && jerr != io.EOF {
-		return nil, jerr
+		finish()
+		return cr, jerr
 	}
 
-	return nil, nil
+	finish()
+	return cr, nil
 }
 
 func prepare(ctx context.Context, endpointBase, method string, p util.Parameters, c IClient) (*http.Request, error) {
@@ -317,6 +540,11 @@ func prepare(ctx context.Context, endpointBase, method string, p util.Parameters
 		return nil, fmt.Errorf(gotwierrors.ErrorClientNotReady)
 	}
 
+	c, err := callOptionsFrom(ctx).credentials(c)
+	if err != nil {
+		return nil, err
+	}
+
 	endpoint := p.ResolveEndpoint(endpointBase)
 	p.SetAccessToken(c.AccessToken())
 	req, err := newRequest(ctx, endpoint, method, p)
@@ -379,13 +607,20 @@ func newRequest(ctx context.Context, endpoint, method string, p util.Parameters)
 		return nil, err
 	}
 
+	o := callOptionsFrom(ctx)
+	for k, v := range o.header {
+		req.Header[k] = append(req.Header[k], v...)
+	}
+
 	contentType := "application/json;charset=UTF-8"
-	if ct := ctx.Value("Content-Type"); ct != nil {
-		if ctStr, ok := ct.(string); ok {
-			contentType = ctStr
-		}
+	if o.contentType != "" {
+		contentType = o.contentType
+	} else if ct, ok := ctx.Value("Content-Type").(string); ok {
+		// Deprecated: setting "Content-Type" in the context is kept for compatibility. Use WithContentType.
+		contentType = ct
 	}
 	req.Header.Set("Content-Type", contentType)
+	req.Header.Del("Authorization")
 
 	return req, nil
 }
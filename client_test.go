@@ -309,6 +309,72 @@ func Test_NewClientWithAccessToken(t *testing.T) {
 	}
 }
 
+func Test_NewClientWithAccessToken_StreamHTTPClient(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Duration(60) * time.Second}
+	streamHTTPClient := &http.Client{}
+
+	cases := []struct {
+		name              string
+		in                *gotwi.NewClientWithAccessTokenInput
+		expectTimeout     time.Duration
+		expectIdleTimeout time.Duration
+		expectClient      *http.Client
+	}{
+		{
+			name:              "ok: default",
+			in:                &gotwi.NewClientWithAccessTokenInput{AccessToken: "test-token"},
+			expectTimeout:     0,
+			expectIdleTimeout: gotwi.DefaultStreamIdleTimeout,
+		},
+		{
+			name: "ok: http client is also used for streaming",
+			in: &gotwi.NewClientWithAccessTokenInput{
+				AccessToken: "test-token",
+				HTTPClient:  httpClient,
+			},
+			expectTimeout:     time.Duration(60) * time.Second,
+			expectIdleTimeout: gotwi.DefaultStreamIdleTimeout,
+			expectClient:      httpClient,
+		},
+		{
+			name: "ok: with stream http client",
+			in: &gotwi.NewClientWithAccessTokenInput{
+				AccessToken:       "test-token",
+				HTTPClient:        httpClient,
+				StreamHTTPClient:  streamHTTPClient,
+				StreamIdleTimeout: time.Duration(90) * time.Second,
+			},
+			expectTimeout:     0,
+			expectIdleTimeout: time.Duration(90) * time.Second,
+			expectClient:      streamHTTPClient,
+		},
+		{
+			name: "ok: idle timeout is disabled",
+			in: &gotwi.NewClientWithAccessTokenInput{
+				AccessToken:       "test-token",
+				StreamIdleTimeout: -1,
+			},
+			expectTimeout:     0,
+			expectIdleTimeout: 0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			gc, err := gotwi.NewClientWithAccessToken(c.in)
+			asst.NoError(err)
+			asst.NotNil(gc.StreamHTTPClient)
+			asst.Equal(c.expectTimeout, gc.StreamHTTPClient.Timeout)
+			asst.Equal(c.expectIdleTimeout, gc.StreamIdleTimeout())
+			if c.expectClient != nil {
+				asst.Same(c.expectClient, gc.StreamHTTPClient)
+			}
+		})
+	}
+}
+
 func Test_IsReady(t *testing.T) {
 	cases := []struct {
 		name   string
@@ -844,3 +910,140 @@ func Test_resolveNon2XXResponse(t *testing.T) {
 		})
 	}
 }
+
+func Test_CallAPIWithResponse(t *testing.T) {
+	cases := []struct {
+		name       string
+		mockInput  *gotwi.MockInput
+		params     util.Parameters
+		wantErr    bool
+		decodeErr  bool
+		expectBody string
+		expect     gotwi.ClientResponse
+	}{
+		{
+			name: "ok",
+			mockInput: &gotwi.MockInput{
+				ResponseStatusCode: http.StatusOK,
+				ResponseHeader: map[string][]string{
+					"Content-Type":           {"application/json;charset=UTF-8"},
+					"X-Transaction-Id":       {"transaction-id"},
+					"X-Access-Level":         {"read-write"},
+					"X-Rate-Limit-Limit":     {"900"},
+					"X-Rate-Limit-Remaining": {"899"},
+					"X-Rate-Limit-Reset":     {"1700000000"},
+				},
+				ResponseBody: io.NopCloser(strings.NewReader("{\"text\": \"ok\"}\n")),
+			},
+			params:     &gotwi.MockAPIParameter{},
+			expectBody: "{\"text\": \"ok\"}\n",
+			expect: gotwi.ClientResponse{
+				StatusCode:    http.StatusOK,
+				TransactionID: "transaction-id",
+				AccessLevel:   "read-write",
+			},
+		},
+		{
+			name: "error: not 200 response",
+			mockInput: &gotwi.MockInput{
+				ResponseStatusCode: http.StatusForbidden,
+				ResponseHeader: map[string][]string{
+					"Content-Type":     {"application/json;charset=UTF-8"},
+					"X-Transaction-Id": {"transaction-id"},
+				},
+				ResponseBody: io.NopCloser(strings.NewReader(`{"title":"Forbidden"}`)),
+			},
+			params:     &gotwi.MockAPIParameter{},
+			wantErr:    true,
+			expectBody: `{"title":"Forbidden"}`,
+			expect: gotwi.ClientResponse{
+				StatusCode:    http.StatusForbidden,
+				TransactionID: "transaction-id",
+			},
+		},
+		{
+			name: "error: response cannot be decoded",
+			mockInput: &gotwi.MockInput{
+				ResponseStatusCode: http.StatusOK,
+				ResponseHeader: map[string][]string{
+					"Content-Type":     {"text/html"},
+					"X-Transaction-Id": {"transaction-id"},
+				},
+				ResponseBody: io.NopCloser(strings.NewReader("<html>maintenance</html>")),
+			},
+			params:     &gotwi.MockAPIParameter{},
+			wantErr:    true,
+			decodeErr:  true,
+			expectBody: "<html>maintenance</html>",
+			expect: gotwi.ClientResponse{
+				StatusCode:    http.StatusOK,
+				TransactionID: "transaction-id",
+			},
+		},
+		{
+			name: "error: parameter is nil",
+			mockInput: &gotwi.MockInput{
+				ResponseStatusCode: http.StatusOK,
+				ResponseBody:       io.NopCloser(strings.NewReader(`{}`)),
+			},
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			client, err := gotwi.NewClientWithAccessToken(&gotwi.NewClientWithAccessTokenInput{
+				AccessToken: "token",
+				HTTPClient:  gotwi.NewMockHTTPClient(c.mockInput),
+			})
+			assert.NoError(tt, err)
+
+			res := &gotwi.MockResponse{}
+			cr, err := client.CallAPIWithResponse(context.Background(), "test-endpoint", http.MethodGet, c.params, res)
+			if c.wantErr {
+				assert.Error(tt, err)
+			} else {
+				assert.NoError(tt, err)
+				assert.Equal(tt, "ok", res.Text)
+			}
+
+			if c.expect.StatusCode == 0 {
+				assert.Nil(tt, cr)
+				return
+			}
+
+			assert.Equal(tt, c.expect.StatusCode, cr.StatusCode)
+			assert.Equal(tt, c.expect.TransactionID, cr.TransactionID)
+			assert.Equal(tt, c.expect.AccessLevel, cr.AccessLevel)
+			assert.Equal(tt, c.expectBody, string(cr.Body))
+			assert.Equal(tt, c.wantErr && !c.decodeErr, cr.Error != nil)
+			if c.expect.StatusCode == http.StatusOK && !c.wantErr {
+				assert.Equal(tt, 900, cr.RateLimitInfo.Limit)
+				assert.Equal(tt, 899, cr.RateLimitInfo.Remaining)
+				assert.Equal(tt, int64(1700000000), cr.RateLimitInfo.ResetAt.Unix())
+			}
+		})
+	}
+}
+
+func Test_CallAPI_WithResponseCapture(t *testing.T) {
+	client, err := gotwi.NewClientWithAccessToken(&gotwi.NewClientWithAccessTokenInput{
+		AccessToken: "token",
+		HTTPClient: gotwi.NewMockHTTPClient(&gotwi.MockInput{
+			ResponseStatusCode: http.StatusOK,
+			ResponseHeader:     map[string][]string{"X-Transaction-Id": {"transaction-id"}},
+			ResponseBody:       io.NopCloser(strings.NewReader(`{"text": "ok"}`)),
+		}),
+	})
+	assert.NoError(t, err)
+
+	cr := gotwi.ClientResponse{}
+	ctx := gotwi.WithResponseCapture(context.Background(), &cr)
+	res, err := gotwi.Do[*gotwi.MockResponse](ctx, client, http.MethodGet, "test-endpoint", &gotwi.MockAPIParameter{})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", res.Text)
+	assert.Equal(t, "transaction-id", cr.TransactionID)
+	assert.Equal(t, `{"text": "ok"}`, string(cr.Body))
+	assert.Same(t, res, cr.Response)
+}
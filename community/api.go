@@ -0,0 +1,25 @@
+package community
+
+import (
+	"context"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/community/types"
+)
+
+const (
+	getEndpoint    = "https://api.twitter.com/2/communities/:id"
+	searchEndpoint = "https://api.twitter.com/2/communities/search"
+)
+
+// Returns a variety of information about a single Community specified by ID.
+// https://docs.x.com/x-api/communities/get-community-by-id
+func Get(ctx context.Context, c gotwi.IClient, p *types.GetInput, opts ...gotwi.CallOption) (*types.GetOutput, error) {
+	return gotwi.Do[*types.GetOutput](ctx, c, "GET", getEndpoint, p, opts...)
+}
+
+// Returns Communities whose name matches the specified search query.
+// https://docs.x.com/x-api/communities/search-communities
+func Search(ctx context.Context, c gotwi.IClient, p *types.SearchInput, opts ...gotwi.CallOption) (*types.SearchOutput, error) {
+	return gotwi.Do[*types.SearchOutput](ctx, c, "GET", searchEndpoint, p, opts...)
+}
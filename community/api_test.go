@@ -0,0 +1,103 @@
+package community_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/community"
+	"github.com/michimani/gotwi/community/types"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Get(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *types.GetInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			params: &types.GetInput{ID: "1"},
+		},
+		{
+			name:    "error",
+			params:  &types.GetInput{ID: "1"},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "error: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return c.mockErr
+				},
+			})
+
+			res, err := community.Get(context.Background(), mockClient, c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, res)
+		})
+	}
+}
+
+func Test_Search(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *types.SearchInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			params: &types.SearchInput{Query: "go"},
+		},
+		{
+			name:    "error",
+			params:  &types.SearchInput{Query: "go"},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "error: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return c.mockErr
+				},
+			})
+
+			res, err := community.Search(context.Background(), mockClient, c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, res)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package types
+
+import (
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/internal/util"
+)
+
+type GetInput struct {
+	accessToken string
+
+	// Path parameter
+	ID string // required: Community ID
+
+	// Query parameters
+	CommunityFields fields.CommunityFieldList
+}
+
+var getQueryParameters = map[string]struct{}{
+	"community.fields": {},
+}
+
+func (p *GetInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *GetInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *GetInput) ResolveEndpoint(endpointBase string) string {
+	if p.ID == "" {
+		return ""
+	}
+
+	encoded := url.QueryEscape(p.ID)
+	endpoint := strings.Replace(endpointBase, ":id", encoded, 1)
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, getQueryParameters)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func (p *GetInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *GetInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+	m = fields.SetFieldsParams(m, p.CommunityFields)
+	return m
+}
+
+type SearchMaxResults int
+
+func (m SearchMaxResults) Valid() bool {
+	return m >= 10 && m <= 100
+}
+
+func (m SearchMaxResults) String() string {
+	return strconv.Itoa(int(m))
+}
+
+type SearchInput struct {
+	accessToken string
+
+	// Query parameters
+	Query           string // required: a keyword to match the Community name
+	MaxResults      SearchMaxResults
+	NextToken       string
+	CommunityFields fields.CommunityFieldList
+}
+
+var searchQueryParameters = map[string]struct{}{
+	"query":            {},
+	"max_results":      {},
+	"next_token":       {},
+	"community.fields": {},
+}
+
+func (p *SearchInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *SearchInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *SearchInput) ResolveEndpoint(endpointBase string) string {
+	if p.Query == "" {
+		return ""
+	}
+
+	endpoint := endpointBase
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, searchQueryParameters)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func (p *SearchInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *SearchInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+	m["query"] = p.Query
+	m = fields.SetFieldsParams(m, p.CommunityFields)
+
+	if p.MaxResults.Valid() {
+		m["max_results"] = p.MaxResults.String()
+	}
+
+	if p.NextToken != "" {
+		m["next_token"] = p.NextToken
+	}
+
+	return m
+}
@@ -0,0 +1,86 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/michimani/gotwi/community/types"
+	"github.com/michimani/gotwi/fields"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint/:id"
+
+	cases := []struct {
+		name   string
+		params *types.GetInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.GetInput{ID: "1146654567674912769"},
+			expect: "test/endpoint/1146654567674912769",
+		},
+		{
+			name: "with community.fields",
+			params: &types.GetInput{
+				ID:              "1146654567674912769",
+				CommunityFields: fields.CommunityFieldList{fields.CommunityFieldName, fields.CommunityFieldMemberCount},
+			},
+			expect: "test/endpoint/1146654567674912769?community.fields=name%2Cmember_count",
+		},
+		{
+			name:   "id is not set",
+			params: &types.GetInput{},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, c.params.ResolveEndpoint(endpoint))
+		})
+	}
+}
+
+func Test_SearchInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint"
+
+	cases := []struct {
+		name   string
+		params *types.SearchInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.SearchInput{Query: "golang"},
+			expect: endpoint + "?query=golang",
+		},
+		{
+			name: "all query parameters",
+			params: &types.SearchInput{
+				Query:           "golang",
+				MaxResults:      10,
+				NextToken:       "token",
+				CommunityFields: fields.CommunityFieldList{fields.CommunityFieldAccess, fields.CommunityFieldJoinPolicy},
+			},
+			expect: endpoint + "?community.fields=access%2Cjoin_policy&max_results=10&next_token=token&query=golang",
+		},
+		{
+			name:   "invalid max_results",
+			params: &types.SearchInput{Query: "golang", MaxResults: 9},
+			expect: endpoint + "?query=golang",
+		},
+		{
+			name:   "query is not set",
+			params: &types.SearchInput{MaxResults: 10},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, c.params.ResolveEndpoint(endpoint))
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package types
+
+import "github.com/michimani/gotwi/resources"
+
+type GetOutput struct {
+	Data   resources.Community      `json:"data"`
+	Errors []resources.PartialError `json:"errors"`
+}
+
+func (r *GetOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+type SearchOutput struct {
+	Data   []resources.Community    `json:"data"`
+	Meta   resources.PaginationMeta `json:"meta"`
+	Errors []resources.PartialError `json:"errors"`
+}
+
+func (r *SearchOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
@@ -2,7 +2,6 @@ package batchcompliance
 
 import (
 	"context"
-	"errors"
 
 	"github.com/michimani/gotwi"
 	"github.com/michimani/gotwi/compliance/batchcompliance/types"
@@ -16,30 +15,14 @@ const (
 
 // Returns a list of recent compliance jobs.
 // https://developer.twitter.com/en/docs/twitter-api/compliance/batch-compliance/api-reference/get-compliance-jobs
-func ListJobs(ctx context.Context, c gotwi.IClient, p *types.ListJobsInput) (*types.ListJobsOutput, error) {
-	if p == nil {
-		return nil, errors.New("ListJobsInput is nil")
-	}
-	res := &types.ListJobsOutput{}
-	if err := c.CallAPI(ctx, listJobsEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func ListJobs(ctx context.Context, c gotwi.IClient, p *types.ListJobsInput, opts ...gotwi.CallOption) (*types.ListJobsOutput, error) {
+	return gotwi.Do[*types.ListJobsOutput](ctx, c, "GET", listJobsEndpoint, p, opts...)
 }
 
 // Get a single compliance job with the specified ID.
 // https://developer.twitter.com/en/docs/twitter-api/compliance/batch-compliance/api-reference/get-compliance-jobs-id
-func GetJob(ctx context.Context, c gotwi.IClient, p *types.GetJobInput) (*types.GetJobOutput, error) {
-	if p == nil {
-		return nil, errors.New("GetJobInput is nil")
-	}
-	res := &types.GetJobOutput{}
-	if err := c.CallAPI(ctx, GetJobEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func GetJob(ctx context.Context, c gotwi.IClient, p *types.GetJobInput, opts ...gotwi.CallOption) (*types.GetJobOutput, error) {
+	return gotwi.Do[*types.GetJobOutput](ctx, c, "GET", GetJobEndpoint, p, opts...)
 }
 
 // Creates a new compliance job for Tweet IDs or user IDs.
@@ -47,14 +30,6 @@ func GetJob(ctx context.Context, c gotwi.IClient, p *types.GetJobInput) (*types.
 // The destination URL represents the location that contains the list of IDs consumed by your App.
 // You can run one batch job at a time.
 // https://developer.twitter.com/en/docs/twitter-api/compliance/batch-compliance/api-reference/post-compliance-jobs
-func CreateJob(ctx context.Context, c gotwi.IClient, p *types.CreateJobInput) (*types.CreateJobOutput, error) {
-	if p == nil {
-		return nil, errors.New("CreateJobInput is nil")
-	}
-	res := &types.CreateJobOutput{}
-	if err := c.CallAPI(ctx, createJobEndpoint, "POST", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func CreateJob(ctx context.Context, c gotwi.IClient, p *types.CreateJobInput, opts ...gotwi.CallOption) (*types.CreateJobOutput, error) {
+	return gotwi.Do[*types.CreateJobOutput](ctx, c, "POST", createJobEndpoint, p, opts...)
 }
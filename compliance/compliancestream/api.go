@@ -0,0 +1,71 @@
+package compliancestream
+
+import (
+	"context"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/compliance/compliancestream/types"
+)
+
+const (
+	tweetComplianceStreamEndpoint = "https://api.twitter.com/2/tweets/compliance/stream"
+	userComplianceStreamEndpoint  = "https://api.twitter.com/2/users/compliance/stream"
+	likesComplianceStreamEndpoint = "https://api.twitter.com/2/likes/compliance/stream"
+	tweetLabelStreamEndpoint      = "https://api.twitter.com/2/tweets/label/stream"
+)
+
+// Streams all Tweet compliance events: delete, edit, withheld, drop, undrop and scrub_geo.
+// The partition parameter is required, and each of the four partitions must be connected to receive all events.
+// To take over the connections left by a crashed consumer, call connection.TerminateActiveConnections once
+// before connecting the partitions. Terminating from each partition would also terminate the others.
+// https://developer.twitter.com/en/docs/twitter-api/compliance/streams/api-reference/get-tweets-compliance-stream
+func TweetComplianceStream(ctx context.Context, c *gotwi.Client, p *types.TweetComplianceStreamInput) (*gotwi.StreamClient[*types.TweetComplianceStreamOutput], error) {
+	tc := gotwi.NewTypedClient[*types.TweetComplianceStreamOutput](c)
+	s, err := tc.CallStreamAPI(ctx, tweetComplianceStreamEndpoint, "GET", p)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Streams all User compliance events: delete, undelete, withheld, suspend, unsuspend, protect, unprotect and scrub_geo.
+// The partition parameter is required, and each of the four partitions must be connected to receive all events.
+// To take over the connections left by a crashed consumer, call connection.TerminateActiveConnections once
+// before connecting the partitions. Terminating from each partition would also terminate the others.
+// https://developer.twitter.com/en/docs/twitter-api/compliance/streams/api-reference/get-users-compliance-stream
+func UserComplianceStream(ctx context.Context, c *gotwi.Client, p *types.UserComplianceStreamInput) (*gotwi.StreamClient[*types.UserComplianceStreamOutput], error) {
+	tc := gotwi.NewTypedClient[*types.UserComplianceStreamOutput](c)
+	s, err := tc.CallStreamAPI(ctx, userComplianceStreamEndpoint, "GET", p)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Streams all compliance events for Likes (favorite delete events).
+// To take over the connection left by a crashed consumer, call connection.TerminateActiveConnections before connecting.
+// https://developer.twitter.com/en/docs/twitter-api/compliance/streams/api-reference/get-likes-compliance-stream
+func LikesComplianceStream(ctx context.Context, c *gotwi.Client, p *types.LikesComplianceStreamInput) (*gotwi.StreamClient[*types.LikesComplianceStreamOutput], error) {
+	tc := gotwi.NewTypedClient[*types.LikesComplianceStreamOutput](c)
+	s, err := tc.CallStreamAPI(ctx, likesComplianceStreamEndpoint, "GET", p)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Streams all labeling events applied to Tweets, such as public Tweet notices being applied or removed.
+// To take over the connection left by a crashed consumer, call connection.TerminateActiveConnections before connecting.
+// https://developer.twitter.com/en/docs/twitter-api/compliance/streams/api-reference/get-tweets-label-stream
+func TweetLabelStream(ctx context.Context, c *gotwi.Client, p *types.TweetLabelStreamInput) (*gotwi.StreamClient[*types.TweetLabelStreamOutput], error) {
+	tc := gotwi.NewTypedClient[*types.TweetLabelStreamOutput](c)
+	s, err := tc.CallStreamAPI(ctx, tweetLabelStreamEndpoint, "GET", p)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
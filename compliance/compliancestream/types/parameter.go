@@ -0,0 +1,215 @@
+package types
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/michimani/gotwi/internal/util"
+)
+
+// Partition is the partition number of a compliance stream. It must be 1, 2, 3 or 4.
+type Partition int
+
+func (v Partition) Valid() bool {
+	return int(v) >= 1 && int(v) <= 4
+}
+
+func (v Partition) String() string {
+	return strconv.Itoa(int(v))
+}
+
+type BackfillMinutes int
+
+func (v BackfillMinutes) Valid() bool {
+	return int(v) > 0 && int(v) <= 5
+}
+
+func (v BackfillMinutes) String() string {
+	return strconv.Itoa(int(v))
+}
+
+type TweetComplianceStreamInput struct {
+	accessToken string
+
+	// Query parameters
+	Partition       Partition // required
+	BackfillMinutes BackfillMinutes
+	StartTime       *time.Time
+	EndTime         *time.Time
+}
+
+var partitionedStreamQueryParameters = map[string]struct{}{
+	"partition":        {},
+	"backfill_minutes": {},
+	"start_time":       {},
+	"end_time":         {},
+}
+
+func (p *TweetComplianceStreamInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *TweetComplianceStreamInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *TweetComplianceStreamInput) ResolveEndpoint(endpointBase string) string {
+	if p == nil || !p.Partition.Valid() {
+		return ""
+	}
+
+	return resolveEndpoint(endpointBase, p.ParameterMap(), partitionedStreamQueryParameters)
+}
+
+func (p *TweetComplianceStreamInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *TweetComplianceStreamInput) ParameterMap() map[string]string {
+	m := parameterMap(p.BackfillMinutes, p.StartTime, p.EndTime)
+	if p.Partition.Valid() {
+		m["partition"] = p.Partition.String()
+	}
+
+	return m
+}
+
+type UserComplianceStreamInput struct {
+	accessToken string
+
+	// Query parameters
+	Partition       Partition // required
+	BackfillMinutes BackfillMinutes
+	StartTime       *time.Time
+	EndTime         *time.Time
+}
+
+func (p *UserComplianceStreamInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *UserComplianceStreamInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *UserComplianceStreamInput) ResolveEndpoint(endpointBase string) string {
+	if p == nil || !p.Partition.Valid() {
+		return ""
+	}
+
+	return resolveEndpoint(endpointBase, p.ParameterMap(), partitionedStreamQueryParameters)
+}
+
+func (p *UserComplianceStreamInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *UserComplianceStreamInput) ParameterMap() map[string]string {
+	m := parameterMap(p.BackfillMinutes, p.StartTime, p.EndTime)
+	if p.Partition.Valid() {
+		m["partition"] = p.Partition.String()
+	}
+
+	return m
+}
+
+type LikesComplianceStreamInput struct {
+	accessToken string
+
+	// Query parameters
+	BackfillMinutes BackfillMinutes
+	StartTime       *time.Time
+	EndTime         *time.Time
+}
+
+var streamQueryParameters = map[string]struct{}{
+	"backfill_minutes": {},
+	"start_time":       {},
+	"end_time":         {},
+}
+
+func (p *LikesComplianceStreamInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *LikesComplianceStreamInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *LikesComplianceStreamInput) ResolveEndpoint(endpointBase string) string {
+	if p == nil {
+		return ""
+	}
+
+	return resolveEndpoint(endpointBase, p.ParameterMap(), streamQueryParameters)
+}
+
+func (p *LikesComplianceStreamInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *LikesComplianceStreamInput) ParameterMap() map[string]string {
+	return parameterMap(p.BackfillMinutes, p.StartTime, p.EndTime)
+}
+
+type TweetLabelStreamInput struct {
+	accessToken string
+
+	// Query parameters
+	BackfillMinutes BackfillMinutes
+	StartTime       *time.Time
+	EndTime         *time.Time
+}
+
+func (p *TweetLabelStreamInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *TweetLabelStreamInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *TweetLabelStreamInput) ResolveEndpoint(endpointBase string) string {
+	if p == nil {
+		return ""
+	}
+
+	return resolveEndpoint(endpointBase, p.ParameterMap(), streamQueryParameters)
+}
+
+func (p *TweetLabelStreamInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *TweetLabelStreamInput) ParameterMap() map[string]string {
+	return parameterMap(p.BackfillMinutes, p.StartTime, p.EndTime)
+}
+
+func resolveEndpoint(endpointBase string, pm map[string]string, allowed map[string]struct{}) string {
+	endpoint := endpointBase
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, allowed)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func parameterMap(backfillMinutes BackfillMinutes, startTime, endTime *time.Time) map[string]string {
+	m := map[string]string{}
+
+	if backfillMinutes.Valid() {
+		m["backfill_minutes"] = backfillMinutes.String()
+	}
+
+	if startTime != nil {
+		m["start_time"] = startTime.Format(time.RFC3339)
+	}
+
+	if endTime != nil {
+		m["end_time"] = endTime.Format(time.RFC3339)
+	}
+
+	return m
+}
@@ -0,0 +1,211 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/michimani/gotwi/compliance/compliancestream/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TweetComplianceStreamInput_SetAccessToken(t *testing.T) {
+	cases := []struct {
+		name   string
+		token  string
+		expect string
+	}{
+		{
+			name:   "normal",
+			token:  "test-token",
+			expect: "test-token",
+		},
+		{
+			name:   "empty",
+			token:  "",
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			p := &types.TweetComplianceStreamInput{}
+			p.SetAccessToken(c.token)
+			assert.Equal(tt, c.expect, p.AccessToken())
+		})
+	}
+}
+
+func Test_TweetComplianceStreamInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint/"
+	startTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	endTime := time.Date(2026, 1, 2, 4, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		name   string
+		params *types.TweetComplianceStreamInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.TweetComplianceStreamInput{Partition: 1},
+			expect: endpoint + "?partition=1",
+		},
+		{
+			name: "with backfill_minutes",
+			params: &types.TweetComplianceStreamInput{
+				Partition:       2,
+				BackfillMinutes: 5,
+			},
+			expect: endpoint + "?backfill_minutes=5&partition=2",
+		},
+		{
+			name: "with invalid backfill_minutes",
+			params: &types.TweetComplianceStreamInput{
+				Partition:       2,
+				BackfillMinutes: 6,
+			},
+			expect: endpoint + "?partition=2",
+		},
+		{
+			name: "all query parameters",
+			params: &types.TweetComplianceStreamInput{
+				Partition:       4,
+				BackfillMinutes: 1,
+				StartTime:       &startTime,
+				EndTime:         &endTime,
+			},
+			expect: endpoint + "?backfill_minutes=1&end_time=2026-01-02T04%3A04%3A05Z&partition=4&start_time=2026-01-02T03%3A04%3A05Z",
+		},
+		{
+			name:   "invalid partition",
+			params: &types.TweetComplianceStreamInput{Partition: 5},
+			expect: "",
+		},
+		{
+			name:   "partition is not set",
+			params: &types.TweetComplianceStreamInput{},
+			expect: "",
+		},
+		{
+			name:   "nil",
+			params: nil,
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			ep := c.params.ResolveEndpoint(endpoint)
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
+
+func Test_UserComplianceStreamInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint/"
+
+	cases := []struct {
+		name   string
+		params *types.UserComplianceStreamInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.UserComplianceStreamInput{Partition: 3, BackfillMinutes: 2},
+			expect: endpoint + "?backfill_minutes=2&partition=3",
+		},
+		{
+			name:   "partition is not set",
+			params: &types.UserComplianceStreamInput{},
+			expect: "",
+		},
+		{
+			name:   "nil",
+			params: nil,
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			ep := c.params.ResolveEndpoint(endpoint)
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
+
+func Test_LikesComplianceStreamInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint/"
+	startTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		name   string
+		params *types.LikesComplianceStreamInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.LikesComplianceStreamInput{},
+			expect: endpoint,
+		},
+		{
+			name: "with start_time",
+			params: &types.LikesComplianceStreamInput{
+				StartTime: &startTime,
+			},
+			expect: endpoint + "?start_time=2026-01-02T03%3A04%3A05Z",
+		},
+		{
+			name:   "nil",
+			params: nil,
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			ep := c.params.ResolveEndpoint(endpoint)
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
+
+func Test_TweetLabelStreamInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint/"
+
+	cases := []struct {
+		name   string
+		params *types.TweetLabelStreamInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.TweetLabelStreamInput{},
+			expect: endpoint,
+		},
+		{
+			name:   "with backfill_minutes",
+			params: &types.TweetLabelStreamInput{BackfillMinutes: 3},
+			expect: endpoint + "?backfill_minutes=3",
+		},
+		{
+			name:   "nil",
+			params: nil,
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			ep := c.params.ResolveEndpoint(endpoint)
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
+
+func Test_TweetComplianceStreamInput_Body(t *testing.T) {
+	p := &types.TweetComplianceStreamInput{Partition: 1}
+	r, err := p.Body()
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+}
@@ -0,0 +1,39 @@
+package types
+
+import "github.com/michimani/gotwi/resources"
+
+type TweetComplianceStreamOutput struct {
+	Data   resources.TweetComplianceEvent `json:"data"`
+	Errors []resources.PartialError       `json:"errors,omitempty"`
+}
+
+func (r *TweetComplianceStreamOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+type UserComplianceStreamOutput struct {
+	Data   resources.UserComplianceEvent `json:"data"`
+	Errors []resources.PartialError      `json:"errors,omitempty"`
+}
+
+func (r *UserComplianceStreamOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+type LikesComplianceStreamOutput struct {
+	Data   resources.LikesComplianceEvent `json:"data"`
+	Errors []resources.PartialError       `json:"errors,omitempty"`
+}
+
+func (r *LikesComplianceStreamOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+type TweetLabelStreamOutput struct {
+	Data   resources.TweetLabelEvent `json:"data"`
+	Errors []resources.PartialError  `json:"errors,omitempty"`
+}
+
+func (r *TweetLabelStreamOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
@@ -0,0 +1,175 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/michimani/gotwi/compliance/compliancestream/types"
+	"github.com/michimani/gotwi/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TweetComplianceStreamOutput_HasPartialError(t *testing.T) {
+	var errorTitle string = "test partical error"
+	cases := []struct {
+		name   string
+		res    *types.TweetComplianceStreamOutput
+		expect bool
+	}{
+		{
+			name: "has partical error",
+			res: &types.TweetComplianceStreamOutput{
+				Errors: []resources.PartialError{
+					{Title: &errorTitle},
+				}},
+			expect: true,
+		},
+		{
+			name: "has no partical error",
+			res: &types.TweetComplianceStreamOutput{
+				Errors: []resources.PartialError{}},
+			expect: false,
+		},
+		{
+			name:   "partical error is nil",
+			res:    &types.TweetComplianceStreamOutput{},
+			expect: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			hpe := c.res.HasPartialError()
+			assert.Equal(tt, c.expect, hpe)
+		})
+	}
+}
+
+func Test_TweetComplianceStreamOutput_Unmarshal(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		expect func(asst *assert.Assertions, o *types.TweetComplianceStreamOutput)
+	}{
+		{
+			name: "delete",
+			body: `{"data":{"delete":{"tweet":{"id":"1","author_id":"2"},"event_at":"2026-01-02T03:04:05.000Z"}}}`,
+			expect: func(asst *assert.Assertions, o *types.TweetComplianceStreamOutput) {
+				asst.NotNil(o.Data.Delete)
+				asst.Equal("1", *o.Data.Delete.Tweet.ID)
+				asst.Equal("2", *o.Data.Delete.Tweet.AuthorID)
+				asst.NotNil(o.Data.Delete.EventAt)
+				asst.Nil(o.Data.Withheld)
+			},
+		},
+		{
+			name: "withheld",
+			body: `{"data":{"withheld":{"tweet":{"id":"1","author_id":"2"},"withheld_in_countries":["DE","FR"],"event_at":"2026-01-02T03:04:05.000Z"}}}`,
+			expect: func(asst *assert.Assertions, o *types.TweetComplianceStreamOutput) {
+				asst.NotNil(o.Data.Withheld)
+				asst.Equal([]string{"DE", "FR"}, o.Data.Withheld.WithheldInCountries)
+				asst.Nil(o.Data.Delete)
+			},
+		},
+		{
+			name: "scrub_geo",
+			body: `{"data":{"scrub_geo":{"tweet":{"id":"1","author_id":"2"},"event_at":"2026-01-02T03:04:05.000Z"}}}`,
+			expect: func(asst *assert.Assertions, o *types.TweetComplianceStreamOutput) {
+				asst.NotNil(o.Data.ScrubGeo)
+				asst.Equal("1", *o.Data.ScrubGeo.Tweet.ID)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			o := &types.TweetComplianceStreamOutput{}
+			asst.NoError(json.Unmarshal([]byte(c.body), o))
+			c.expect(asst, o)
+		})
+	}
+}
+
+func Test_UserComplianceStreamOutput_Unmarshal(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		expect func(asst *assert.Assertions, o *types.UserComplianceStreamOutput)
+	}{
+		{
+			name: "user_suspend",
+			body: `{"data":{"user_suspend":{"user":{"id":"1"},"event_at":"2026-01-02T03:04:05.000Z"}}}`,
+			expect: func(asst *assert.Assertions, o *types.UserComplianceStreamOutput) {
+				asst.NotNil(o.Data.UserSuspend)
+				asst.Equal("1", *o.Data.UserSuspend.User.ID)
+			},
+		},
+		{
+			name: "user_protect",
+			body: `{"data":{"user_protect":{"user":{"id":"1"},"event_at":"2026-01-02T03:04:05.000Z"}}}`,
+			expect: func(asst *assert.Assertions, o *types.UserComplianceStreamOutput) {
+				asst.NotNil(o.Data.UserProtect)
+				asst.Nil(o.Data.UserSuspend)
+			},
+		},
+		{
+			name: "user_delete",
+			body: `{"data":{"user_delete":{"user":{"id":"1"},"event_at":"2026-01-02T03:04:05.000Z"}}}`,
+			expect: func(asst *assert.Assertions, o *types.UserComplianceStreamOutput) {
+				asst.NotNil(o.Data.UserDelete)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			o := &types.UserComplianceStreamOutput{}
+			asst.NoError(json.Unmarshal([]byte(c.body), o))
+			c.expect(asst, o)
+		})
+	}
+}
+
+func Test_LikesComplianceStreamOutput_Unmarshal(t *testing.T) {
+	asst := assert.New(t)
+	body := `{"data":{"delete":{"favorite":{"id":"1","user_id":"2"},"event_at":"2026-01-02T03:04:05.000Z"}}}`
+
+	o := &types.LikesComplianceStreamOutput{}
+	asst.NoError(json.Unmarshal([]byte(body), o))
+	asst.NotNil(o.Data.Delete)
+	asst.Equal("1", *o.Data.Delete.Favorite.ID)
+	asst.Equal("2", *o.Data.Delete.Favorite.UserID)
+}
+
+func Test_TweetLabelStreamOutput_Unmarshal(t *testing.T) {
+	cases := []struct {
+		name        string
+		body        string
+		application resources.TweetLabelApplication
+	}{
+		{
+			name:        "apply",
+			body:        `{"data":{"public_tweet_notice":{"tweet":{"id":"1","author_id":"2"},"event_type":"misleading","application":"apply","event_at":"2026-01-02T03:04:05.000Z"}}}`,
+			application: resources.TweetLabelApplicationApply,
+		},
+		{
+			name:        "remove",
+			body:        `{"data":{"public_tweet_unviewable":{"tweet":{"id":"1","author_id":"2"},"event_type":"misleading","application":"remove","event_at":"2026-01-02T03:04:05.000Z"}}}`,
+			application: resources.TweetLabelApplicationRemove,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			o := &types.TweetLabelStreamOutput{}
+			asst.NoError(json.Unmarshal([]byte(c.body), o))
+			label := o.Data.Label()
+			asst.NotNil(label)
+			asst.Equal(c.application, label.Application)
+			asst.Equal("1", *label.Tweet.ID)
+		})
+	}
+}
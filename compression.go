@@ -0,0 +1,131 @@
+package gotwi
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// TransferMetrics holds the number of bytes of response bodies received by a client with gzip enabled.
+// It is shared by the Client and the TypedClients created from it, and is safe for concurrent use.
+type TransferMetrics struct {
+	compressed   atomic.Int64
+	uncompressed atomic.Int64
+}
+
+// CompressedBytes returns the number of bytes received over the wire.
+// For a response that is not compressed, it is the same as the uncompressed size.
+func (m *TransferMetrics) CompressedBytes() int64 {
+	if m == nil {
+		return 0
+	}
+	return m.compressed.Load()
+}
+
+// UncompressedBytes returns the number of bytes after decompression.
+func (m *TransferMetrics) UncompressedBytes() int64 {
+	if m == nil {
+		return 0
+	}
+	return m.uncompressed.Load()
+}
+
+// Reset sets both counters to zero.
+func (m *TransferMetrics) Reset() {
+	if m == nil {
+		return
+	}
+	m.compressed.Store(0)
+	m.uncompressed.Store(0)
+}
+
+// setAcceptEncoding requests a gzip-compressed response.
+// Setting the header explicitly disables the transparent decompression of http.Transport,
+// so the response must be decoded with decodeResponseBody.
+func setAcceptEncoding(req *http.Request) {
+	req.Header.Set("Accept-Encoding", "gzip")
+}
+
+// decodeResponseBody replaces the body of res with a reader that decompresses it if it is gzip-compressed,
+// and counts the bytes before and after decompression into m.
+func decodeResponseBody(res *http.Response, m *TransferMetrics) {
+	if res == nil || res.Body == nil {
+		return
+	}
+
+	wire := &countingReader{r: res.Body, n: compressedCounter(m)}
+	if !strings.EqualFold(res.Header.Get("Content-Encoding"), "gzip") {
+		wire.also = uncompressedCounter(m)
+		res.Body = &readCloser{Reader: wire, closer: res.Body}
+		return
+	}
+
+	res.Body = &readCloser{
+		Reader: &countingReader{r: &lazyGzipReader{r: wire}, n: uncompressedCounter(m)},
+		closer: res.Body,
+	}
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+}
+
+func compressedCounter(m *TransferMetrics) *atomic.Int64 {
+	if m == nil {
+		return nil
+	}
+	return &m.compressed
+}
+
+func uncompressedCounter(m *TransferMetrics) *atomic.Int64 {
+	if m == nil {
+		return nil
+	}
+	return &m.uncompressed
+}
+
+type countingReader struct {
+	r    io.Reader
+	n    *atomic.Int64
+	also *atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if c.n != nil {
+		c.n.Add(int64(n))
+	}
+	if c.also != nil {
+		c.also.Add(int64(n))
+	}
+	return n, err
+}
+
+// lazyGzipReader creates the gzip.Reader on the first Read,
+// because gzip.NewReader blocks until the gzip header arrives, which may take a while on a stream.
+type lazyGzipReader struct {
+	r  io.Reader
+	zr *gzip.Reader
+}
+
+func (l *lazyGzipReader) Read(p []byte) (int, error) {
+	if l.zr == nil {
+		zr, err := gzip.NewReader(l.r)
+		if err != nil {
+			return 0, err
+		}
+		l.zr = zr
+	}
+	return l.zr.Read(p)
+}
+
+type readCloser struct {
+	io.Reader
+	closer io.Closer
+}
+
+func (r *readCloser) Close() error {
+	return r.closer.Close()
+}
@@ -0,0 +1,143 @@
+package gotwi_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/stretchr/testify/assert"
+)
+
+func gzipBytes(t *testing.T, s string) []byte {
+	buf := new(bytes.Buffer)
+	zw := gzip.NewWriter(buf)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+type streamTestParameter struct {
+	gotwi.MockAPIParameter
+}
+
+func (p streamTestParameter) ResolveEndpoint(endpointBase string) string { return endpointBase }
+
+func Test_Exec_Gzip(t *testing.T) {
+	body := `{"text":"` + strings.Repeat("a", 1000) + `"}`
+	compressed := gzipBytes(t, body)
+
+	cases := []struct {
+		name               string
+		gzip               bool
+		header             map[string][]string
+		body               []byte
+		expectCompressed   int64
+		expectUncompressed int64
+		expectNilMetrics   bool
+	}{
+		{
+			name:               "ok: compressed response",
+			gzip:               true,
+			header:             map[string][]string{"Content-Encoding": {"gzip"}},
+			body:               compressed,
+			expectCompressed:   int64(len(compressed)),
+			expectUncompressed: int64(len(body)),
+		},
+		{
+			name:               "ok: uncompressed response",
+			gzip:               true,
+			body:               []byte(body),
+			expectCompressed:   int64(len(body)),
+			expectUncompressed: int64(len(body)),
+		},
+		{
+			name:             "ok: gzip is not enabled",
+			gzip:             false,
+			body:             []byte(body),
+			expectNilMetrics: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			gc, err := gotwi.NewClientWithAccessToken(&gotwi.NewClientWithAccessTokenInput{
+				AccessToken: "test-token",
+				Gzip:        c.gzip,
+				HTTPClient: gotwi.NewMockHTTPClient(&gotwi.MockInput{
+					ResponseStatusCode: http.StatusOK,
+					ResponseHeader:     c.header,
+					ResponseBody:       io.NopCloser(bytes.NewReader(c.body)),
+				}),
+			})
+			asst.NoError(err)
+
+			req, _ := http.NewRequestWithContext(context.TODO(), http.MethodGet, "https://example.com", nil)
+			res := &gotwi.MockResponse{}
+			non200err, err := gc.Exec(req, res)
+			asst.NoError(err)
+			asst.Nil(non200err)
+			asst.Equal(strings.Repeat("a", 1000), res.Text)
+
+			if c.expectNilMetrics {
+				asst.Nil(gc.TransferMetrics())
+				return
+			}
+
+			asst.Equal("gzip", req.Header.Get("Accept-Encoding"))
+			asst.Equal(c.expectCompressed, gc.TransferMetrics().CompressedBytes())
+			asst.Equal(c.expectUncompressed, gc.TransferMetrics().UncompressedBytes())
+
+			gc.TransferMetrics().Reset()
+			asst.Zero(gc.TransferMetrics().CompressedBytes())
+			asst.Zero(gc.TransferMetrics().UncompressedBytes())
+		})
+	}
+}
+
+func Test_CallStreamAPI_Gzip(t *testing.T) {
+	asst := assert.New(t)
+
+	lines := `{"text":"first"}` + "\r\n" + `{"text":"second"}` + "\r\n"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		asst.Equal("gzip", r.Header.Get("Accept-Encoding"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(gzipBytes(t, lines))
+	}))
+	defer ts.Close()
+
+	gc, err := gotwi.NewClientWithAccessToken(&gotwi.NewClientWithAccessTokenInput{
+		AccessToken: "test-token",
+		Gzip:        true,
+	})
+	asst.NoError(err)
+
+	tc := gotwi.NewTypedClient[*gotwi.MockResponse](gc)
+	st, err := tc.CallStreamAPI(context.Background(), ts.URL, http.MethodGet, &streamTestParameter{})
+	asst.NoError(err)
+	defer st.Stop()
+
+	texts := []string{}
+	for st.Receive() {
+		r, err := st.Read()
+		asst.NoError(err)
+		texts = append(texts, r.Text)
+	}
+
+	asst.NoError(st.Err())
+	asst.Equal([]string{"first", "second"}, texts)
+	asst.Equal(int64(len(lines)), tc.TransferMetrics().UncompressedBytes())
+	asst.Same(gc.TransferMetrics(), tc.TransferMetrics())
+}
@@ -0,0 +1,32 @@
+package connection
+
+import (
+	"context"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/connection/types"
+)
+
+const (
+	listEndpoint             = "https://api.twitter.com/2/connections"
+	deleteAllEndpoint        = "https://api.twitter.com/2/connections/all"
+	deleteByEndpointEndpoint = "https://api.twitter.com/2/connections/:endpoint_id"
+)
+
+// Returns the streaming connections of the authenticated App.
+// https://docs.x.com/x-api/connections/get-connection-history
+func List(ctx context.Context, c gotwi.IClient, p *types.ListInput, opts ...gotwi.CallOption) (*types.ListOutput, error) {
+	return gotwi.Do[*types.ListOutput](ctx, c, "GET", listEndpoint, p, opts...)
+}
+
+// Terminates all active streaming connections of the authenticated App.
+// https://docs.x.com/x-api/connections/terminate-all-connections
+func DeleteAll(ctx context.Context, c gotwi.IClient, p *types.DeleteAllInput, opts ...gotwi.CallOption) (*types.DeleteAllOutput, error) {
+	return gotwi.Do[*types.DeleteAllOutput](ctx, c, "DELETE", deleteAllEndpoint, p, opts...)
+}
+
+// Terminates all active streaming connections of the authenticated App for the specified endpoint.
+// https://docs.x.com/x-api/connections/terminate-connections-by-endpoint
+func DeleteByEndpoint(ctx context.Context, c gotwi.IClient, p *types.DeleteByEndpointInput, opts ...gotwi.CallOption) (*types.DeleteByEndpointOutput, error) {
+	return gotwi.Do[*types.DeleteByEndpointOutput](ctx, c, "DELETE", deleteByEndpointEndpoint, p, opts...)
+}
@@ -0,0 +1,151 @@
+package connection_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/connection"
+	"github.com/michimani/gotwi/connection/types"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_List(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *types.ListInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			params: &types.ListInput{},
+		},
+		{
+			name:    "error",
+			params:  &types.ListInput{},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "error: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					assert.Equal(tt, "GET", method)
+					return c.mockErr
+				},
+			})
+
+			res, err := connection.List(context.Background(), mockClient, c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, res)
+		})
+	}
+}
+
+func Test_DeleteAll(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *types.DeleteAllInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			params: &types.DeleteAllInput{},
+		},
+		{
+			name:    "error",
+			params:  &types.DeleteAllInput{},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "error: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					assert.Equal(tt, "DELETE", method)
+					return c.mockErr
+				},
+			})
+
+			res, err := connection.DeleteAll(context.Background(), mockClient, c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, res)
+		})
+	}
+}
+
+func Test_DeleteByEndpoint(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *types.DeleteByEndpointInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			params: &types.DeleteByEndpointInput{EndpointID: types.EndpointIDFilteredStream},
+		},
+		{
+			name:    "error",
+			params:  &types.DeleteByEndpointInput{EndpointID: types.EndpointIDFilteredStream},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "error: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					assert.Equal(tt, "DELETE", method)
+					return c.mockErr
+				},
+			})
+
+			res, err := connection.DeleteByEndpoint(context.Background(), mockClient, c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, res)
+		})
+	}
+}
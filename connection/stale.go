@@ -0,0 +1,96 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/connection/types"
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/internal/util"
+)
+
+const connectionIssueTooManyConnections = "TooManyConnections"
+
+// IsTooManyConnections reports whether err is the error returned when a stream is at its connection limit.
+func IsTooManyConnections(err error) bool {
+	var ge *gotwi.GotwiError
+	if !errors.As(err, &ge) || !ge.OnAPI {
+		return false
+	}
+
+	return ge.StatusCode == http.StatusTooManyRequests && ge.ConnectionIssue == connectionIssueTooManyConnections
+}
+
+// ConnectTerminatingStale calls connect, and if it fails with a TooManyConnections error,
+// lists the active connections of the endpoint, terminates them and calls connect once more.
+// A consumer that crashed leaves its connection open until the server notices,
+// so this lets a restarted consumer take over the stream without waiting.
+// Note that the connections endpoints can only terminate every connection of an endpoint at once,
+// so it must be used only for a stream with a single connection.
+// For a partitioned stream, it would terminate the healthy connections of the other partitions,
+// which then terminate each other on reconnection. Call TerminateActiveConnections once before connecting them instead.
+func ConnectTerminatingStale[T util.Response](
+	ctx context.Context,
+	c gotwi.IClient,
+	endpointID types.EndpointID,
+	connect func(ctx context.Context) (*gotwi.StreamClient[T], error),
+) (*gotwi.StreamClient[T], error) {
+	s, err := connect(ctx)
+	if err == nil || !IsTooManyConnections(err) {
+		return s, err
+	}
+
+	terminated, terr := TerminateActiveConnections(ctx, c, endpointID)
+	if terr != nil {
+		return nil, errors.Join(err, terr)
+	}
+	if !terminated {
+		return nil, err
+	}
+
+	return connect(ctx)
+}
+
+// TerminateActiveConnections terminates all the active connections of the endpoint, including every partition.
+// It returns false without terminating if the endpoint has no active connections.
+func TerminateActiveConnections(ctx context.Context, c gotwi.IClient, endpointID types.EndpointID) (bool, error) {
+	hasActive, err := hasActiveConnections(ctx, c, endpointID)
+	if err != nil || !hasActive {
+		return false, err
+	}
+
+	if _, err := DeleteByEndpoint(ctx, c, &types.DeleteByEndpointInput{EndpointID: endpointID}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func hasActiveConnections(ctx context.Context, c gotwi.IClient, endpointID types.EndpointID) (bool, error) {
+	p := &types.ListInput{
+		Status:           types.ConnectionStatusActive,
+		ConnectionFields: fields.ConnectionFieldList{fields.ConnectionFieldEndpointName},
+		MaxResults:       100,
+	}
+
+	for {
+		res, err := List(ctx, c, p)
+		if err != nil {
+			return false, err
+		}
+
+		for _, conn := range res.Data {
+			// a connection without the endpoint name may belong to another stream, so it is not counted
+			if conn.EndpointName != nil && *conn.EndpointName == string(endpointID) {
+				return true, nil
+			}
+		}
+
+		if res.Meta.NextToken == nil || *res.Meta.NextToken == "" {
+			return false, nil
+		}
+		p.PaginationToken = *res.Meta.NextToken
+	}
+}
@@ -0,0 +1,229 @@
+package connection_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/connection"
+	"github.com/michimani/gotwi/connection/types"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func tooManyConnectionsErr() error {
+	return &gotwi.GotwiError{
+		OnAPI: true,
+		Non2XXError: resources.Non2XXError{
+			StatusCode:      http.StatusTooManyRequests,
+			Title:           "ConnectionException",
+			ConnectionIssue: "TooManyConnections",
+		},
+	}
+}
+
+func Test_IsTooManyConnections(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		expect bool
+	}{
+		{
+			name:   "too many connections",
+			err:    tooManyConnectionsErr(),
+			expect: true,
+		},
+		{
+			name: "rate limit",
+			err: &gotwi.GotwiError{
+				OnAPI:       true,
+				Non2XXError: resources.Non2XXError{StatusCode: http.StatusTooManyRequests},
+			},
+			expect: false,
+		},
+		{
+			name:   "not an API error",
+			err:    errors.New("error"),
+			expect: false,
+		},
+		{
+			name:   "nil",
+			err:    nil,
+			expect: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, connection.IsTooManyConnections(c.err))
+		})
+	}
+}
+
+func Test_ConnectTerminatingStale(t *testing.T) {
+	filteredStream := string(types.EndpointIDFilteredStream)
+	sampleStream := string(types.EndpointIDSampleStream)
+
+	cases := []struct {
+		name             string
+		connectErrs      []error
+		connections      []resources.Connection
+		listErr          error
+		wantErr          bool
+		expectConnects   int
+		expectTerminated bool
+	}{
+		{
+			name:           "ok: connected",
+			connectErrs:    []error{nil},
+			expectConnects: 1,
+		},
+		{
+			name:             "ok: stale connection is terminated",
+			connectErrs:      []error{tooManyConnectionsErr(), nil},
+			connections:      []resources.Connection{{EndpointName: &filteredStream}},
+			expectConnects:   2,
+			expectTerminated: true,
+		},
+		{
+			name:           "error: other error is not retried",
+			connectErrs:    []error{errors.New("error")},
+			wantErr:        true,
+			expectConnects: 1,
+		},
+		{
+			name:           "error: no active connections of the endpoint",
+			connectErrs:    []error{tooManyConnectionsErr()},
+			connections:    []resources.Connection{{EndpointName: &sampleStream}},
+			wantErr:        true,
+			expectConnects: 1,
+		},
+		{
+			name:           "error: failed to list connections",
+			connectErrs:    []error{tooManyConnectionsErr()},
+			listErr:        errors.New("list error"),
+			wantErr:        true,
+			expectConnects: 1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			terminated := false
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					switch res := i.(type) {
+					case *types.ListOutput:
+						if c.listErr != nil {
+							return c.listErr
+						}
+						res.Data = c.connections
+					case *types.DeleteByEndpointOutput:
+						asst.Equal(types.EndpointIDFilteredStream, p.(*types.DeleteByEndpointInput).EndpointID)
+						terminated = true
+					}
+					return nil
+				},
+			})
+
+			connects := 0
+			s, err := connection.ConnectTerminatingStale(context.Background(), mockClient, types.EndpointIDFilteredStream,
+				func(ctx context.Context) (*gotwi.StreamClient[*gotwi.MockAPIResponse], error) {
+					err := c.connectErrs[connects]
+					connects++
+					if err != nil {
+						return nil, err
+					}
+					return &gotwi.StreamClient[*gotwi.MockAPIResponse]{}, nil
+				})
+
+			asst.Equal(c.expectConnects, connects)
+			asst.Equal(c.expectTerminated, terminated)
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(s)
+				return
+			}
+
+			asst.NoError(err)
+			asst.NotNil(s)
+		})
+	}
+}
+
+func Test_TerminateActiveConnections(t *testing.T) {
+	filteredStream := string(types.EndpointIDFilteredStream)
+	sampleStream := string(types.EndpointIDSampleStream)
+
+	cases := []struct {
+		name        string
+		connections []resources.Connection
+		listErr     error
+		deleteErr   error
+		wantErr     bool
+		expect      bool
+	}{
+		{
+			name:        "ok: terminated",
+			connections: []resources.Connection{{EndpointName: &sampleStream}, {EndpointName: &filteredStream}},
+			expect:      true,
+		},
+		{
+			name:        "ok: no active connections of the endpoint",
+			connections: []resources.Connection{{EndpointName: &sampleStream}},
+			expect:      false,
+		},
+		{
+			name:        "ok: connection without the endpoint name is not counted",
+			connections: []resources.Connection{{}},
+			expect:      false,
+		},
+		{
+			name:    "error: failed to list connections",
+			listErr: errors.New("list error"),
+			wantErr: true,
+		},
+		{
+			name:        "error: failed to terminate",
+			connections: []resources.Connection{{EndpointName: &filteredStream}},
+			deleteErr:   errors.New("delete error"),
+			wantErr:     true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					switch res := i.(type) {
+					case *types.ListOutput:
+						if c.listErr != nil {
+							return c.listErr
+						}
+						res.Data = c.connections
+					case *types.DeleteByEndpointOutput:
+						return c.deleteErr
+					}
+					return nil
+				},
+			})
+
+			terminated, err := connection.TerminateActiveConnections(context.Background(), mockClient, types.EndpointIDFilteredStream)
+			if c.wantErr {
+				asst.Error(err)
+				asst.False(terminated)
+				return
+			}
+
+			asst.NoError(err)
+			asst.Equal(c.expect, terminated)
+		})
+	}
+}
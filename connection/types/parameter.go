@@ -0,0 +1,161 @@
+package types
+
+import (
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/internal/util"
+)
+
+// EndpointID identifies a streaming endpoint in the connections endpoints.
+type EndpointID string
+
+const (
+	EndpointIDFilteredStream         EndpointID = "filtered_stream"
+	EndpointIDSampleStream           EndpointID = "sample_stream"
+	EndpointIDSample10Stream         EndpointID = "sample10_stream"
+	EndpointIDFirehoseStream         EndpointID = "firehose_stream"
+	EndpointIDTweetsComplianceStream EndpointID = "tweets_compliance_stream"
+	EndpointIDUsersComplianceStream  EndpointID = "users_compliance_stream"
+	EndpointIDLikesComplianceStream  EndpointID = "likes_compliance_stream"
+	EndpointIDTweetLabelStream       EndpointID = "tweet_label_stream"
+)
+
+type ConnectionStatus string
+
+const (
+	ConnectionStatusActive   ConnectionStatus = "active"
+	ConnectionStatusInactive ConnectionStatus = "inactive"
+	ConnectionStatusAll      ConnectionStatus = "all"
+)
+
+type ListMaxResults int
+
+func (m ListMaxResults) Valid() bool {
+	return m >= 1 && m <= 100
+}
+
+func (m ListMaxResults) String() string {
+	return strconv.Itoa(int(m))
+}
+
+type ListInput struct {
+	accessToken string
+
+	// Query parameters
+	Status           ConnectionStatus
+	ConnectionFields fields.ConnectionFieldList
+	MaxResults       ListMaxResults
+	PaginationToken  string
+}
+
+var listQueryParameters = map[string]struct{}{
+	"status":            {},
+	"connection.fields": {},
+	"max_results":       {},
+	"pagination_token":  {},
+}
+
+func (p *ListInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *ListInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *ListInput) ResolveEndpoint(endpointBase string) string {
+	endpoint := endpointBase
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, listQueryParameters)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func (p *ListInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *ListInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+	m = fields.SetFieldsParams(m, p.ConnectionFields)
+
+	if p.Status != "" {
+		m["status"] = string(p.Status)
+	}
+
+	if p.MaxResults.Valid() {
+		m["max_results"] = p.MaxResults.String()
+	}
+
+	if p.PaginationToken != "" {
+		m["pagination_token"] = p.PaginationToken
+	}
+
+	return m
+}
+
+type DeleteAllInput struct {
+	accessToken string
+}
+
+func (p *DeleteAllInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *DeleteAllInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *DeleteAllInput) ResolveEndpoint(endpointBase string) string {
+	return endpointBase
+}
+
+func (p *DeleteAllInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *DeleteAllInput) ParameterMap() map[string]string {
+	return map[string]string{}
+}
+
+type DeleteByEndpointInput struct {
+	accessToken string
+
+	// Path parameter
+	EndpointID EndpointID // required
+}
+
+func (p *DeleteByEndpointInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *DeleteByEndpointInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *DeleteByEndpointInput) ResolveEndpoint(endpointBase string) string {
+	if p.EndpointID == "" {
+		return ""
+	}
+
+	escaped := url.QueryEscape(string(p.EndpointID))
+	endpoint := strings.Replace(endpointBase, ":endpoint_id", escaped, 1)
+
+	return endpoint
+}
+
+func (p *DeleteByEndpointInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *DeleteByEndpointInput) ParameterMap() map[string]string {
+	return map[string]string{}
+}
@@ -0,0 +1,75 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/michimani/gotwi/connection/types"
+	"github.com/michimani/gotwi/fields"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ListInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint"
+
+	cases := []struct {
+		name   string
+		params *types.ListInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.ListInput{},
+			expect: endpoint,
+		},
+		{
+			name: "all query parameters",
+			params: &types.ListInput{
+				Status:           types.ConnectionStatusActive,
+				ConnectionFields: fields.ConnectionFieldList{fields.ConnectionFieldEndpointName, fields.ConnectionFieldID},
+				MaxResults:       50,
+				PaginationToken:  "token",
+			},
+			expect: endpoint + "?connection.fields=endpoint_name%2Cid&max_results=50&pagination_token=token&status=active",
+		},
+		{
+			name:   "invalid max_results",
+			params: &types.ListInput{MaxResults: 101},
+			expect: endpoint,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			ep := c.params.ResolveEndpoint(endpoint)
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
+
+func Test_DeleteByEndpointInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint/:endpoint_id"
+
+	cases := []struct {
+		name   string
+		params *types.DeleteByEndpointInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.DeleteByEndpointInput{EndpointID: types.EndpointIDFilteredStream},
+			expect: "test/endpoint/filtered_stream",
+		},
+		{
+			name:   "endpoint id is empty",
+			params: &types.DeleteByEndpointInput{},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			ep := c.params.ResolveEndpoint(endpoint)
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
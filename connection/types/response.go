@@ -0,0 +1,39 @@
+package types
+
+import "github.com/michimani/gotwi/resources"
+
+type ListOutput struct {
+	Data   []resources.Connection   `json:"data"`
+	Meta   resources.PaginationMeta `json:"meta"`
+	Errors []resources.PartialError `json:"errors"`
+}
+
+func (r *ListOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+type DeleteAllOutput struct {
+	Data struct {
+		SuccessfulKills *int                             `json:"successful_kills"`
+		FailedKills     *int                             `json:"failed_kills"`
+		Results         []resources.ConnectionKillResult `json:"results,omitempty"`
+	} `json:"data"`
+	Errors []resources.PartialError `json:"errors"`
+}
+
+func (r *DeleteAllOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+type DeleteByEndpointOutput struct {
+	Data struct {
+		SuccessfulKills *int                             `json:"successful_kills"`
+		FailedKills     *int                             `json:"failed_kills"`
+		Results         []resources.ConnectionKillResult `json:"results,omitempty"`
+	} `json:"data"`
+	Errors []resources.PartialError `json:"errors"`
+}
+
+func (r *DeleteByEndpointOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
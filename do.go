@@ -0,0 +1,143 @@
+package gotwi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/michimani/gotwi/internal/gotwierrors"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/resources"
+)
+
+// Do calls the endpoint with the input, and returns the response decoded into a new O.
+// O must be a pointer type, e.g. *types.ListOutput.
+// It returns an error without calling the API if in is nil.
+// The options are applied to this call only.
+func Do[O util.Response](ctx context.Context, c IClient, method, endpoint string, in util.Parameters, opts ...CallOption) (O, error) {
+	var zero O
+	if isNilParameters(in) {
+		return zero, fmt.Errorf(gotwierrors.ErrorParametersNil, endpoint)
+	}
+
+	t := reflect.TypeOf((*O)(nil)).Elem()
+	if t.Kind() != reflect.Pointer {
+		return zero, fmt.Errorf("output type %s is not a pointer", t)
+	}
+
+	res := reflect.New(t.Elem()).Interface().(O)
+	ctx = WithCallOptions(ctx, opts...)
+	if err := c.CallAPI(ctx, endpoint, method, in, res); err != nil {
+		return zero, err
+	}
+
+	return res, nil
+}
+
+func isNilParameters(in util.Parameters) bool {
+	if in == nil {
+		return true
+	}
+	v := reflect.ValueOf(in)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// CustomInput is the input for calling an endpoint that gotwi does not wrap yet.
+type CustomInput struct {
+	accessToken string
+
+	// PathParameters replaces each ":name" in the endpoint with the escaped value.
+	// If any value is empty, the endpoint cannot be resolved.
+	PathParameters map[string]string
+
+	// QueryParameters is added to the endpoint as the query string.
+	QueryParameters map[string]string
+
+	// JSONBody is marshaled to JSON and sent as the request body if it is not nil.
+	JSONBody any
+}
+
+func (p *CustomInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *CustomInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *CustomInput) ResolveEndpoint(endpointBase string) string {
+	endpoint := endpointBase
+
+	// replace longer names first, so that ":id" does not break ":id_str"
+	names := make([]string, 0, len(p.PathParameters))
+	for name := range p.PathParameters {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	for _, name := range names {
+		v := p.PathParameters[name]
+		if v == "" {
+			return ""
+		}
+		endpoint = strings.ReplaceAll(endpoint, ":"+name, url.QueryEscape(v))
+	}
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		includes := make(map[string]struct{}, len(pm))
+		for k := range pm {
+			includes[k] = struct{}{}
+		}
+		endpoint += "?" + util.QueryString(pm, includes)
+	}
+
+	return endpoint
+}
+
+func (p *CustomInput) Body() (io.Reader, error) {
+	if p.JSONBody == nil {
+		return nil, nil
+	}
+
+	json, err := json.Marshal(p.JSONBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.NewReader(string(json)), nil
+}
+
+func (p *CustomInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+	for k, v := range p.QueryParameters {
+		m[k] = v
+	}
+	return m
+}
+
+// CustomOutput is the output of an endpoint that gotwi does not wrap yet.
+// Each top-level object of the response is kept as raw JSON.
+type CustomOutput struct {
+	Data     json.RawMessage          `json:"data"`
+	Includes json.RawMessage          `json:"includes,omitempty"`
+	Meta     json.RawMessage          `json:"meta,omitempty"`
+	Errors   []resources.PartialError `json:"errors,omitempty"`
+}
+
+func (r *CustomOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+// DecodeData decodes the data object of the response into v.
+func (r *CustomOutput) DecodeData(v any) error {
+	if len(r.Data) == 0 {
+		return errors.New("response has no data")
+	}
+	return json.Unmarshal(r.Data, v)
+}
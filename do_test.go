@@ -0,0 +1,142 @@
+package gotwi_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type doTestInput struct {
+	gotwi.MockAPIParameter
+}
+
+func Test_Do(t *testing.T) {
+	cases := []struct {
+		name      string
+		in        util.Parameters
+		mockErr   error
+		wantErr   bool
+		expectErr string
+	}{
+		{
+			name: "ok",
+			in:   &doTestInput{},
+		},
+		{
+			name:    "ng: api error",
+			in:      &doTestInput{},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:      "ng: typed nil input",
+			in:        (*doTestInput)(nil),
+			wantErr:   true,
+			expectErr: "Parameter for test/endpoint is nil.",
+		},
+		{
+			name:      "ng: nil input",
+			in:        nil,
+			wantErr:   true,
+			expectErr: "Parameter for test/endpoint is nil.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			called := false
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					called = true
+					assert.Equal(tt, "test/endpoint", endpoint)
+					assert.Equal(tt, "POST", method)
+					i.(*gotwi.MockResponse).Text = "ok"
+					return c.mockErr
+				},
+			})
+
+			res, err := gotwi.Do[*gotwi.MockResponse](context.Background(), mockClient, "POST", "test/endpoint", c.in)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				if c.expectErr != "" {
+					assert.EqualError(tt, err, c.expectErr)
+					assert.False(tt, called)
+				}
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.Equal(tt, "ok", res.Text)
+		})
+	}
+}
+
+func Test_Do_NonPointerOutput(t *testing.T) {
+	_, err := gotwi.Do[gotwi.CustomOutputValue](context.Background(), &gotwi.MockGotwiClient{}, "GET", "test/endpoint", &doTestInput{})
+	assert.Error(t, err)
+}
+
+func Test_CustomInput_ResolveEndpoint(t *testing.T) {
+	cases := []struct {
+		name     string
+		in       *gotwi.CustomInput
+		endpoint string
+		expect   string
+	}{
+		{
+			name:     "ok: no parameters",
+			in:       &gotwi.CustomInput{},
+			endpoint: "test/endpoint",
+			expect:   "test/endpoint",
+		},
+		{
+			name: "ok: path and query parameters",
+			in: &gotwi.CustomInput{
+				PathParameters:  map[string]string{"id": "a b", "id_str": "x"},
+				QueryParameters: map[string]string{"max_results": "10", "expansions": "author_id"},
+			},
+			endpoint: "test/:id/endpoint/:id_str",
+			expect:   "test/a+b/endpoint/x?expansions=author_id&max_results=10",
+		},
+		{
+			name:     "ng: empty path parameter",
+			in:       &gotwi.CustomInput{PathParameters: map[string]string{"id": ""}},
+			endpoint: "test/:id",
+			expect:   "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, c.in.ResolveEndpoint(c.endpoint))
+		})
+	}
+}
+
+func Test_CustomInput_Body(t *testing.T) {
+	r, err := (&gotwi.CustomInput{}).Body()
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+
+	r, err = (&gotwi.CustomInput{JSONBody: map[string]string{"text": "hello"}}).Body()
+	assert.NoError(t, err)
+	b, _ := io.ReadAll(r)
+	assert.Equal(t, `{"text":"hello"}`, string(b))
+}
+
+func Test_CustomOutput_DecodeData(t *testing.T) {
+	o := &gotwi.CustomOutput{Data: []byte(`{"id":"1"}`)}
+	v := struct {
+		ID string `json:"id"`
+	}{}
+	assert.NoError(t, o.DecodeData(&v))
+	assert.Equal(t, "1", v.ID)
+
+	assert.Error(t, (&gotwi.CustomOutput{}).DecodeData(&v))
+}
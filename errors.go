@@ -78,6 +78,9 @@ func non2XXErrorSummary(e *resources.Non2XXError) string {
 	if e.Type != "" {
 		summary = append(summary, fmt.Sprintf("type=\"%s\"", e.Type))
 	}
+	if e.ConnectionIssue != "" {
+		summary = append(summary, fmt.Sprintf("connectionIssue=\"%s\"", e.ConnectionIssue))
+	}
 
 	ercnt := 1
 	for _, er := range e.APIErrors {
@@ -1,5 +1,7 @@
 package gotwi
 
+import "time"
+
 type MockResponse struct {
 	Text string `json:"text"`
 }
@@ -8,6 +10,10 @@ func (c *Client) SetDebugMode(d bool) {
 	c.debug = d
 }
 
+func (c *Client) StreamIdleTimeout() time.Duration {
+	return c.streamIdleTimeout
+}
+
 func (m *MockResponse) HasPartialError() bool { return true }
 
 var (
@@ -24,5 +30,11 @@ var (
 	ExportWrapWithAPIErr     = wrapWithAPIErr
 	ExportNon2XXErrorSummary = non2XXErrorSummary
 
-	ExportNewStreamClient = newStreamClient[*MockResponse]
+	ExportNewStreamClient      = newStreamClient[*MockResponse]
+	ExportNewIdleTimeoutReader = newIdleTimeoutReader
 )
+
+// CustomOutputValue is a non-pointer response type, which cannot be used as the output of Do.
+type CustomOutputValue struct{}
+
+func (CustomOutputValue) HasPartialError() bool { return false }
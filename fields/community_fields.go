@@ -0,0 +1,36 @@
+package fields
+
+type CommunityField string
+
+const (
+	CommunityFieldAccess      CommunityField = "access"
+	CommunityFieldCreatedAt   CommunityField = "created_at"
+	CommunityFieldDescription CommunityField = "description"
+	CommunityFieldID          CommunityField = "id"
+	CommunityFieldJoinPolicy  CommunityField = "join_policy"
+	CommunityFieldMemberCount CommunityField = "member_count"
+	CommunityFieldName        CommunityField = "name"
+)
+
+func (f CommunityField) String() string {
+	return string(f)
+}
+
+type CommunityFieldList []CommunityField
+
+func (fl CommunityFieldList) FieldsName() string {
+	return "community.fields"
+}
+
+func (fl CommunityFieldList) Values() []string {
+	if fl == nil {
+		return []string{}
+	}
+
+	s := []string{}
+	for _, f := range fl {
+		s = append(s, f.String())
+	}
+
+	return s
+}
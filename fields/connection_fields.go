@@ -0,0 +1,35 @@
+package fields
+
+type ConnectionField string
+
+const (
+	ConnectionFieldClientIP         ConnectionField = "client_ip"
+	ConnectionFieldConnectedAt      ConnectionField = "connected_at"
+	ConnectionFieldDisconnectReason ConnectionField = "disconnect_reason"
+	ConnectionFieldDisconnectedAt   ConnectionField = "disconnected_at"
+	ConnectionFieldEndpointName     ConnectionField = "endpoint_name"
+	ConnectionFieldID               ConnectionField = "id"
+)
+
+func (f ConnectionField) String() string {
+	return string(f)
+}
+
+type ConnectionFieldList []ConnectionField
+
+func (fl ConnectionFieldList) FieldsName() string {
+	return "connection.fields"
+}
+
+func (fl ConnectionFieldList) Values() []string {
+	if fl == nil {
+		return []string{}
+	}
+
+	s := []string{}
+	for _, f := range fl {
+		s = append(s, f.String())
+	}
+
+	return s
+}
@@ -0,0 +1,35 @@
+package fields
+
+type UsageField string
+
+const (
+	UsageFieldCapResetDay         UsageField = "cap_reset_day"
+	UsageFieldDailyClientAppUsage UsageField = "daily_client_app_usage"
+	UsageFieldDailyProjectUsage   UsageField = "daily_project_usage"
+	UsageFieldProjectCap          UsageField = "project_cap"
+	UsageFieldProjectID           UsageField = "project_id"
+	UsageFieldProjectUsage        UsageField = "project_usage"
+)
+
+func (f UsageField) String() string {
+	return string(f)
+}
+
+type UsageFieldList []UsageField
+
+func (fl UsageFieldList) FieldsName() string {
+	return "usage.fields"
+}
+
+func (fl UsageFieldList) Values() []string {
+	if fl == nil {
+		return []string{}
+	}
+
+	s := []string{}
+	for _, f := range fl {
+		s = append(s, f.String())
+	}
+
+	return s
+}
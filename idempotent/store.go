@@ -0,0 +1,88 @@
+package idempotent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOutcomeNotFound is returned by Store when no outcome is recorded for the key.
+var ErrOutcomeNotFound = errors.New("outcome is not found")
+
+type Operation string
+
+const (
+	OperationCreateTweet     Operation = "create_tweet"
+	OperationLike            Operation = "like"
+	OperationRetweet         Operation = "retweet"
+	OperationCreateFollowing Operation = "create_following"
+)
+
+// Outcome is the recorded result of a write operation.
+type Outcome struct {
+	// Idempotency key of the operation.
+	Key string
+
+	Operation Operation
+
+	// True if the API reported that the operation had already been done (e.g. error code 139, 160, 327, 187).
+	AlreadyDone bool
+
+	// True if the operation was confirmed by reading the state after an ambiguous failure.
+	Verified bool
+
+	// ID of the created Tweet. It is set only for OperationCreateTweet, and may be empty when AlreadyDone is true.
+	TweetID string
+
+	// True if the follow request is pending. It is set only for OperationCreateFollowing.
+	PendingFollow bool
+
+	RecordedAt time.Time
+}
+
+// Store records the outcomes of write operations.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the outcome recorded for the key. It returns ErrOutcomeNotFound if there is no outcome.
+	Get(ctx context.Context, key string) (*Outcome, error)
+
+	// Put records the outcome.
+	Put(ctx context.Context, o *Outcome) error
+}
+
+// MemoryStore is a Store that keeps the outcomes in memory.
+type MemoryStore struct {
+	mu       sync.Mutex
+	outcomes map[string]Outcome
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		outcomes: map[string]Outcome{},
+	}
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (*Outcome, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.outcomes[key]
+	if !ok {
+		return nil, ErrOutcomeNotFound
+	}
+
+	return &o, nil
+}
+
+func (s *MemoryStore) Put(ctx context.Context, o *Outcome) error {
+	if o == nil {
+		return errors.New("Outcome is nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outcomes[o.Key] = *o
+
+	return nil
+}
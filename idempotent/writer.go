@@ -0,0 +1,410 @@
+package idempotent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/posting"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/like"
+	liketypes "github.com/michimani/gotwi/tweet/like/types"
+	"github.com/michimani/gotwi/tweet/managetweet"
+	managetweettypes "github.com/michimani/gotwi/tweet/managetweet/types"
+	"github.com/michimani/gotwi/tweet/retweet"
+	retweettypes "github.com/michimani/gotwi/tweet/retweet/types"
+	"github.com/michimani/gotwi/tweet/timeline"
+	timelinetypes "github.com/michimani/gotwi/tweet/timeline/types"
+	"github.com/michimani/gotwi/user/follow"
+	followtypes "github.com/michimani/gotwi/user/follow/types"
+	"github.com/michimani/gotwi/user/userlookup"
+	userlookuptypes "github.com/michimani/gotwi/user/userlookup/types"
+)
+
+const (
+	defaultMaxAttempts = 2
+
+	// Tweets created after this duration before sending are checked for verifying.
+	verifyClockSkew = time.Minute
+
+	// Maximum number of pages searched for verifying. The lists are ordered by recency,
+	// so an operation just done is found in the first page in most cases.
+	// If it is not found, the operation is sent again, and the API answers that it has already been done.
+	verifyMaxPages = 10
+)
+
+const (
+	errorCodeAlreadyFavorited     resources.ErrorCode = 139
+	errorCodeAlreadyRequestFollow resources.ErrorCode = 160
+	errorCodeAlreadyRetweeted     resources.ErrorCode = 327
+)
+
+// NewWriterInput is struct for creating a Writer.
+type NewWriterInput struct {
+	Client gotwi.IClient // required
+
+	// Store for the outcomes. Default is MemoryStore.
+	Store Store
+
+	// Maximum number of sending for each operation. Default is 2.
+	MaxAttempts int
+}
+
+// ErrOperationMismatch is returned by Writer when the outcome recorded for the key is of another operation.
+var ErrOperationMismatch = errors.New("idempotency key is used for another operation")
+
+// Writer calls write endpoints with idempotency keys.
+// An operation whose outcome has been recorded for the key is not sent again.
+// Calls with the same key are serialized within the Writer, so the operation is sent only once even if they run concurrently.
+// Writers sharing a Store in different processes are not serialized.
+// When sending fails ambiguously (e.g. network error, timeout or 5XX status),
+// Writer verifies the state before sending again.
+type Writer struct {
+	client      gotwi.IClient
+	store       Store
+	maxAttempts int
+	now         func() time.Time
+
+	mu     sync.Mutex
+	userID string
+
+	// locks serializes the operations with the same key.
+	locksMu sync.Mutex
+	locks   map[string]*keyLock
+}
+
+type keyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Result is the result of an idempotent write operation.
+type Result struct {
+	Outcome
+
+	// True if the outcome had been recorded by a previous call, and the API was not called.
+	Replayed bool
+}
+
+func NewWriter(in *NewWriterInput) (*Writer, error) {
+	if in == nil {
+		return nil, errors.New("NewWriterInput is nil")
+	}
+
+	if in.Client == nil {
+		return nil, errors.New("Client is required")
+	}
+
+	w := &Writer{
+		client:      in.Client,
+		store:       in.Store,
+		maxAttempts: in.MaxAttempts,
+		now:         time.Now,
+		locks:       map[string]*keyLock{},
+	}
+
+	if w.store == nil {
+		w.store = NewMemoryStore()
+	}
+	if w.maxAttempts <= 0 {
+		w.maxAttempts = defaultMaxAttempts
+	}
+
+	return w, nil
+}
+
+// CreateTweet creates a Tweet with the idempotency key.
+// After an ambiguous failure, the recent Tweets of the authenticated user are searched for the same text.
+func (w *Writer) CreateTweet(ctx context.Context, key string, p *managetweettypes.CreateInput) (*Result, error) {
+	if p == nil {
+		return nil, errors.New("CreateInput is nil")
+	}
+
+	sentAt := w.now()
+	return w.do(ctx, key, OperationCreateTweet, operation{
+		send: func(ctx context.Context) (*Outcome, error) {
+			res, err := managetweet.Create(ctx, w.client, p)
+			if err != nil {
+				return nil, err
+			}
+			return &Outcome{TweetID: gotwi.StringValue(res.Data.ID)}, nil
+		},
+		verify: func(ctx context.Context) (*Outcome, error) {
+			return w.verifyTweet(ctx, gotwi.StringValue(p.Text), sentAt)
+		},
+		alreadyDone: func(ge *gotwi.GotwiError) bool {
+			return posting.IsDuplicate(ge)
+		},
+	})
+}
+
+// Like likes a Tweet with the idempotency key.
+// After an ambiguous failure, the liking users of the Tweet are checked.
+func (w *Writer) Like(ctx context.Context, key string, p *liketypes.CreateInput) (*Result, error) {
+	if p == nil {
+		return nil, errors.New("CreateInput is nil")
+	}
+
+	return w.do(ctx, key, OperationLike, operation{
+		send: func(ctx context.Context) (*Outcome, error) {
+			if _, err := like.Create(ctx, w.client, p); err != nil {
+				return nil, err
+			}
+			return &Outcome{}, nil
+		},
+		verify: func(ctx context.Context) (*Outcome, error) {
+			return searchPages(ctx, func(ctx context.Context, token string) (*Outcome, string, error) {
+				res, err := like.ListUsers(ctx, w.client, &liketypes.ListUsersInput{ID: p.TweetID, MaxResults: 100, PaginationToken: token})
+				if err != nil {
+					return nil, "", err
+				}
+				return outcomeIfContainsUser(res.Data, p.ID), gotwi.StringValue(res.Meta.NextToken), nil
+			})
+		},
+		alreadyDone: func(ge *gotwi.GotwiError) bool {
+			return posting.HasErrorCode(ge, errorCodeAlreadyFavorited)
+		},
+	})
+}
+
+// Retweet retweets a Tweet with the idempotency key.
+// After an ambiguous failure, the retweeting users of the Tweet are checked.
+func (w *Writer) Retweet(ctx context.Context, key string, p *retweettypes.CreateInput) (*Result, error) {
+	if p == nil {
+		return nil, errors.New("CreateInput is nil")
+	}
+
+	return w.do(ctx, key, OperationRetweet, operation{
+		send: func(ctx context.Context) (*Outcome, error) {
+			if _, err := retweet.Create(ctx, w.client, p); err != nil {
+				return nil, err
+			}
+			return &Outcome{}, nil
+		},
+		verify: func(ctx context.Context) (*Outcome, error) {
+			return searchPages(ctx, func(ctx context.Context, token string) (*Outcome, string, error) {
+				res, err := retweet.ListUsers(ctx, w.client, &retweettypes.ListUsersInput{ID: p.TweetID, MaxResults: 100, PaginationToken: token})
+				if err != nil {
+					return nil, "", err
+				}
+				return outcomeIfContainsUser(res.Data, p.ID), gotwi.StringValue(res.Meta.NextToken), nil
+			})
+		},
+		alreadyDone: func(ge *gotwi.GotwiError) bool {
+			return posting.HasErrorCode(ge, errorCodeAlreadyRetweeted)
+		},
+	})
+}
+
+// CreateFollowing follows a user with the idempotency key.
+// After an ambiguous failure, the following users of the authenticated user are checked.
+func (w *Writer) CreateFollowing(ctx context.Context, key string, p *followtypes.CreateFollowingInput) (*Result, error) {
+	if p == nil {
+		return nil, errors.New("CreateFollowingInput is nil")
+	}
+
+	return w.do(ctx, key, OperationCreateFollowing, operation{
+		send: func(ctx context.Context) (*Outcome, error) {
+			res, err := follow.CreateFollowing(ctx, w.client, p)
+			if err != nil {
+				return nil, err
+			}
+			return &Outcome{PendingFollow: res.Data.PendingFollow}, nil
+		},
+		verify: func(ctx context.Context) (*Outcome, error) {
+			return searchPages(ctx, func(ctx context.Context, token string) (*Outcome, string, error) {
+				res, err := follow.ListFollowings(ctx, w.client, &followtypes.ListFollowingsInput{ID: p.ID, MaxResults: 1000, PaginationToken: token})
+				if err != nil {
+					return nil, "", err
+				}
+				return outcomeIfContainsUser(res.Data, p.TargetID), gotwi.StringValue(res.Meta.NextToken), nil
+			})
+		},
+		alreadyDone: func(ge *gotwi.GotwiError) bool {
+			return posting.HasErrorCode(ge, errorCodeAlreadyRequestFollow)
+		},
+	})
+}
+
+type operation struct {
+	send        func(ctx context.Context) (*Outcome, error)
+	verify      func(ctx context.Context) (*Outcome, error) // returns nil if the operation has not been done
+	alreadyDone func(ge *gotwi.GotwiError) bool
+}
+
+func (w *Writer) do(ctx context.Context, key string, op Operation, o operation) (*Result, error) {
+	if key == "" {
+		return nil, errors.New("idempotency key is required")
+	}
+
+	// Another call with the same key must not send while this one is in flight.
+	unlock := w.lockKey(key)
+	defer unlock()
+
+	recorded, err := w.store.Get(ctx, key)
+	if err == nil {
+		if recorded.Operation != op {
+			return nil, fmt.Errorf("%w: key=%s recorded=%s requested=%s", ErrOperationMismatch, key, recorded.Operation, op)
+		}
+		return &Result{Outcome: *recorded, Replayed: true}, nil
+	}
+	if !errors.Is(err, ErrOutcomeNotFound) {
+		return nil, err
+	}
+
+	var lastErr error
+	for i := 0; i < w.maxAttempts; i++ {
+		out, err := o.send(ctx)
+		if err == nil {
+			return w.record(ctx, key, op, out)
+		}
+
+		var ge *gotwi.GotwiError
+		if errors.As(err, &ge) && ge.OnAPI {
+			if o.alreadyDone(ge) {
+				return w.record(ctx, key, op, &Outcome{AlreadyDone: true})
+			}
+			if posting.IsRejected(ge) {
+				return nil, err
+			}
+		}
+
+		// The operation may have been done. Verify the state before sending again.
+		lastErr = err
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		verified, verr := o.verify(ctx)
+		if verr != nil {
+			return nil, errors.Join(err, verr)
+		}
+		if verified != nil {
+			verified.Verified = true
+			return w.record(ctx, key, op, verified)
+		}
+	}
+
+	return nil, lastErr
+}
+
+// lockKey locks the key, and returns the function to unlock it.
+// The lock is removed when no call holds or waits for it.
+func (w *Writer) lockKey(key string) func() {
+	w.locksMu.Lock()
+	l, ok := w.locks[key]
+	if !ok {
+		l = &keyLock{}
+		w.locks[key] = l
+	}
+	l.refs++
+	w.locksMu.Unlock()
+
+	l.mu.Lock()
+
+	return func() {
+		l.mu.Unlock()
+
+		w.locksMu.Lock()
+		defer w.locksMu.Unlock()
+		l.refs--
+		if l.refs == 0 {
+			delete(w.locks, key)
+		}
+	}
+}
+
+func (w *Writer) record(ctx context.Context, key string, op Operation, o *Outcome) (*Result, error) {
+	o.Key = key
+	o.Operation = op
+	o.RecordedAt = w.now()
+
+	if err := w.store.Put(ctx, o); err != nil {
+		return nil, err
+	}
+
+	return &Result{Outcome: *o}, nil
+}
+
+// verifyTweet searches the recent Tweets of the authenticated user for the text.
+// The text of each Tweet is normalized by posting.PostedText, e.g. the t.co links are expanded, before comparing.
+func (w *Writer) verifyTweet(ctx context.Context, text string, sentAt time.Time) (*Outcome, error) {
+	if strings.TrimSpace(text) == "" {
+		// A Tweet without text cannot be identified.
+		return nil, nil
+	}
+
+	userID, err := w.authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := sentAt.Add(-verifyClockSkew)
+	return searchPages(ctx, func(ctx context.Context, token string) (*Outcome, string, error) {
+		res, err := timeline.ListTweets(ctx, w.client, &timelinetypes.ListTweetsInput{
+			ID:              userID,
+			StartTime:       &startTime,
+			MaxResults:      100,
+			PaginationToken: token,
+			TweetFields:     posting.TweetFields,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, t := range res.Data {
+			if posting.SameText(t, text) {
+				return &Outcome{TweetID: gotwi.StringValue(t.ID)}, "", nil
+			}
+		}
+		return nil, gotwi.StringValue(res.Meta.NextToken), nil
+	})
+}
+
+// searchPages calls page with the pagination token until it returns an outcome,
+// there is no next page, or verifyMaxPages pages are searched.
+func searchPages(ctx context.Context, page func(ctx context.Context, token string) (*Outcome, string, error)) (*Outcome, error) {
+	token := ""
+	for i := 0; i < verifyMaxPages; i++ {
+		o, next, err := page(ctx, token)
+		if err != nil || o != nil {
+			return o, err
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+
+	return nil, nil
+}
+
+func (w *Writer) authenticatedUserID(ctx context.Context) (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.userID != "" {
+		return w.userID, nil
+	}
+
+	res, err := userlookup.GetMe(ctx, w.client, &userlookuptypes.GetMeInput{})
+	if err != nil {
+		return "", err
+	}
+
+	w.userID = gotwi.StringValue(res.Data.ID)
+	return w.userID, nil
+}
+
+func outcomeIfContainsUser(users []resources.User, userID string) *Outcome {
+	for _, u := range users {
+		if gotwi.StringValue(u.ID) == userID {
+			return &Outcome{}
+		}
+	}
+	return nil
+}
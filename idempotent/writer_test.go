@@ -0,0 +1,332 @@
+package idempotent_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/idempotent"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/resources"
+	liketypes "github.com/michimani/gotwi/tweet/like/types"
+	managetweettypes "github.com/michimani/gotwi/tweet/managetweet/types"
+	retweettypes "github.com/michimani/gotwi/tweet/retweet/types"
+	timelinetypes "github.com/michimani/gotwi/tweet/timeline/types"
+	followtypes "github.com/michimani/gotwi/user/follow/types"
+	userlookuptypes "github.com/michimani/gotwi/user/userlookup/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func apiError(statusCode int, code resources.ErrorCode) error {
+	return &gotwi.GotwiError{OnAPI: true, Non2XXError: resources.Non2XXError{
+		StatusCode: statusCode,
+		APIErrors:  []resources.ErrorInformation{{Message: "error", Code: code}},
+	}}
+}
+
+func newWriter(t *testing.T, fn func(p util.Parameters, i util.Response) error) *idempotent.Writer {
+	mc := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+		MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+			return fn(p, i)
+		},
+	})
+
+	w, err := idempotent.NewWriter(&idempotent.NewWriterInput{Client: mc})
+	assert.NoError(t, err)
+
+	return w
+}
+
+func Test_NewWriter(t *testing.T) {
+	_, err := idempotent.NewWriter(nil)
+	assert.Error(t, err)
+
+	_, err = idempotent.NewWriter(&idempotent.NewWriterInput{})
+	assert.Error(t, err)
+
+	w, err := idempotent.NewWriter(&idempotent.NewWriterInput{Client: gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{})})
+	assert.NoError(t, err)
+	assert.NotNil(t, w)
+}
+
+func Test_CreateTweet(t *testing.T) {
+	cases := []struct {
+		name         string
+		createErrs   []error
+		timeline     []resources.Tweet
+		text         string
+		expect       *idempotent.Result
+		expectCreate int
+		wantErr      bool
+	}{
+		{
+			name:         "ok",
+			expect:       &idempotent.Result{Outcome: idempotent.Outcome{TweetID: "new"}},
+			expectCreate: 1,
+		},
+		{
+			name:         "ok: duplicate is treated as already done",
+			createErrs:   []error{apiError(http.StatusForbidden, 187)},
+			expect:       &idempotent.Result{Outcome: idempotent.Outcome{AlreadyDone: true}},
+			expectCreate: 1,
+		},
+		{
+			name:         "ok: verified after network error",
+			createErrs:   []error{errors.New("timeout")},
+			timeline:     []resources.Tweet{{ID: gotwi.String("other"), Text: gotwi.String("other")}, {ID: gotwi.String("posted"), Text: gotwi.String("hello")}},
+			expect:       &idempotent.Result{Outcome: idempotent.Outcome{TweetID: "posted", Verified: true}},
+			expectCreate: 1,
+		},
+		{
+			name:       "ok: verified with normalized text",
+			createErrs: []error{errors.New("timeout")},
+			timeline: []resources.Tweet{{
+				ID:   gotwi.String("posted"),
+				Text: gotwi.String("hello &amp; https://t.co/abc"),
+				Entities: &resources.TweetEntities{URLs: []resources.URL{
+					{URL: gotwi.String("https://t.co/abc"), ExpandedURL: gotwi.String("https://example.com")},
+				}},
+			}},
+			text:         "hello & https://example.com",
+			expect:       &idempotent.Result{Outcome: idempotent.Outcome{TweetID: "posted", Verified: true}},
+			expectCreate: 1,
+		},
+		{
+			name:         "ok: sent again when not found in timeline",
+			createErrs:   []error{apiError(http.StatusServiceUnavailable, 0)},
+			expect:       &idempotent.Result{Outcome: idempotent.Outcome{TweetID: "new"}},
+			expectCreate: 2,
+		},
+		{
+			name:         "ng: client error is not retried",
+			createErrs:   []error{apiError(http.StatusBadRequest, 0)},
+			expectCreate: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "ng: ambiguous failures exceed max attempts",
+			createErrs:   []error{errors.New("timeout"), errors.New("timeout")},
+			expectCreate: 2,
+			wantErr:      true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			creates := 0
+			w := newWriter(tt, func(p util.Parameters, i util.Response) error {
+				switch p.(type) {
+				case *managetweettypes.CreateInput:
+					creates++
+					if creates <= len(c.createErrs) {
+						return c.createErrs[creates-1]
+					}
+					i.(*managetweettypes.CreateOutput).Data.ID = gotwi.String("new")
+				case *userlookuptypes.GetMeInput:
+					i.(*userlookuptypes.GetMeOutput).Data.ID = gotwi.String("me")
+				case *timelinetypes.ListTweetsInput:
+					i.(*timelinetypes.ListTweetsOutput).Data = c.timeline
+				}
+				return nil
+			})
+
+			text := "hello"
+			if c.text != "" {
+				text = c.text
+			}
+
+			ctx := context.Background()
+			res, err := w.CreateTweet(ctx, "key", &managetweettypes.CreateInput{Text: gotwi.String(text)})
+			assert.Equal(tt, c.expectCreate, creates)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.Equal(tt, c.expect.TweetID, res.TweetID)
+			assert.Equal(tt, c.expect.AlreadyDone, res.AlreadyDone)
+			assert.Equal(tt, c.expect.Verified, res.Verified)
+			assert.Equal(tt, idempotent.OperationCreateTweet, res.Operation)
+			assert.False(tt, res.Replayed)
+
+			// the second call is replayed from the store
+			replayed, err := w.CreateTweet(ctx, "key", &managetweettypes.CreateInput{Text: gotwi.String(text)})
+			assert.NoError(tt, err)
+			assert.True(tt, replayed.Replayed)
+			assert.Equal(tt, res.Outcome, replayed.Outcome)
+			assert.Equal(tt, c.expectCreate, creates)
+		})
+	}
+}
+
+func Test_Like(t *testing.T) {
+	cases := []struct {
+		name      string
+		createErr error
+		likers    []resources.User
+		expect    idempotent.Outcome
+		wantErr   bool
+	}{
+		{
+			name:   "ok",
+			expect: idempotent.Outcome{},
+		},
+		{
+			name:      "ok: already favorited",
+			createErr: apiError(http.StatusForbidden, 139),
+			expect:    idempotent.Outcome{AlreadyDone: true},
+		},
+		{
+			name:      "ok: verified after network error",
+			createErr: errors.New("timeout"),
+			likers:    []resources.User{{ID: gotwi.String("me")}},
+			expect:    idempotent.Outcome{Verified: true},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			creates := 0
+			w := newWriter(tt, func(p util.Parameters, i util.Response) error {
+				switch p.(type) {
+				case *liketypes.CreateInput:
+					creates++
+					if creates == 1 && c.createErr != nil {
+						return c.createErr
+					}
+				case *liketypes.ListUsersInput:
+					i.(*liketypes.ListUsersOutput).Data = c.likers
+				}
+				return nil
+			})
+
+			res, err := w.Like(context.Background(), "key", &liketypes.CreateInput{ID: "me", TweetID: "tweet"})
+			assert.NoError(tt, err)
+			assert.Equal(tt, c.expect.AlreadyDone, res.AlreadyDone)
+			assert.Equal(tt, c.expect.Verified, res.Verified)
+			assert.Equal(tt, idempotent.OperationLike, res.Operation)
+		})
+	}
+}
+
+func Test_Like_VerifyPages(t *testing.T) {
+	pages := map[string][]resources.User{
+		"":       {{ID: gotwi.String("other1")}},
+		"page-2": {{ID: gotwi.String("other2")}, {ID: gotwi.String("me")}},
+	}
+	creates, lists := 0, 0
+	w := newWriter(t, func(p util.Parameters, i util.Response) error {
+		switch in := p.(type) {
+		case *liketypes.CreateInput:
+			creates++
+			return errors.New("timeout")
+		case *liketypes.ListUsersInput:
+			lists++
+			out := i.(*liketypes.ListUsersOutput)
+			out.Data = pages[in.PaginationToken]
+			if in.PaginationToken == "" {
+				out.Meta.NextToken = gotwi.String("page-2")
+			}
+		}
+		return nil
+	})
+
+	res, err := w.Like(context.Background(), "key", &liketypes.CreateInput{ID: "me", TweetID: "tweet"})
+	assert.NoError(t, err)
+	assert.True(t, res.Verified)
+	assert.Equal(t, 1, creates)
+	assert.Equal(t, 2, lists)
+}
+
+func Test_Retweet(t *testing.T) {
+	w := newWriter(t, func(p util.Parameters, i util.Response) error {
+		return apiError(http.StatusForbidden, 327)
+	})
+
+	res, err := w.Retweet(context.Background(), "key", &retweettypes.CreateInput{ID: "me", TweetID: "tweet"})
+	assert.NoError(t, err)
+	assert.True(t, res.AlreadyDone)
+	assert.Equal(t, idempotent.OperationRetweet, res.Operation)
+
+	_, err = w.Retweet(context.Background(), "key", nil)
+	assert.Error(t, err)
+}
+
+func Test_CreateFollowing(t *testing.T) {
+	w := newWriter(t, func(p util.Parameters, i util.Response) error {
+		switch p.(type) {
+		case *followtypes.CreateFollowingInput:
+			return errors.New("timeout")
+		case *followtypes.ListFollowingsInput:
+			return errors.New("verify error")
+		}
+		return nil
+	})
+
+	// verification failure does not cause sending again
+	res, err := w.CreateFollowing(context.Background(), "key", &followtypes.CreateFollowingInput{ID: "me", TargetID: "target"})
+	assert.Error(t, err)
+	assert.Nil(t, res)
+
+	_, err = w.CreateFollowing(context.Background(), "", &followtypes.CreateFollowingInput{})
+	assert.Error(t, err)
+}
+
+func Test_Writer_ConcurrentCalls(t *testing.T) {
+	var mu sync.Mutex
+	creates := 0
+	w := newWriter(t, func(p util.Parameters, i util.Response) error {
+		if _, ok := p.(*liketypes.CreateInput); ok {
+			mu.Lock()
+			creates++
+			mu.Unlock()
+		}
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	replayed := make([]bool, 10)
+	for n := range replayed {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := w.Like(context.Background(), "key", &liketypes.CreateInput{ID: "me", TweetID: "tweet"})
+			if assert.NoError(t, err) {
+				replayed[n] = res.Replayed
+			}
+		}()
+	}
+	wg.Wait()
+
+	// only one of the calls sends, and the others replay its outcome
+	assert.Equal(t, 1, creates)
+	sent := 0
+	for _, r := range replayed {
+		if !r {
+			sent++
+		}
+	}
+	assert.Equal(t, 1, sent)
+}
+
+func Test_Writer_OperationMismatch(t *testing.T) {
+	w := newWriter(t, func(p util.Parameters, i util.Response) error {
+		return nil
+	})
+
+	_, err := w.Like(context.Background(), "key", &liketypes.CreateInput{ID: "me", TweetID: "tweet"})
+	assert.NoError(t, err)
+
+	res, err := w.Retweet(context.Background(), "key", &retweettypes.CreateInput{ID: "me", TweetID: "tweet"})
+	assert.ErrorIs(t, err, idempotent.ErrOperationMismatch)
+	assert.Nil(t, res)
+
+	res, err = w.Like(context.Background(), "key", &liketypes.CreateInput{ID: "me", TweetID: "tweet"})
+	assert.NoError(t, err)
+	assert.True(t, res.Replayed)
+}
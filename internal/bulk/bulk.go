@@ -0,0 +1,204 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/resources"
+)
+
+const (
+	DefaultParallelism = 4
+
+	maxRateLimitRetries  = 3
+	defaultRateLimitWait = 15 * time.Minute
+)
+
+// Unique returns values without duplicates and empty values, keeping the order.
+func Unique(values []string) []string {
+	seen := map[string]struct{}{}
+	u := []string{}
+	for _, v := range values {
+		if _, ok := seen[v]; ok || v == "" {
+			continue
+		}
+		seen[v] = struct{}{}
+		u = append(u, v)
+	}
+
+	return u
+}
+
+// Chunk splits values into batches that have at most size values.
+func Chunk(values []string, size int) [][]string {
+	batches := [][]string{}
+	for size < len(values) {
+		batches = append(batches, values[:size:size])
+		values = values[size:]
+	}
+	if len(values) > 0 {
+		batches = append(batches, values)
+	}
+
+	return batches
+}
+
+// Run calls fn for each batch of values concurrently, and returns the results in the order of batches.
+// When fn returns an error of rate limit exceeded, all calls wait until the rate limit is reset,
+// and the batch is retried. Any other error cancels the remaining calls.
+func Run[T any](ctx context.Context, values []string, batchSize, parallelism int, fn func(ctx context.Context, batch []string) (T, error)) ([]T, error) {
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+
+	batches := Chunk(Unique(values), batchSize)
+	results := make([]T, len(batches))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	g := &gate{}
+	sem := make(chan struct{}, parallelism)
+
+	for i, batch := range batches {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			res, err := call(ctx, g, func() (T, error) { return fn(ctx, batch) })
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			results[i] = res
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// gate pauses all calls until the rate limit is reset.
+type gate struct {
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// call calls fn, and retries it after the rate limit is reset.
+func call[T any](ctx context.Context, g *gate, fn func() (T, error)) (T, error) {
+	var zero T
+	for i := 0; ; i++ {
+		if err := g.wait(ctx); err != nil {
+			return zero, err
+		}
+
+		res, err := fn()
+		if err == nil {
+			return res, nil
+		}
+
+		resetAt, limited := rateLimitResetAt(err)
+		if !limited || i >= maxRateLimitRetries {
+			return zero, err
+		}
+		g.pause(resetAt)
+	}
+}
+
+func (g *gate) wait(ctx context.Context) error {
+	g.mu.Lock()
+	d := time.Until(g.pausedUntil)
+	g.mu.Unlock()
+
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func (g *gate) pause(until time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if until.After(g.pausedUntil) {
+		g.pausedUntil = until
+	}
+}
+
+func rateLimitResetAt(err error) (time.Time, bool) {
+	var ge *gotwi.GotwiError
+	if !errors.As(err, &ge) || !ge.OnAPI || ge.StatusCode != http.StatusTooManyRequests {
+		return time.Time{}, false
+	}
+
+	if ge.RateLimitInfo != nil && ge.RateLimitInfo.ResetAt != nil {
+		return *ge.RateLimitInfo.ResetAt, true
+	}
+
+	return time.Now().Add(defaultRateLimitWait), true
+}
+
+// UniqueBy returns items without duplicates of the key, keeping the order.
+// Items whose key is empty are always kept.
+func UniqueBy[T any](items []T, key func(T) *string) []T {
+	seen := map[string]struct{}{}
+	u := []T{}
+	for _, item := range items {
+		k := key(item)
+		if k != nil && *k != "" {
+			if _, ok := seen[*k]; ok {
+				continue
+			}
+			seen[*k] = struct{}{}
+		}
+		u = append(u, item)
+	}
+
+	return u
+}
+
+// ErrorKey returns the requested value that caused the partial error.
+func ErrorKey(e resources.PartialError) string {
+	if e.Value != nil && *e.Value != "" {
+		return *e.Value
+	}
+	if e.ResourceID != nil {
+		return *e.ResourceID
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+package bulk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/bulk"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unique(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, bulk.Unique([]string{"a", "b", "a", "", "c", "b"}))
+	assert.Equal(t, []string{}, bulk.Unique(nil))
+}
+
+func Test_Chunk(t *testing.T) {
+	cases := []struct {
+		name   string
+		values []string
+		size   int
+		expect [][]string
+	}{
+		{
+			name:   "ok",
+			values: []string{"1", "2", "3", "4", "5"},
+			size:   2,
+			expect: [][]string{{"1", "2"}, {"3", "4"}, {"5"}},
+		},
+		{
+			name:   "ok: just size",
+			values: []string{"1", "2"},
+			size:   2,
+			expect: [][]string{{"1", "2"}},
+		},
+		{
+			name:   "ok: empty",
+			values: []string{},
+			size:   2,
+			expect: [][]string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, bulk.Chunk(c.values, c.size))
+		})
+	}
+}
+
+func Test_Run(t *testing.T) {
+	values := []string{}
+	for i := 0; i < 25; i++ {
+		values = append(values, string(rune('a'+i)))
+	}
+
+	t.Run("ok: results are in order of batches", func(tt *testing.T) {
+		var running, maxRunning int32
+		res, err := bulk.Run(context.Background(), values, 10, 2, func(ctx context.Context, batch []string) (string, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return strings.Join(batch, ""), nil
+		})
+
+		assert.NoError(tt, err)
+		assert.Equal(tt, []string{"abcdefghij", "klmnopqrst", "uvwxy"}, res)
+		assert.LessOrEqual(tt, maxRunning, int32(2))
+	})
+
+	t.Run("ok: retry after rate limit is reset", func(tt *testing.T) {
+		resetAt := time.Now()
+		var calls int32
+		res, err := bulk.Run(context.Background(), values[:3], 10, 0, func(ctx context.Context, batch []string) (int, error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return 0, &gotwi.GotwiError{OnAPI: true, Non2XXError: resources.Non2XXError{
+					StatusCode:    http.StatusTooManyRequests,
+					RateLimitInfo: &util.RateLimitInformation{ResetAt: &resetAt},
+				}}
+			}
+			return len(batch), nil
+		})
+
+		assert.NoError(tt, err)
+		assert.Equal(tt, []int{3}, res)
+		assert.Equal(tt, int32(2), calls)
+	})
+
+	t.Run("ng: error stops the remaining batches", func(tt *testing.T) {
+		var calls int32
+		res, err := bulk.Run(context.Background(), values, 1, 1, func(ctx context.Context, batch []string) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			return 0, errors.New("error")
+		})
+
+		assert.Error(tt, err)
+		assert.Nil(tt, res)
+		assert.Less(tt, calls, int32(len(values)))
+	})
+}
+
+func Test_UniqueBy(t *testing.T) {
+	users := []resources.User{
+		{ID: gotwi.String("1")},
+		{ID: gotwi.String("2")},
+		{ID: gotwi.String("1")},
+		{ID: nil},
+		{ID: nil},
+	}
+
+	u := bulk.UniqueBy(users, func(u resources.User) *string { return u.ID })
+	assert.Len(t, u, 4)
+}
+
+func Test_ErrorKey(t *testing.T) {
+	assert.Equal(t, "v", bulk.ErrorKey(resources.PartialError{Value: gotwi.String("v"), ResourceID: gotwi.String("r")}))
+	assert.Equal(t, "r", bulk.ErrorKey(resources.PartialError{ResourceID: gotwi.String("r")}))
+	assert.Equal(t, "", bulk.ErrorKey(resources.PartialError{}))
+}
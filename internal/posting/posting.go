@@ -0,0 +1,100 @@
+// Package posting has the helpers shared by the writers that verify their operations,
+// i.e. tweet/publisher and idempotent.
+package posting
+
+import (
+	"html"
+	"net/http"
+	"strings"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/resources"
+)
+
+const (
+	ErrorCodeRateLimitExceeded resources.ErrorCode = 88
+	ErrorCodeOverPostingLimit  resources.ErrorCode = 185
+	ErrorCodeDuplicateStatus   resources.ErrorCode = 187
+)
+
+// TweetFields are the fields needed by SameText, to be requested when looking up the posted Tweets.
+var TweetFields = fields.TweetFieldList{fields.TweetFieldEntities, fields.TweetFieldNoteTweet}
+
+// HasErrorCode reports whether the error has the API error of the code.
+func HasErrorCode(ge *gotwi.GotwiError, code resources.ErrorCode) bool {
+	for _, ae := range ge.APIErrors {
+		if ae.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDuplicate reports whether the Tweet is rejected because the same text has been posted.
+func IsDuplicate(ge *gotwi.GotwiError) bool {
+	if HasErrorCode(ge, ErrorCodeDuplicateStatus) {
+		return true
+	}
+
+	return ge.StatusCode == http.StatusForbidden && strings.Contains(strings.ToLower(ge.Detail), "duplicate content")
+}
+
+// IsPostingLimit reports whether the request is rejected by the rate limit or the posting limit.
+func IsPostingLimit(ge *gotwi.GotwiError) bool {
+	return ge.StatusCode == http.StatusTooManyRequests ||
+		HasErrorCode(ge, ErrorCodeRateLimitExceeded) ||
+		HasErrorCode(ge, ErrorCodeOverPostingLimit)
+}
+
+// IsRejected reports whether the request is rejected by the API with a 4XX status,
+// so the operation has not been done and sending it again does not change the result.
+// 408 Request Timeout is not a rejection, because the operation may have been done.
+func IsRejected(ge *gotwi.GotwiError) bool {
+	return ge.OnAPI &&
+		ge.StatusCode >= http.StatusBadRequest &&
+		ge.StatusCode < http.StatusInternalServerError &&
+		ge.StatusCode != http.StatusRequestTimeout
+}
+
+// SameText reports whether the Tweet has the text, as posted by the create Tweet endpoint.
+// The text of the Tweet is normalized by PostedText before comparing.
+func SameText(t resources.Tweet, text string) bool {
+	return PostedText(t) == strings.TrimSpace(text)
+}
+
+// PostedText returns the text of the Tweet as it was sent to the create Tweet endpoint, as far as possible.
+// The full text of a long Tweet is taken from note_tweet, the t.co links are replaced with their expanded URLs,
+// the links to the attached media are removed, and the HTML entities (&amp; &lt; &gt;) are unescaped.
+// The entities and note_tweet are needed for the normalization, so request TweetFields.
+func PostedText(t resources.Tweet) string {
+	text := gotwi.StringValue(t.Text)
+	var urls []resources.URL
+	if t.Entities != nil {
+		urls = t.Entities.URLs
+	}
+	if t.NoteTweet != nil && t.NoteTweet.Text != nil {
+		text = gotwi.StringValue(t.NoteTweet.Text)
+		urls = t.NoteTweet.Entities.URLs
+	}
+
+	for _, u := range urls {
+		short := gotwi.StringValue(u.URL)
+		if short == "" {
+			continue
+		}
+		expanded := gotwi.StringValue(u.ExpandedURL)
+		if isMediaURL(u) {
+			expanded = ""
+		}
+		text = strings.Replace(text, short, expanded, 1)
+	}
+
+	return strings.TrimSpace(html.UnescapeString(text))
+}
+
+// isMediaURL reports whether the URL is the link to the attached media, which is appended to the text by the API.
+func isMediaURL(u resources.URL) bool {
+	display := gotwi.StringValue(u.DisplayURL)
+	return strings.HasPrefix(display, "pic.twitter.com/") || strings.HasPrefix(display, "pic.x.com/")
+}
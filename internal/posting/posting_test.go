@@ -0,0 +1,94 @@
+package posting_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/posting"
+	"github.com/michimani/gotwi/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PostedText(t *testing.T) {
+	cases := []struct {
+		name   string
+		tweet  resources.Tweet
+		expect string
+	}{
+		{
+			name:   "plain",
+			tweet:  resources.Tweet{Text: gotwi.String(" hello ")},
+			expect: "hello",
+		},
+		{
+			name:   "html entities",
+			tweet:  resources.Tweet{Text: gotwi.String("a &amp; b &lt;c&gt;")},
+			expect: "a & b <c>",
+		},
+		{
+			name: "t.co links and media",
+			tweet: resources.Tweet{
+				Text: gotwi.String("see https://t.co/abc https://t.co/pic"),
+				Entities: &resources.TweetEntities{URLs: []resources.URL{
+					{URL: gotwi.String("https://t.co/abc"), ExpandedURL: gotwi.String("https://example.com/a?b=1&c=2")},
+					{URL: gotwi.String("https://t.co/pic"), ExpandedURL: gotwi.String("https://x.com/u/status/1/photo/1"), DisplayURL: gotwi.String("pic.x.com/pic")},
+				}},
+			},
+			expect: "see https://example.com/a?b=1&c=2",
+		},
+		{
+			name: "note tweet",
+			tweet: resources.Tweet{
+				Text: gotwi.String("long…"),
+				NoteTweet: func() *resources.TweetNoteTweet {
+					n := &resources.TweetNoteTweet{Text: gotwi.String("long text https://t.co/abc")}
+					n.Entities.URLs = []resources.URL{{URL: gotwi.String("https://t.co/abc"), ExpandedURL: gotwi.String("https://example.com")}}
+					return n
+				}(),
+			},
+			expect: "long text https://example.com",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, posting.PostedText(c.tweet))
+			assert.True(tt, posting.SameText(c.tweet, c.expect+"\n"))
+		})
+	}
+}
+
+func Test_Errors(t *testing.T) {
+	apiError := func(statusCode int, code resources.ErrorCode, detail string) *gotwi.GotwiError {
+		return &gotwi.GotwiError{OnAPI: true, Non2XXError: resources.Non2XXError{
+			StatusCode: statusCode,
+			APIErrors:  []resources.ErrorInformation{{Code: code}},
+			Detail:     detail,
+		}}
+	}
+
+	cases := []struct {
+		name         string
+		err          *gotwi.GotwiError
+		duplicate    bool
+		postingLimit bool
+		rejected     bool
+	}{
+		{name: "duplicate code", err: apiError(http.StatusForbidden, 187, ""), duplicate: true, rejected: true},
+		{name: "duplicate detail", err: apiError(http.StatusForbidden, 0, "duplicate content"), duplicate: true, rejected: true},
+		{name: "too many requests", err: apiError(http.StatusTooManyRequests, 0, ""), postingLimit: true, rejected: true},
+		{name: "over posting limit", err: apiError(http.StatusForbidden, 185, ""), postingLimit: true, rejected: true},
+		{name: "bad request", err: apiError(http.StatusBadRequest, 0, ""), rejected: true},
+		{name: "request timeout", err: apiError(http.StatusRequestTimeout, 0, "")},
+		{name: "server error", err: apiError(http.StatusServiceUnavailable, 0, "")},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.duplicate, posting.IsDuplicate(c.err))
+			assert.Equal(tt, c.postingLimit, posting.IsPostingLimit(c.err))
+			assert.Equal(tt, c.rejected, posting.IsRejected(c.err))
+		})
+	}
+}
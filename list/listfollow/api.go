@@ -2,7 +2,6 @@ package listfollow
 
 import (
 	"context"
-	"errors"
 
 	"github.com/michimani/gotwi"
 	"github.com/michimani/gotwi/list/listfollow/types"
@@ -17,56 +16,24 @@ const (
 
 // Returns a list of users who are followers of the specified List.
 // https://developer.twitter.com/en/docs/twitter-api/lists/list-follows/api-reference/get-lists-id-followers
-func ListFollowers(ctx context.Context, c gotwi.IClient, p *types.ListFollowersInput) (*types.ListFollowersOutput, error) {
-	if p == nil {
-		return nil, errors.New("ListFollowersInput is nil")
-	}
-	res := &types.ListFollowersOutput{}
-	if err := c.CallAPI(ctx, listFollowersEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func ListFollowers(ctx context.Context, c gotwi.IClient, p *types.ListFollowersInput, opts ...gotwi.CallOption) (*types.ListFollowersOutput, error) {
+	return gotwi.Do[*types.ListFollowersOutput](ctx, c, "GET", listFollowersEndpoint, p, opts...)
 }
 
 // Returns all Lists a specified user follows.
 // https://developer.twitter.com/en/docs/twitter-api/lists/list-follows/api-reference/get-users-id-followed_lists
-func ListFollowed(ctx context.Context, c gotwi.IClient, p *types.ListFollowedInput) (*types.ListFollowedOutput, error) {
-	if p == nil {
-		return nil, errors.New("ListFollowedInput is nil")
-	}
-	res := &types.ListFollowedOutput{}
-	if err := c.CallAPI(ctx, listFollowedEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func ListFollowed(ctx context.Context, c gotwi.IClient, p *types.ListFollowedInput, opts ...gotwi.CallOption) (*types.ListFollowedOutput, error) {
+	return gotwi.Do[*types.ListFollowedOutput](ctx, c, "GET", listFollowedEndpoint, p, opts...)
 }
 
 // Enables the authenticated user to follow a List.
 // https://developer.twitter.com/en/docs/twitter-api/lists/manage-lists/api-reference/post-users-id-followed-lists
-func Create(ctx context.Context, c gotwi.IClient, p *types.CreateInput) (*types.CreateOutput, error) {
-	if p == nil {
-		return nil, errors.New("CreateInput is nil")
-	}
-	res := &types.CreateOutput{}
-	if err := c.CallAPI(ctx, createEndpoint, "POST", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func Create(ctx context.Context, c gotwi.IClient, p *types.CreateInput, opts ...gotwi.CallOption) (*types.CreateOutput, error) {
+	return gotwi.Do[*types.CreateOutput](ctx, c, "POST", createEndpoint, p, opts...)
 }
 
 // Enables the authenticated user to unfollow a List.
 // https://developer.twitter.com/en/docs/twitter-api/lists/manage-lists/api-reference/delete-users-id-followed-lists-list_id
-func Delete(ctx context.Context, c gotwi.IClient, p *types.DeleteInput) (*types.DeleteOutput, error) {
-	if p == nil {
-		return nil, errors.New("DeleteInput is nil")
-	}
-	res := &types.DeleteOutput{}
-	if err := c.CallAPI(ctx, deleteEndpoint, "DELETE", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func Delete(ctx context.Context, c gotwi.IClient, p *types.DeleteInput, opts ...gotwi.CallOption) (*types.DeleteOutput, error) {
+	return gotwi.Do[*types.DeleteOutput](ctx, c, "DELETE", deleteEndpoint, p, opts...)
 }
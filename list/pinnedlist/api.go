@@ -15,33 +15,18 @@ const (
 
 // Returns the Lists pinned by a specified user.
 // https://developer.twitter.com/en/docs/twitter-api/lists/pinned-lists/api-reference/get-users-id-pinned_lists
-func List(ctx context.Context, c gotwi.IClient, p *types.ListInput) (*types.ListOutput, error) {
-	res := &types.ListOutput{}
-	if err := c.CallAPI(ctx, listEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func List(ctx context.Context, c gotwi.IClient, p *types.ListInput, opts ...gotwi.CallOption) (*types.ListOutput, error) {
+	return gotwi.Do[*types.ListOutput](ctx, c, "GET", listEndpoint, p, opts...)
 }
 
 // Enables the authenticated user to pin a List.
 // https://developer.twitter.com/en/docs/twitter-api/lists/manage-lists/api-reference/post-users-id-pinned-lists
-func Create(ctx context.Context, c gotwi.IClient, p *types.CreateInput) (*types.CreateOutput, error) {
-	res := &types.CreateOutput{}
-	if err := c.CallAPI(ctx, createEndpoint, "POST", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func Create(ctx context.Context, c gotwi.IClient, p *types.CreateInput, opts ...gotwi.CallOption) (*types.CreateOutput, error) {
+	return gotwi.Do[*types.CreateOutput](ctx, c, "POST", createEndpoint, p, opts...)
 }
 
 // Enables the authenticated user to unpin a List.
 // https://developer.twitter.com/en/docs/twitter-api/lists/manage-lists/api-reference/delete-users-id-pinned-lists-list_id
-func Delete(ctx context.Context, c gotwi.IClient, p *types.DeleteInput) (*types.DeleteOutput, error) {
-	res := &types.DeleteOutput{}
-	if err := c.CallAPI(ctx, deleteEndpoint, "DELETE", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func Delete(ctx context.Context, c gotwi.IClient, p *types.DeleteInput, opts ...gotwi.CallOption) (*types.DeleteOutput, error) {
+	return gotwi.Do[*types.DeleteOutput](ctx, c, "DELETE", deleteEndpoint, p, opts...)
 }
@@ -0,0 +1,25 @@
+package medialookup
+
+import (
+	"context"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/media/medialookup/types"
+)
+
+const (
+	getEndpoint  = "https://api.x.com/2/media/:media_key"
+	listEndpoint = "https://api.x.com/2/media"
+)
+
+// Returns information about a single Media specified by the requested media key.
+// https://docs.x.com/x-api/media/get-media-by-media-key
+func Get(ctx context.Context, c gotwi.IClient, p *types.GetInput, opts ...gotwi.CallOption) (*types.GetOutput, error) {
+	return gotwi.Do[*types.GetOutput](ctx, c, "GET", getEndpoint, p, opts...)
+}
+
+// Returns information about multiple Media specified by media keys. Up to 100 media keys can be looked up.
+// https://docs.x.com/x-api/media/get-media-by-media-keys
+func List(ctx context.Context, c gotwi.IClient, p *types.ListInput, opts ...gotwi.CallOption) (*types.ListOutput, error) {
+	return gotwi.Do[*types.ListOutput](ctx, c, "GET", listEndpoint, p, opts...)
+}
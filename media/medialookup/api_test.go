@@ -0,0 +1,103 @@
+package medialookup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/media/medialookup"
+	"github.com/michimani/gotwi/media/medialookup/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Get(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *types.GetInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			params: &types.GetInput{MediaKey: "3_1"},
+		},
+		{
+			name:    "error",
+			params:  &types.GetInput{MediaKey: "3_1"},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "error: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return c.mockErr
+				},
+			})
+
+			res, err := medialookup.Get(context.Background(), mockClient, c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, res)
+		})
+	}
+}
+
+func Test_List(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *types.ListInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			params: &types.ListInput{MediaKeys: []string{"3_1"}},
+		},
+		{
+			name:    "error",
+			params:  &types.ListInput{MediaKeys: []string{"3_1"}},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "error: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return c.mockErr
+				},
+			})
+
+			res, err := medialookup.List(context.Background(), mockClient, c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, res)
+		})
+	}
+}
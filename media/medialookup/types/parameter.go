@@ -0,0 +1,107 @@
+package types
+
+import (
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/internal/util"
+)
+
+type GetInput struct {
+	accessToken string
+
+	// Path parameter
+	MediaKey string // required: Media key, e.g. "3_1146654567674912769"
+
+	// Query parameters
+	MediaFields fields.MediaFieldList
+}
+
+var getQueryParameters = map[string]struct{}{
+	"media.fields": {},
+}
+
+func (p *GetInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *GetInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *GetInput) ResolveEndpoint(endpointBase string) string {
+	if p.MediaKey == "" {
+		return ""
+	}
+
+	encoded := url.QueryEscape(p.MediaKey)
+	endpoint := strings.Replace(endpointBase, ":media_key", encoded, 1)
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, getQueryParameters)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func (p *GetInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *GetInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+	m = fields.SetFieldsParams(m, p.MediaFields)
+	return m
+}
+
+type ListInput struct {
+	accessToken string
+
+	// Query parameters
+	MediaKeys   []string // required: up to 100 media keys
+	MediaFields fields.MediaFieldList
+}
+
+var listQueryParameters = map[string]struct{}{
+	"media_keys":   {},
+	"media.fields": {},
+}
+
+func (p *ListInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *ListInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *ListInput) ResolveEndpoint(endpointBase string) string {
+	if len(p.MediaKeys) == 0 {
+		return ""
+	}
+
+	endpoint := endpointBase
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, listQueryParameters)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func (p *ListInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *ListInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+	m["media_keys"] = util.QueryValue(p.MediaKeys)
+	m = fields.SetFieldsParams(m, p.MediaFields)
+	return m
+}
@@ -0,0 +1,79 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/media/medialookup/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint/:media_key"
+
+	cases := []struct {
+		name   string
+		params *types.GetInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.GetInput{MediaKey: "3_1146654567674912769"},
+			expect: "test/endpoint/3_1146654567674912769",
+		},
+		{
+			name: "with media.fields",
+			params: &types.GetInput{
+				MediaKey:    "3_1146654567674912769",
+				MediaFields: fields.MediaFieldList{fields.MediaFieldAltText, fields.MediaFieldType},
+			},
+			expect: "test/endpoint/3_1146654567674912769?media.fields=alt_text%2Ctype",
+		},
+		{
+			name:   "media key is not set",
+			params: &types.GetInput{},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, c.params.ResolveEndpoint(endpoint))
+		})
+	}
+}
+
+func Test_ListInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint"
+
+	cases := []struct {
+		name   string
+		params *types.ListInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.ListInput{MediaKeys: []string{"3_1", "7_2"}},
+			expect: endpoint + "?media_keys=3_1%2C7_2",
+		},
+		{
+			name: "with media.fields",
+			params: &types.ListInput{
+				MediaKeys:   []string{"3_1"},
+				MediaFields: fields.MediaFieldList{fields.MediaFieldAltText},
+			},
+			expect: endpoint + "?media.fields=alt_text&media_keys=3_1",
+		},
+		{
+			name:   "media keys are not set",
+			params: &types.ListInput{},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, c.params.ResolveEndpoint(endpoint))
+		})
+	}
+}
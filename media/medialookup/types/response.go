@@ -0,0 +1,21 @@
+package types
+
+import "github.com/michimani/gotwi/resources"
+
+type GetOutput struct {
+	Data   resources.Media          `json:"data"`
+	Errors []resources.PartialError `json:"errors"`
+}
+
+func (r *GetOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+type ListOutput struct {
+	Data   []resources.Media        `json:"data"`
+	Errors []resources.PartialError `json:"errors"`
+}
+
+func (r *ListOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
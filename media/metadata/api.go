@@ -0,0 +1,18 @@
+package metadata
+
+import (
+	"context"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/media/metadata/types"
+)
+
+const (
+	createEndpoint = "https://api.x.com/2/media/metadata"
+)
+
+// Creates metadata such as alt text and sensitive content warnings for an uploaded Media.
+// https://docs.x.com/x-api/media/create-media-metadata
+func Create(ctx context.Context, c gotwi.IClient, p *types.CreateInput, opts ...gotwi.CallOption) (*types.CreateOutput, error) {
+	return gotwi.Do[*types.CreateOutput](ctx, c, "POST", createEndpoint, p, opts...)
+}
@@ -0,0 +1,58 @@
+package metadata_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/media/metadata"
+	"github.com/michimani/gotwi/media/metadata/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Create(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *types.CreateInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			params: &types.CreateInput{MediaID: "1"},
+		},
+		{
+			name:    "error",
+			params:  &types.CreateInput{MediaID: "1"},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "error: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return c.mockErr
+				},
+			})
+
+			res, err := metadata.Create(context.Background(), mockClient, c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, res)
+		})
+	}
+}
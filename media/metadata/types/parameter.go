@@ -0,0 +1,49 @@
+package types
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/michimani/gotwi/resources"
+)
+
+// CreateInput is the input for the Create endpoint.
+type CreateInput struct {
+	accessToken string
+
+	// The media identifier returned by upload.Initialize or upload.Finalize.
+	MediaID string `json:"id"`
+
+	// Metadata to associate with the media.
+	Metadata resources.MediaMetadata `json:"metadata"`
+}
+
+func (p *CreateInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *CreateInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *CreateInput) ResolveEndpoint(endpointBase string) string {
+	if p.MediaID == "" {
+		return ""
+	}
+
+	return endpointBase
+}
+
+func (p *CreateInput) Body() (io.Reader, error) {
+	json, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.NewReader(string(json)), nil
+}
+
+func (p *CreateInput) ParameterMap() map[string]string {
+	return map[string]string{}
+}
@@ -0,0 +1,75 @@
+package types_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/michimani/gotwi/media/metadata/types"
+	"github.com/michimani/gotwi/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateInput_Body(t *testing.T) {
+	cases := []struct {
+		name   string
+		params *types.CreateInput
+		expect io.Reader
+	}{
+		{
+			name: "ok: alt text",
+			params: &types.CreateInput{
+				MediaID: "1146654567674912769",
+				Metadata: resources.MediaMetadata{
+					AltText: &resources.MediaAltText{Text: "A dog on the beach"},
+				},
+			},
+			expect: strings.NewReader(`{"id":"1146654567674912769","metadata":{"alt_text":{"text":"A dog on the beach"}}}`),
+		},
+		{
+			name: "ok: sensitive media warning",
+			params: &types.CreateInput{
+				MediaID: "1146654567674912769",
+				Metadata: resources.MediaMetadata{
+					SensitiveMediaWarning: &resources.SensitiveMediaWarning{GraphicViolence: true},
+				},
+			},
+			expect: strings.NewReader(`{"id":"1146654567674912769","metadata":{"sensitive_media_warning":{"graphic_violence":true}}}`),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			r, err := c.params.Body()
+			assert.NoError(tt, err)
+			assert.Equal(tt, c.expect, r)
+		})
+	}
+}
+
+func Test_CreateInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint"
+
+	cases := []struct {
+		name   string
+		params *types.CreateInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.CreateInput{MediaID: "1146654567674912769"},
+			expect: endpoint,
+		},
+		{
+			name:   "media id is not set",
+			params: &types.CreateInput{},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, c.params.ResolveEndpoint(endpoint))
+		})
+	}
+}
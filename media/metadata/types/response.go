@@ -0,0 +1,13 @@
+package types
+
+import "github.com/michimani/gotwi/resources"
+
+// CreateOutput is the output for the Create endpoint.
+type CreateOutput struct {
+	Data   resources.MediaWithMetadata `json:"data"`
+	Errors []resources.PartialError    `json:"errors"`
+}
+
+func (r *CreateOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
@@ -0,0 +1,26 @@
+package subtitles
+
+import (
+	"context"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/media/subtitles/types"
+)
+
+const (
+	createEndpoint = "https://api.x.com/2/media/subtitles"
+	deleteEndpoint = "https://api.x.com/2/media/subtitles"
+)
+
+// Associates an uploaded subtitle file with a video.
+// The subtitle file is uploaded with upload.Initialize using MediaCategorySubtitles and MediaTypeSRT or MediaTypeVTT.
+// https://docs.x.com/x-api/media/create-media-subtitles
+func Create(ctx context.Context, c gotwi.IClient, p *types.CreateInput, opts ...gotwi.CallOption) (*types.CreateOutput, error) {
+	return gotwi.Do[*types.CreateOutput](ctx, c, "POST", createEndpoint, p, opts...)
+}
+
+// Removes the subtitles of the specified language from a video.
+// https://docs.x.com/x-api/media/delete-media-subtitles
+func Delete(ctx context.Context, c gotwi.IClient, p *types.DeleteInput, opts ...gotwi.CallOption) (*types.DeleteOutput, error) {
+	return gotwi.Do[*types.DeleteOutput](ctx, c, "DELETE", deleteEndpoint, p, opts...)
+}
@@ -0,0 +1,103 @@
+package subtitles_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/media/subtitles"
+	"github.com/michimani/gotwi/media/subtitles/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Create(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *types.CreateInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			params: &types.CreateInput{MediaID: "1"},
+		},
+		{
+			name:    "error",
+			params:  &types.CreateInput{MediaID: "1"},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "error: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return c.mockErr
+				},
+			})
+
+			res, err := subtitles.Create(context.Background(), mockClient, c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, res)
+		})
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *types.DeleteInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			params: &types.DeleteInput{MediaID: "1", LanguageCode: "EN"},
+		},
+		{
+			name:    "error",
+			params:  &types.DeleteInput{MediaID: "1", LanguageCode: "EN"},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "error: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return c.mockErr
+				},
+			})
+
+			res, err := subtitles.Delete(context.Background(), mockClient, c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, res)
+		})
+	}
+}
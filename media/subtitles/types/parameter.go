@@ -0,0 +1,103 @@
+package types
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/michimani/gotwi/resources"
+)
+
+// MediaCategory is the media category of the video that subtitles are associated with.
+type MediaCategory string
+
+const (
+	MediaCategoryAmplifyVideo MediaCategory = "AmplifyVideo"
+	MediaCategoryTweetVideo   MediaCategory = "TweetVideo"
+)
+
+// CreateInput is the input for the Create endpoint.
+type CreateInput struct {
+	accessToken string
+
+	// The media identifier of the video.
+	MediaID string `json:"id"`
+
+	// The media category of the video.
+	MediaCategory MediaCategory `json:"media_category,omitempty"`
+
+	// The subtitle file to associate with the video.
+	Subtitles resources.Subtitle `json:"subtitles"`
+}
+
+func (p *CreateInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *CreateInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *CreateInput) ResolveEndpoint(endpointBase string) string {
+	if p.MediaID == "" || p.Subtitles.ID == "" {
+		return ""
+	}
+
+	return endpointBase
+}
+
+func (p *CreateInput) Body() (io.Reader, error) {
+	json, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.NewReader(string(json)), nil
+}
+
+func (p *CreateInput) ParameterMap() map[string]string {
+	return map[string]string{}
+}
+
+// DeleteInput is the input for the Delete endpoint.
+type DeleteInput struct {
+	accessToken string
+
+	// The media identifier of the video.
+	MediaID string `json:"id"`
+
+	// The media category of the video.
+	MediaCategory MediaCategory `json:"media_category,omitempty"`
+
+	// The language code of the subtitles to remove.
+	LanguageCode string `json:"language_code"`
+}
+
+func (p *DeleteInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *DeleteInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *DeleteInput) ResolveEndpoint(endpointBase string) string {
+	if p.MediaID == "" || p.LanguageCode == "" {
+		return ""
+	}
+
+	return endpointBase
+}
+
+func (p *DeleteInput) Body() (io.Reader, error) {
+	json, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.NewReader(string(json)), nil
+}
+
+func (p *DeleteInput) ParameterMap() map[string]string {
+	return map[string]string{}
+}
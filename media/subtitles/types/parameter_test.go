@@ -0,0 +1,113 @@
+package types_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/michimani/gotwi/media/subtitles/types"
+	"github.com/michimani/gotwi/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateInput_Body(t *testing.T) {
+	p := &types.CreateInput{
+		MediaID:       "1146654567674912769",
+		MediaCategory: types.MediaCategoryTweetVideo,
+		Subtitles: resources.Subtitle{
+			DisplayName:  "English",
+			ID:           "1146654567674912770",
+			LanguageCode: "EN",
+		},
+	}
+
+	r, err := p.Body()
+	assert.NoError(t, err)
+	assert.Equal(t, strings.NewReader(`{"id":"1146654567674912769","media_category":"TweetVideo","subtitles":{"display_name":"English","id":"1146654567674912770","language_code":"EN"}}`), r)
+}
+
+func Test_CreateInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint"
+
+	cases := []struct {
+		name   string
+		params *types.CreateInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.CreateInput{MediaID: "1", Subtitles: resources.Subtitle{ID: "2"}},
+			expect: endpoint,
+		},
+		{
+			name:   "media id is not set",
+			params: &types.CreateInput{Subtitles: resources.Subtitle{ID: "2"}},
+			expect: "",
+		},
+		{
+			name:   "subtitle id is not set",
+			params: &types.CreateInput{MediaID: "1"},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, c.params.ResolveEndpoint(endpoint))
+		})
+	}
+}
+
+func Test_DeleteInput_Body(t *testing.T) {
+	cases := []struct {
+		name   string
+		params *types.DeleteInput
+		expect io.Reader
+	}{
+		{
+			name:   "ok",
+			params: &types.DeleteInput{MediaID: "1", MediaCategory: types.MediaCategoryAmplifyVideo, LanguageCode: "EN"},
+			expect: strings.NewReader(`{"id":"1","media_category":"AmplifyVideo","language_code":"EN"}`),
+		},
+		{
+			name:   "ok: without media category",
+			params: &types.DeleteInput{MediaID: "1", LanguageCode: "EN"},
+			expect: strings.NewReader(`{"id":"1","language_code":"EN"}`),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			r, err := c.params.Body()
+			assert.NoError(tt, err)
+			assert.Equal(tt, c.expect, r)
+		})
+	}
+}
+
+func Test_DeleteInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint"
+
+	cases := []struct {
+		name   string
+		params *types.DeleteInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.DeleteInput{MediaID: "1", LanguageCode: "EN"},
+			expect: endpoint,
+		},
+		{
+			name:   "language code is not set",
+			params: &types.DeleteInput{MediaID: "1"},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, c.params.ResolveEndpoint(endpoint))
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package types
+
+import "github.com/michimani/gotwi/resources"
+
+// CreateOutput is the output for the Create endpoint.
+type CreateOutput struct {
+	Data   resources.MediaWithSubtitles `json:"data"`
+	Errors []resources.PartialError     `json:"errors"`
+}
+
+func (r *CreateOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+// DeleteOutput is the output for the Delete endpoint.
+type DeleteOutput struct {
+	Data struct {
+		Deleted bool `json:"deleted"`
+	} `json:"data"`
+	Errors []resources.PartialError `json:"errors"`
+}
+
+func (r *DeleteOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
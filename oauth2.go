@@ -7,7 +7,10 @@ import (
 	"strings"
 )
 
-const OAuth2TokenEndpoint = "https://api.twitter.com/oauth2/token"
+const (
+	OAuth2TokenEndpoint           = "https://api.twitter.com/oauth2/token"
+	OAuth2InvalidateTokenEndpoint = "https://api.twitter.com/oauth2/invalidate_token"
+)
 
 type OAuth2TokenResponse struct {
 	TokenType   string `json:"token_type"`
@@ -45,3 +48,35 @@ func GenerateBearerToken(c IClient, apiKey, apiKeySecret string) (string, error)
 
 	return o2r.AccessToken, nil
 }
+
+// InvalidateBearerToken invalidates the bearer token generated by GenerateBearerToken.
+// After that, GenerateBearerToken returns a new token.
+func InvalidateBearerToken(c IClient, apiKey, apiKeySecret, token string) error {
+	if token == "" {
+		return fmt.Errorf("token is empty")
+	}
+
+	uv := url.Values{}
+	uv.Add("access_token", token)
+	body := strings.NewReader(uv.Encode())
+
+	req, err := http.NewRequest("POST", OAuth2InvalidateTokenEndpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=UTF-8")
+	req.SetBasicAuth(apiKey, apiKeySecret)
+
+	o2r := OAuth2TokenResponse{}
+	not200err, err := c.Exec(req, &o2r)
+	if err != nil {
+		return err
+	}
+
+	if not200err != nil {
+		return wrapWithAPIErr(not200err)
+	}
+
+	return nil
+}
@@ -85,3 +85,49 @@ func Test_GenerateBearerToken(t *testing.T) {
 		})
 	}
 }
+
+func Test_InvalidateBearerToken(t *testing.T) {
+	cases := []struct {
+		name    string
+		client  *gotwi.MockGotwiClient
+		token   string
+		wantErr bool
+	}{
+		{
+			name:    "normal",
+			client:  gotwi.NewMockGotwiClient("access_token", false, false),
+			token:   "access_token",
+			wantErr: false,
+		},
+		{
+			name:    "error: error",
+			client:  gotwi.NewMockGotwiClient("access_token", true, false),
+			token:   "access_token",
+			wantErr: true,
+		},
+		{
+			name:    "error: not 200 error",
+			client:  gotwi.NewMockGotwiClient("access_token", false, true),
+			token:   "access_token",
+			wantErr: true,
+		},
+		{
+			name:    "error: token is empty",
+			client:  gotwi.NewMockGotwiClient("access_token", false, false),
+			token:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			err := gotwi.InvalidateBearerToken(c.client, "key", "sec", c.token)
+			if c.wantErr {
+				assert.Error(tt, err)
+				return
+			}
+
+			assert.NoError(tt, err)
+		})
+	}
+}
@@ -0,0 +1,396 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/resources"
+)
+
+// DefaultRateLimitWindow is the time an app is skipped after a 429 response without the x-rate-limit-reset header.
+const DefaultRateLimitWindow = time.Duration(15) * time.Minute
+
+// ErrNoAvailableApp is returned by BearerPool when no app can be used for the call.
+var ErrNoAvailableApp = errors.New("no app is available")
+
+// App is an app whose OAuth 2.0 App-only bearer token is used by BearerPool.
+type App struct {
+	// Name identifies the app in the pool. Required and unique.
+	Name string
+	// APIKey and APIKeySecret are used to generate and invalidate the bearer token.
+	// They may be empty if BearerToken is set, but then the token cannot be regenerated.
+	APIKey       string
+	APIKeySecret string
+	// BearerToken is a pre-generated bearer token. If it is empty, it is generated with APIKey and APIKeySecret.
+	BearerToken string
+}
+
+type NewBearerPoolInput struct {
+	// HTTPClient is shared by all the apps. If it is nil, the default client of gotwi is used.
+	HTTPClient *http.Client
+	// Apps are the apps to spread the calls across. At least one is required.
+	Apps  []App
+	Gzip  bool
+	Debug bool
+}
+
+// BearerPool is a gotwi.IClient that spreads the calls across the bearer tokens of several apps.
+// For each call, it selects the app with the most remaining rate limit of the endpoint,
+// and fails over to the next app when the API returns 429 Too Many Requests or 401 Unauthorized.
+// An app whose bearer token is rejected with 401 is not used until RegenerateBearerToken succeeds.
+// It can be passed to any endpoint function, e.g. searchtweet.ListRecent.
+type BearerPool struct {
+	httpClient *http.Client
+	gzip       bool
+	debug      bool
+
+	// regenerateMu serializes RegenerateBearerToken.
+	regenerateMu sync.Mutex
+
+	mu   sync.Mutex
+	apps []*bearerApp
+}
+
+type bearerApp struct {
+	App
+	client *gotwi.Client
+	limits map[string]*util.RateLimitInformation
+	// unauthorized is true if the bearer token has been rejected with 401 Unauthorized.
+	unauthorized bool
+}
+
+func NewBearerPool(in *NewBearerPoolInput) (*BearerPool, error) {
+	if in == nil {
+		return nil, errors.New("NewBearerPoolInput is nil")
+	}
+	if len(in.Apps) == 0 {
+		return nil, errors.New("NewBearerPoolInput.Apps is empty")
+	}
+
+	p := &BearerPool{
+		httpClient: in.HTTPClient,
+		gzip:       in.Gzip,
+		debug:      in.Debug,
+	}
+
+	names := map[string]struct{}{}
+	for _, a := range in.Apps {
+		if a.Name == "" {
+			return nil, errors.New("App.Name is empty")
+		}
+		if _, ok := names[a.Name]; ok {
+			return nil, fmt.Errorf("App.Name %s is duplicated", a.Name)
+		}
+		names[a.Name] = struct{}{}
+
+		app := &bearerApp{App: a, limits: map[string]*util.RateLimitInformation{}}
+		if err := p.authorize(app); err != nil {
+			return nil, fmt.Errorf("app %s: %w", a.Name, err)
+		}
+		p.apps = append(p.apps, app)
+	}
+
+	return p, nil
+}
+
+// authorize creates the client of the app, generating the bearer token if it is not set.
+func (p *BearerPool) authorize(app *bearerApp) error {
+	if app.BearerToken == "" {
+		if app.APIKey == "" || app.APIKeySecret == "" {
+			return errors.New("BearerToken or APIKey and APIKeySecret are required")
+		}
+
+		c, err := gotwi.NewClient(&gotwi.NewClientInput{
+			HTTPClient:           p.httpClient,
+			Gzip:                 p.gzip,
+			AuthenticationMethod: gotwi.AuthenMethodOAuth2BearerToken,
+			APIKey:               app.APIKey,
+			APIKeySecret:         app.APIKeySecret,
+			Debug:                p.debug,
+		})
+		if err != nil {
+			return err
+		}
+		app.BearerToken = c.AccessToken()
+		app.client = c
+		return nil
+	}
+
+	c, err := gotwi.NewClientWithAccessToken(&gotwi.NewClientWithAccessTokenInput{
+		HTTPClient:  p.httpClient,
+		Gzip:        p.gzip,
+		AccessToken: app.BearerToken,
+		Debug:       p.debug,
+	})
+	if err != nil {
+		return err
+	}
+	app.client = c
+	return nil
+}
+
+// RegenerateBearerToken invalidates the bearer token of the app with the invalidate_token endpoint,
+// and generates a new one, e.g. when the token may have been leaked or has been rejected with 401 Unauthorized.
+// If the token has been rejected, a failure of the invalidation is ignored.
+// Calls in flight with the old token do not affect the app after the new token is set.
+func (p *BearerPool) RegenerateBearerToken(ctx context.Context, name string) error {
+	p.regenerateMu.Lock()
+	defer p.regenerateMu.Unlock()
+
+	p.mu.Lock()
+	app, err := p.app(name)
+	if err != nil {
+		p.mu.Unlock()
+		return err
+	}
+	current := app.App
+	client := app.client
+	unauthorized := app.unauthorized
+	p.mu.Unlock()
+
+	if current.APIKey == "" || current.APIKeySecret == "" {
+		return fmt.Errorf("app %s has no APIKey and APIKeySecret to regenerate the bearer token", name)
+	}
+
+	if err := gotwi.InvalidateBearerToken(client, current.APIKey, current.APIKeySecret, current.BearerToken); err != nil && !unauthorized {
+		return err
+	}
+
+	regenerated := &bearerApp{App: current}
+	regenerated.BearerToken = ""
+	if err := p.authorize(regenerated); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	app.BearerToken = regenerated.BearerToken
+	app.client = regenerated.client
+	app.unauthorized = false
+
+	return nil
+}
+
+// BearerToken returns the current bearer token of the app.
+func (p *BearerPool) BearerToken(name string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	app, err := p.app(name)
+	if err != nil {
+		return "", err
+	}
+	return app.BearerToken, nil
+}
+
+// RateLimit returns the last known rate limit of the endpoint for the app, or nil if it is unknown.
+// The endpoint is the one before resolving the path parameters, e.g. "https://api.x.com/2/tweets/search/recent".
+func (p *BearerPool) RateLimit(name, method, endpoint string) *util.RateLimitInformation {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	app, err := p.app(name)
+	if err != nil {
+		return nil
+	}
+	rli, ok := app.limits[rateLimitKey(method, endpoint)]
+	if !ok {
+		return nil
+	}
+	copied := *rli
+	return &copied
+}
+
+func (p *BearerPool) app(name string) (*bearerApp, error) {
+	for _, app := range p.apps {
+		if app.Name == name {
+			return app, nil
+		}
+	}
+	return nil, fmt.Errorf("app %s is not found", name)
+}
+
+func (p *BearerPool) CallAPI(ctx context.Context, endpoint, method string, params util.Parameters, i util.Response) error {
+	_, err := p.CallAPIWithResponse(ctx, endpoint, method, params, i)
+	return err
+}
+
+// CallAPIWithResponse calls the API with the app that has the most remaining rate limit of the endpoint.
+// When the API returns 429 Too Many Requests or 401 Unauthorized, it retries with the next app, until no app is left.
+func (p *BearerPool) CallAPIWithResponse(ctx context.Context, endpoint, method string, params util.Parameters, i util.Response) (*gotwi.ClientResponse, error) {
+	key := rateLimitKey(method, endpoint)
+	tried := map[*bearerApp]struct{}{}
+
+	var lastRes *gotwi.ClientResponse
+	var lastErr error
+	for {
+		app, c := p.selectApp(key, tried)
+		if app == nil {
+			break
+		}
+		tried[app] = struct{}{}
+
+		cr, err := c.CallAPIWithResponse(ctx, endpoint, method, params, i)
+		if cr == nil {
+			return nil, err
+		}
+
+		p.record(app, c, key, cr)
+		if capture := gotwi.ResponseCaptureFrom(ctx); capture != nil {
+			*capture = *cr
+		}
+
+		if cr.StatusCode != http.StatusTooManyRequests && cr.StatusCode != http.StatusUnauthorized {
+			return cr, err
+		}
+		lastRes, lastErr = cr, err
+	}
+
+	if lastErr != nil {
+		return lastRes, lastErr
+	}
+
+	if !p.hasAuthorizedApp() {
+		return nil, fmt.Errorf("%w: the bearer tokens of all apps are rejected", ErrNoAvailableApp)
+	}
+	return nil, fmt.Errorf("%w: all apps are rate limited for %s", ErrRateLimited, key)
+}
+
+// selectApp returns the app with the most remaining rate limit of the endpoint, excluding the tried ones.
+// An app whose rate limit is unknown is preferred, and the one whose rate limit is exhausted
+// or whose bearer token has been rejected is skipped.
+func (p *BearerPool) selectApp(key string, tried map[*bearerApp]struct{}) (*bearerApp, *gotwi.Client) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t := now()
+	var selected *bearerApp
+	best := -1
+	for _, app := range p.apps {
+		if _, ok := tried[app]; ok || app.unauthorized {
+			continue
+		}
+
+		remaining := int(^uint(0) >> 1)
+		if rli, ok := app.limits[key]; ok {
+			if rli.ResetAt != nil && !t.Before(*rli.ResetAt) {
+				delete(app.limits, key)
+			} else {
+				remaining = rli.Remaining
+			}
+		}
+
+		if remaining > 0 && remaining > best {
+			selected, best = app, remaining
+		}
+	}
+
+	if selected == nil {
+		return nil, nil
+	}
+	return selected, selected.client
+}
+
+// record updates the state of the app with the response of the call made with c.
+// The response is ignored if the bearer token of the app has been regenerated since c was selected.
+func (p *BearerPool) record(app *bearerApp, c *gotwi.Client, key string, cr *gotwi.ClientResponse) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if app.client != c {
+		return
+	}
+	if cr.StatusCode == http.StatusUnauthorized {
+		app.unauthorized = true
+	}
+
+	rli := cr.RateLimitInfo
+	if cr.StatusCode == http.StatusTooManyRequests {
+		if rli == nil {
+			resetAt := now().Add(DefaultRateLimitWindow)
+			rli = &util.RateLimitInformation{ResetAt: &resetAt}
+		}
+		exhausted := *rli
+		exhausted.Remaining = 0
+		rli = &exhausted
+	}
+	if rli == nil {
+		return
+	}
+	app.limits[key] = rli
+}
+
+// client returns the client of the app selected as for an endpoint whose rate limit is unknown,
+// or nil if no app is available.
+func (p *BearerPool) client() *gotwi.Client {
+	_, c := p.selectApp("", nil)
+	return c
+}
+
+// Exec sends the request with the client of an available app. The request must already be authenticated.
+func (p *BearerPool) Exec(req *http.Request, i util.Response) (*resources.Non2XXError, error) {
+	c := p.client()
+	if c == nil {
+		return nil, ErrNoAvailableApp
+	}
+	return c.Exec(req, i)
+}
+
+func (p *BearerPool) IsReady() bool {
+	if p == nil {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, app := range p.apps {
+		if !app.unauthorized && app.client.IsReady() {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *BearerPool) hasAuthorizedApp() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, app := range p.apps {
+		if !app.unauthorized {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessToken returns the bearer token of an available app, or "" if no app is available.
+// Each call uses the token of the selected app, regardless of this value.
+func (p *BearerPool) AccessToken() string {
+	c := p.client()
+	if c == nil {
+		return ""
+	}
+	return c.AccessToken()
+}
+
+func (p *BearerPool) AuthenticationMethod() gotwi.AuthenticationMethod {
+	return gotwi.AuthenMethodOAuth2BearerToken
+}
+
+func (p *BearerPool) OAuthToken() string {
+	return ""
+}
+
+func (p *BearerPool) OAuthConsumerKey() string {
+	return ""
+}
+
+func (p *BearerPool) SigningKey() string {
+	return ""
+}
@@ -0,0 +1,331 @@
+package pool_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/pool"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAPI responds to the requests with the status and remaining rate limit of each bearer token,
+// and records the tokens of the requests.
+type fakeAPI struct {
+	mu        sync.Mutex
+	status    map[string]int
+	remaining map[string]int
+	resetAt   time.Time
+	tokens    []string
+	issued    int
+
+	// onRequest is called with the bearer token before responding, if it is not nil.
+	onRequest func(token string)
+}
+
+func (f *fakeAPI) httpClient() *http.Client {
+	return &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if f.onRequest != nil {
+				f.onRequest(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+			}
+
+			f.mu.Lock()
+			defer f.mu.Unlock()
+
+			res := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json;charset=UTF-8"}},
+				Body:       io.NopCloser(strings.NewReader(`{"text":"ok"}`)),
+				Request:    req,
+			}
+
+			switch req.URL.String() {
+			case gotwi.OAuth2TokenEndpoint:
+				f.issued++
+				res.Body = io.NopCloser(strings.NewReader(`{"token_type":"bearer","access_token":"issued-` + strconv.Itoa(f.issued) + `"}`))
+				return res, nil
+			case gotwi.OAuth2InvalidateTokenEndpoint:
+				return res, nil
+			}
+
+			token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+			f.tokens = append(f.tokens, token)
+
+			if s, ok := f.status[token]; ok {
+				res.StatusCode = s
+				res.Status = http.StatusText(s)
+			}
+			if r, ok := f.remaining[token]; ok {
+				res.Header.Set("X-Rate-Limit-Limit", "450")
+				res.Header.Set("X-Rate-Limit-Remaining", strconv.Itoa(r))
+				res.Header.Set("X-Rate-Limit-Reset", strconv.FormatInt(f.resetAt.Unix(), 10))
+			}
+			return res, nil
+		}),
+	}
+}
+
+func (f *fakeAPI) requestedTokens() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.tokens...)
+}
+
+func Test_NewBearerPool(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      *pool.NewBearerPoolInput
+		wantErr bool
+	}{
+		{
+			name: "ok: pre-generated tokens",
+			in: &pool.NewBearerPoolInput{
+				Apps: []pool.App{{Name: "a", BearerToken: "token-a"}, {Name: "b", BearerToken: "token-b"}},
+			},
+		},
+		{
+			name: "ok: generate token",
+			in: &pool.NewBearerPoolInput{
+				HTTPClient: (&fakeAPI{}).httpClient(),
+				Apps:       []pool.App{{Name: "a", APIKey: "key", APIKeySecret: "secret"}},
+			},
+		},
+		{
+			name:    "ng: nil input",
+			wantErr: true,
+		},
+		{
+			name:    "ng: no apps",
+			in:      &pool.NewBearerPoolInput{},
+			wantErr: true,
+		},
+		{
+			name: "ng: no name",
+			in: &pool.NewBearerPoolInput{
+				Apps: []pool.App{{BearerToken: "token-a"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ng: duplicated name",
+			in: &pool.NewBearerPoolInput{
+				Apps: []pool.App{{Name: "a", BearerToken: "token-a"}, {Name: "a", BearerToken: "token-b"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ng: no credentials",
+			in: &pool.NewBearerPoolInput{
+				Apps: []pool.App{{Name: "a"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			p, err := pool.NewBearerPool(c.in)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, p)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.True(tt, p.IsReady())
+			assert.EqualValues(tt, gotwi.AuthenMethodOAuth2BearerToken, p.AuthenticationMethod())
+		})
+	}
+}
+
+func Test_BearerPool_CallAPI(t *testing.T) {
+	const endpoint = "https://api.x.com/2/tweets/search/recent"
+
+	current := time.Unix(1700000000, 0)
+	restore := pool.ExportSetNow(func() time.Time { return current })
+	defer restore()
+
+	cases := []struct {
+		name         string
+		status       map[string]int
+		remaining    map[string]int
+		wantAnyErr   bool
+		expectTokens []string
+	}{
+		{
+			name:         "ok: the app with unknown rate limit is preferred, then the one with the most remaining",
+			remaining:    map[string]int{"token-a": 10, "token-b": 20, "token-c": 5},
+			expectTokens: []string{"token-a", "token-b", "token-c", "token-b", "token-b"},
+		},
+		{
+			name:         "ok: fail over on 429",
+			status:       map[string]int{"token-a": http.StatusTooManyRequests},
+			remaining:    map[string]int{"token-a": 0, "token-b": 100, "token-c": 50},
+			expectTokens: []string{"token-a", "token-b", "token-c", "token-b", "token-b", "token-b"},
+		},
+		{
+			name:         "ng: all apps are rate limited",
+			status:       map[string]int{"token-a": http.StatusTooManyRequests, "token-b": http.StatusTooManyRequests, "token-c": http.StatusTooManyRequests},
+			wantAnyErr:   true,
+			expectTokens: []string{"token-a", "token-b", "token-c"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			api := &fakeAPI{status: c.status, remaining: c.remaining, resetAt: current.Add(15 * time.Minute)}
+			p, err := pool.NewBearerPool(&pool.NewBearerPoolInput{
+				HTTPClient: api.httpClient(),
+				Apps: []pool.App{
+					{Name: "a", BearerToken: "token-a"},
+					{Name: "b", BearerToken: "token-b"},
+					{Name: "c", BearerToken: "token-c"},
+				},
+			})
+			asst.NoError(err)
+
+			for range 3 {
+				err = p.CallAPI(context.Background(), endpoint, http.MethodGet, &gotwi.CustomInput{}, &testResponse{})
+				if err != nil {
+					break
+				}
+			}
+
+			if c.wantAnyErr {
+				asst.Error(err)
+				asst.Equal(c.expectTokens, api.requestedTokens())
+
+				// the API is not called again until the rate limits are reset
+				err = p.CallAPI(context.Background(), endpoint, http.MethodGet, &gotwi.CustomInput{}, &testResponse{})
+				asst.ErrorIs(err, pool.ErrRateLimited)
+				asst.Equal(c.expectTokens, api.requestedTokens())
+				return
+			}
+
+			asst.NoError(err)
+			for range 2 {
+				asst.NoError(p.CallAPI(context.Background(), endpoint, http.MethodGet, &gotwi.CustomInput{}, &testResponse{}))
+			}
+			asst.Equal(c.expectTokens, api.requestedTokens())
+			if rli := p.RateLimit("c", http.MethodGet, endpoint); asst.NotNil(rli) {
+				asst.Equal(c.remaining["token-c"], rli.Remaining)
+			}
+		})
+	}
+}
+
+func Test_BearerPool_Unauthorized(t *testing.T) {
+	asst := assert.New(t)
+
+	api := &fakeAPI{status: map[string]int{"issued-1": http.StatusUnauthorized}}
+	p, err := pool.NewBearerPool(&pool.NewBearerPoolInput{
+		HTTPClient: api.httpClient(),
+		Apps: []pool.App{
+			{Name: "a", APIKey: "key", APIKeySecret: "secret"},
+			{Name: "b", BearerToken: "token-b"},
+		},
+	})
+	asst.NoError(err)
+
+	// fail over on 401, and the app is not used until its token is regenerated
+	for range 2 {
+		asst.NoError(p.CallAPI(context.Background(), "https://api.x.com/2/test", http.MethodGet, &gotwi.CustomInput{}, &testResponse{}))
+	}
+	asst.Equal([]string{"issued-1", "token-b", "token-b"}, api.requestedTokens())
+	asst.Equal("token-b", p.AccessToken())
+
+	asst.NoError(p.RegenerateBearerToken(context.Background(), "a"))
+	asst.NoError(p.CallAPI(context.Background(), "https://api.x.com/2/test", http.MethodGet, &gotwi.CustomInput{}, &testResponse{}))
+	asst.Equal("issued-2", api.requestedTokens()[3])
+
+	// no app is available when all tokens are rejected
+	api.mu.Lock()
+	api.status = map[string]int{"issued-2": http.StatusUnauthorized, "token-b": http.StatusUnauthorized}
+	api.mu.Unlock()
+	asst.Error(p.CallAPI(context.Background(), "https://api.x.com/2/test", http.MethodGet, &gotwi.CustomInput{}, &testResponse{}))
+	err = p.CallAPI(context.Background(), "https://api.x.com/2/test", http.MethodGet, &gotwi.CustomInput{}, &testResponse{})
+	asst.ErrorIs(err, pool.ErrNoAvailableApp)
+	asst.False(p.IsReady())
+	asst.Equal("", p.AccessToken())
+
+	_, err = p.Exec(&http.Request{}, &testResponse{})
+	asst.ErrorIs(err, pool.ErrNoAvailableApp)
+}
+
+func Test_BearerPool_RegenerateBearerToken_CallInFlight(t *testing.T) {
+	asst := assert.New(t)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	api := &fakeAPI{
+		status: map[string]int{"issued-1": http.StatusUnauthorized},
+		onRequest: func(token string) {
+			if token == "issued-1" {
+				once.Do(func() {
+					close(started)
+					<-release
+				})
+			}
+		},
+	}
+	p, err := pool.NewBearerPool(&pool.NewBearerPoolInput{
+		HTTPClient: api.httpClient(),
+		Apps:       []pool.App{{Name: "a", APIKey: "key", APIKeySecret: "secret"}},
+	})
+	asst.NoError(err)
+
+	done := make(chan error)
+	go func() {
+		done <- p.CallAPI(context.Background(), "https://api.x.com/2/test", http.MethodGet, &gotwi.CustomInput{}, &testResponse{})
+	}()
+	<-started
+
+	asst.NoError(p.RegenerateBearerToken(context.Background(), "a"))
+	close(release)
+	asst.Error(<-done)
+
+	// the 401 with the old token does not make the regenerated app unavailable
+	asst.True(p.IsReady())
+	asst.NoError(p.CallAPI(context.Background(), "https://api.x.com/2/test", http.MethodGet, &gotwi.CustomInput{}, &testResponse{}))
+	asst.Equal("issued-2", api.requestedTokens()[1])
+}
+
+func Test_BearerPool_RegenerateBearerToken(t *testing.T) {
+	asst := assert.New(t)
+
+	api := &fakeAPI{}
+	p, err := pool.NewBearerPool(&pool.NewBearerPoolInput{
+		HTTPClient: api.httpClient(),
+		Apps: []pool.App{
+			{Name: "a", APIKey: "key", APIKeySecret: "secret"},
+			{Name: "b", BearerToken: "token-b"},
+		},
+	})
+	asst.NoError(err)
+
+	token, err := p.BearerToken("a")
+	asst.NoError(err)
+	asst.Equal("issued-1", token)
+
+	asst.NoError(p.RegenerateBearerToken(context.Background(), "a"))
+	token, err = p.BearerToken("a")
+	asst.NoError(err)
+	asst.Equal("issued-2", token)
+
+	asst.NoError(p.CallAPI(context.Background(), "https://api.x.com/2/test", http.MethodGet, &gotwi.CustomInput{}, &testResponse{}))
+	asst.Equal([]string{"issued-2"}, api.requestedTokens())
+
+	// an app without API key cannot regenerate the token
+	asst.Error(p.RegenerateBearerToken(context.Background(), "b"))
+	asst.Error(p.RegenerateBearerToken(context.Background(), "unknown"))
+}
@@ -0,0 +1,81 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrCredentialsNotFound is returned by CredentialStore when no credentials are stored for the user.
+var ErrCredentialsNotFound = errors.New("credentials are not found")
+
+// Credentials are the tokens to act on behalf of a user.
+// Either OAuthToken and OAuthTokenSecret (OAuth 1.0a User Context)
+// or AccessToken (OAuth 2.0 Authorization Code with PKCE) must be set.
+type Credentials struct {
+	OAuthToken       string
+	OAuthTokenSecret string
+
+	AccessToken string
+}
+
+func (c *Credentials) valid() bool {
+	if c == nil {
+		return false
+	}
+	if c.AccessToken != "" {
+		return true
+	}
+	return c.OAuthToken != "" && c.OAuthTokenSecret != ""
+}
+
+// CredentialStore resolves the credentials of users.
+// Implementations must be safe for concurrent use.
+type CredentialStore interface {
+	// Get returns the credentials of the user. It returns ErrCredentialsNotFound if there are no credentials.
+	Get(ctx context.Context, userID string) (*Credentials, error)
+}
+
+// MemoryCredentialStore is a CredentialStore that keeps the credentials in memory.
+type MemoryCredentialStore struct {
+	mu          sync.Mutex
+	credentials map[string]Credentials
+}
+
+func NewMemoryCredentialStore() *MemoryCredentialStore {
+	return &MemoryCredentialStore{
+		credentials: map[string]Credentials{},
+	}
+}
+
+func (s *MemoryCredentialStore) Get(ctx context.Context, userID string) (*Credentials, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.credentials[userID]
+	if !ok {
+		return nil, ErrCredentialsNotFound
+	}
+
+	return &c, nil
+}
+
+// Put stores the credentials of the user, replacing the existing ones.
+func (s *MemoryCredentialStore) Put(userID string, c *Credentials) error {
+	if c == nil {
+		return errors.New("Credentials is nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.credentials[userID] = *c
+
+	return nil
+}
+
+// Delete removes the credentials of the user.
+func (s *MemoryCredentialStore) Delete(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.credentials, userID)
+}
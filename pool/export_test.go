@@ -0,0 +1,10 @@
+package pool
+
+import "time"
+
+// ExportSetNow replaces the clock of the package, and returns the function to restore it.
+func ExportSetNow(f func() time.Time) func() {
+	orig := now
+	now = f
+	return func() { now = orig }
+}
@@ -0,0 +1,309 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+)
+
+// DefaultIdleTimeout is the time after which a UserClient that has not been used is evicted from UserPool.
+const DefaultIdleTimeout = time.Duration(30) * time.Minute
+
+// ErrRateLimited is returned by UserClient and BearerPool without calling the API
+// when the rate limit of the endpoint is known to be exhausted.
+var ErrRateLimited = errors.New("rate limit is exhausted")
+
+var now = time.Now
+
+type NewUserPoolInput struct {
+	// HTTPClient is shared by all the clients of the pool. If it is nil, the default client of gotwi is used.
+	HTTPClient *http.Client
+	// APIKey and APIKeySecret are the credentials of the app, which are required for OAuth 1.0a User Context.
+	// Unlike NewClient, the pool does not read them from the environment variables.
+	APIKey       string
+	APIKeySecret string
+	// Store resolves the credentials of the users. Required.
+	Store CredentialStore
+	// IdleTimeout is the time after which an unused client is evicted. The default is DefaultIdleTimeout.
+	IdleTimeout time.Duration
+	Gzip        bool
+	Debug       bool
+}
+
+// UserPool creates and caches a client for each user, whose credentials are resolved from a CredentialStore on demand.
+// All the clients share one http.Client and the app credentials.
+type UserPool struct {
+	httpClient   *http.Client
+	apiKey       string
+	apiKeySecret string
+	store        CredentialStore
+	idleTimeout  time.Duration
+	gzip         bool
+	debug        bool
+
+	mu        sync.Mutex
+	clients   map[string]*UserClient
+	lastSweep time.Time
+}
+
+func NewUserPool(in *NewUserPoolInput) (*UserPool, error) {
+	if in == nil {
+		return nil, errors.New("NewUserPoolInput is nil")
+	}
+	if in.Store == nil {
+		return nil, errors.New("NewUserPoolInput.Store is nil")
+	}
+
+	p := &UserPool{
+		httpClient:   in.HTTPClient,
+		apiKey:       in.APIKey,
+		apiKeySecret: in.APIKeySecret,
+		store:        in.Store,
+		idleTimeout:  in.IdleTimeout,
+		gzip:         in.Gzip,
+		debug:        in.Debug,
+		clients:      map[string]*UserClient{},
+		lastSweep:    now(),
+	}
+	if p.idleTimeout <= 0 {
+		p.idleTimeout = DefaultIdleTimeout
+	}
+
+	return p, nil
+}
+
+// Client returns the client of the user. It resolves the credentials from the store if the client is not cached.
+func (p *UserPool) Client(ctx context.Context, userID string) (*UserClient, error) {
+	if userID == "" {
+		return nil, errors.New("userID is empty")
+	}
+
+	p.evictIdleIfDue()
+
+	p.mu.Lock()
+	uc, ok := p.clients[userID]
+	p.mu.Unlock()
+	if ok {
+		uc.touch()
+		return uc, nil
+	}
+
+	creds, err := p.store.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !creds.valid() {
+		return nil, fmt.Errorf("credentials of user %s are incomplete", userID)
+	}
+
+	c, err := p.newClient(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// another goroutine may have created the client while resolving the credentials
+	if existing, ok := p.clients[userID]; ok {
+		existing.touch()
+		return existing, nil
+	}
+
+	uc = &UserClient{
+		Client: c,
+		userID: userID,
+		pool:   p,
+		limits: map[string]*util.RateLimitInformation{},
+	}
+	uc.touch()
+	p.clients[userID] = uc
+
+	return uc, nil
+}
+
+func (p *UserPool) newClient(creds *Credentials) (*gotwi.Client, error) {
+	if creds.AccessToken != "" {
+		return gotwi.NewClientWithAccessToken(&gotwi.NewClientWithAccessTokenInput{
+			HTTPClient:  p.httpClient,
+			Gzip:        p.gzip,
+			AccessToken: creds.AccessToken,
+			Debug:       p.debug,
+		})
+	}
+
+	if p.apiKey == "" || p.apiKeySecret == "" {
+		return nil, errors.New("APIKey and APIKeySecret are required for OAuth 1.0a User Context")
+	}
+
+	return gotwi.NewClient(&gotwi.NewClientInput{
+		HTTPClient:           p.httpClient,
+		Gzip:                 p.gzip,
+		AuthenticationMethod: gotwi.AuthenMethodOAuth1UserContext,
+		OAuthToken:           creds.OAuthToken,
+		OAuthTokenSecret:     creds.OAuthTokenSecret,
+		APIKey:               p.apiKey,
+		APIKeySecret:         p.apiKeySecret,
+		Debug:                p.debug,
+	})
+}
+
+// Invalidate removes the client of the user from the pool,
+// so that the credentials are resolved again on the next call of Client, e.g. after they are refreshed.
+func (p *UserPool) Invalidate(userID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.clients, userID)
+}
+
+// invalidateClient removes uc only if it is still the client of the user.
+func (p *UserPool) invalidateClient(uc *UserClient) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.clients[uc.userID] == uc {
+		delete(p.clients, uc.userID)
+	}
+}
+
+// EvictIdle removes the clients that have not been used within the idle timeout, and returns the number of them.
+// It is also done by Client periodically.
+func (p *UserPool) EvictIdle() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t := now()
+	p.lastSweep = t
+	n := 0
+	for id, uc := range p.clients {
+		if t.Sub(uc.LastUsed()) >= p.idleTimeout {
+			delete(p.clients, id)
+			n++
+		}
+	}
+
+	return n
+}
+
+func (p *UserPool) evictIdleIfDue() {
+	p.mu.Lock()
+	due := now().Sub(p.lastSweep) >= p.idleTimeout
+	p.mu.Unlock()
+
+	if due {
+		p.EvictIdle()
+	}
+}
+
+// Len returns the number of the cached clients.
+func (p *UserPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.clients)
+}
+
+// UserClient is the client of a user in UserPool.
+// It records the rate limit of each endpoint from the responses,
+// and returns ErrRateLimited without calling the API while the limit is exhausted.
+// When the API returns 401 Unauthorized, the client is removed from the pool.
+type UserClient struct {
+	*gotwi.Client
+	userID string
+	pool   *UserPool
+
+	mu       sync.Mutex
+	lastUsed time.Time
+	limits   map[string]*util.RateLimitInformation
+}
+
+// UserID returns the ID of the user of the client.
+func (u *UserClient) UserID() string {
+	return u.userID
+}
+
+// LastUsed returns the time when the client was last returned by the pool or called.
+func (u *UserClient) LastUsed() time.Time {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.lastUsed
+}
+
+// RateLimit returns the last known rate limit of the endpoint for the user, or nil if it is unknown.
+// The endpoint is the one before resolving the path parameters, e.g. "https://api.x.com/2/tweets/:id".
+func (u *UserClient) RateLimit(method, endpoint string) *util.RateLimitInformation {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	rli, ok := u.limits[rateLimitKey(method, endpoint)]
+	if !ok {
+		return nil
+	}
+	copied := *rli
+	return &copied
+}
+
+func (u *UserClient) CallAPI(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+	_, err := u.CallAPIWithResponse(ctx, endpoint, method, p, i)
+	return err
+}
+
+func (u *UserClient) CallAPIWithResponse(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) (*gotwi.ClientResponse, error) {
+	u.touch()
+
+	key := rateLimitKey(method, endpoint)
+	if err := u.checkRateLimit(key); err != nil {
+		return nil, err
+	}
+
+	cr, err := u.Client.CallAPIWithResponse(ctx, endpoint, method, p, i)
+	if cr == nil {
+		return nil, err
+	}
+
+	if capture := gotwi.ResponseCaptureFrom(ctx); capture != nil {
+		*capture = *cr
+	}
+
+	if cr.RateLimitInfo != nil {
+		u.mu.Lock()
+		u.limits[key] = cr.RateLimitInfo
+		u.mu.Unlock()
+	}
+
+	if cr.StatusCode == http.StatusUnauthorized && u.pool != nil {
+		u.pool.invalidateClient(u)
+	}
+
+	return cr, err
+}
+
+func (u *UserClient) checkRateLimit(key string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	rli, ok := u.limits[key]
+	if !ok || rli.Remaining > 0 || rli.ResetAt == nil {
+		return nil
+	}
+	if !now().Before(*rli.ResetAt) {
+		delete(u.limits, key)
+		return nil
+	}
+
+	return fmt.Errorf("%w: user=%s endpoint=%s reset_at=%s", ErrRateLimited, u.userID, key, rli.ResetAt.Format(time.RFC3339))
+}
+
+func (u *UserClient) touch() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.lastUsed = now()
+}
+
+func rateLimitKey(method, endpoint string) string {
+	return method + " " + endpoint
+}
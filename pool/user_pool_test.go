@@ -0,0 +1,277 @@
+package pool_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/pool"
+	"github.com/stretchr/testify/assert"
+)
+
+type testResponse struct {
+	Text string `json:"text"`
+}
+
+func (r *testResponse) HasPartialError() bool { return false }
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newHTTPClient returns an http.Client that responds with the status and rate limit headers,
+// and counts the requests.
+func newHTTPClient(status, remaining int, resetAt time.Time, calls *atomic.Int32) *http.Client {
+	return &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls.Add(1)
+			return &http.Response{
+				StatusCode: status,
+				Status:     http.StatusText(status),
+				Header: http.Header{
+					"Content-Type":           {"application/json;charset=UTF-8"},
+					"X-Rate-Limit-Limit":     {"15"},
+					"X-Rate-Limit-Remaining": {strconv.Itoa(remaining)},
+					"X-Rate-Limit-Reset":     {strconv.FormatInt(resetAt.Unix(), 10)},
+				},
+				Body:    io.NopCloser(strings.NewReader(`{"text":"ok"}`)),
+				Request: req,
+			}, nil
+		}),
+	}
+}
+
+func newStore() *pool.MemoryCredentialStore {
+	s := pool.NewMemoryCredentialStore()
+	s.Put("oauth1", &pool.Credentials{OAuthToken: "token", OAuthTokenSecret: "secret"})
+	s.Put("oauth2", &pool.Credentials{AccessToken: "access-token"})
+	s.Put("incomplete", &pool.Credentials{OAuthToken: "token"})
+	return s
+}
+
+func Test_NewUserPool(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      *pool.NewUserPoolInput
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			in:   &pool.NewUserPoolInput{Store: pool.NewMemoryCredentialStore()},
+		},
+		{
+			name:    "ng: nil input",
+			in:      nil,
+			wantErr: true,
+		},
+		{
+			name:    "ng: nil store",
+			in:      &pool.NewUserPoolInput{},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			p, err := pool.NewUserPool(c.in)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, p)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, p)
+		})
+	}
+}
+
+func Test_UserPool_Client(t *testing.T) {
+	cases := []struct {
+		name         string
+		apiKey       string
+		userID       string
+		wantErr      error
+		wantAnyErr   bool
+		expectMethod gotwi.AuthenticationMethod
+	}{
+		{
+			name:         "ok: OAuth 1.0a",
+			apiKey:       "api-key",
+			userID:       "oauth1",
+			expectMethod: gotwi.AuthenMethodOAuth1UserContext,
+		},
+		{
+			name:         "ok: OAuth 2.0 without API key",
+			userID:       "oauth2",
+			expectMethod: gotwi.AuthenMethodOAuth2BearerToken,
+		},
+		{
+			name:    "ng: not found",
+			apiKey:  "api-key",
+			userID:  "unknown",
+			wantErr: pool.ErrCredentialsNotFound,
+		},
+		{
+			name:       "ng: incomplete credentials",
+			apiKey:     "api-key",
+			userID:     "incomplete",
+			wantAnyErr: true,
+		},
+		{
+			name:       "ng: OAuth 1.0a without API key",
+			userID:     "oauth1",
+			wantAnyErr: true,
+		},
+		{
+			name:       "ng: empty user ID",
+			userID:     "",
+			wantAnyErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			p, err := pool.NewUserPool(&pool.NewUserPoolInput{
+				APIKey:       c.apiKey,
+				APIKeySecret: c.apiKey,
+				Store:        newStore(),
+			})
+			asst.NoError(err)
+
+			uc, err := p.Client(context.Background(), c.userID)
+			if c.wantErr != nil || c.wantAnyErr {
+				asst.Error(err)
+				if c.wantErr != nil {
+					asst.ErrorIs(err, c.wantErr)
+				}
+				asst.Nil(uc)
+				asst.Equal(0, p.Len())
+				return
+			}
+
+			asst.NoError(err)
+			asst.Equal(c.userID, uc.UserID())
+			asst.Equal(c.expectMethod, uc.AuthenticationMethod())
+			asst.True(uc.IsReady())
+
+			again, err := p.Client(context.Background(), c.userID)
+			asst.NoError(err)
+			asst.Same(uc, again)
+			asst.Equal(1, p.Len())
+		})
+	}
+}
+
+func Test_UserClient_RateLimit(t *testing.T) {
+	asst := assert.New(t)
+
+	current := time.Unix(1700000000, 0)
+	restore := pool.ExportSetNow(func() time.Time { return current })
+	defer restore()
+
+	var calls atomic.Int32
+	p, err := pool.NewUserPool(&pool.NewUserPoolInput{
+		HTTPClient: newHTTPClient(http.StatusOK, 0, current.Add(time.Minute), &calls),
+		Store:      newStore(),
+	})
+	asst.NoError(err)
+
+	uc, err := p.Client(context.Background(), "oauth2")
+	asst.NoError(err)
+
+	const endpoint = "https://api.x.com/2/test"
+	cr := gotwi.ClientResponse{}
+	ctx := gotwi.WithResponseCapture(context.Background(), &cr)
+	asst.NoError(uc.CallAPI(ctx, endpoint, http.MethodGet, &gotwi.CustomInput{}, &testResponse{}))
+	asst.Equal(http.StatusOK, cr.StatusCode)
+
+	rli := uc.RateLimit(http.MethodGet, endpoint)
+	if asst.NotNil(rli) {
+		asst.Equal(15, rli.Limit)
+		asst.Equal(0, rli.Remaining)
+	}
+	asst.Nil(uc.RateLimit(http.MethodPost, endpoint))
+
+	// the limit is exhausted until the reset
+	err = uc.CallAPI(context.Background(), endpoint, http.MethodGet, &gotwi.CustomInput{}, &testResponse{})
+	asst.ErrorIs(err, pool.ErrRateLimited)
+	asst.Equal(int32(1), calls.Load())
+
+	// other endpoints are not limited
+	asst.NoError(uc.CallAPI(context.Background(), endpoint, http.MethodPost, &gotwi.CustomInput{}, &testResponse{}))
+	asst.Equal(int32(2), calls.Load())
+
+	current = current.Add(time.Minute)
+	asst.NoError(uc.CallAPI(context.Background(), endpoint, http.MethodGet, &gotwi.CustomInput{}, &testResponse{}))
+	asst.Equal(int32(3), calls.Load())
+}
+
+func Test_UserClient_Unauthorized(t *testing.T) {
+	asst := assert.New(t)
+
+	var calls atomic.Int32
+	p, err := pool.NewUserPool(&pool.NewUserPoolInput{
+		HTTPClient: newHTTPClient(http.StatusUnauthorized, 10, time.Now().Add(time.Minute), &calls),
+		Store:      newStore(),
+	})
+	asst.NoError(err)
+
+	uc, err := p.Client(context.Background(), "oauth2")
+	asst.NoError(err)
+	asst.Equal(1, p.Len())
+
+	err = uc.CallAPI(context.Background(), "https://api.x.com/2/test", http.MethodGet, &gotwi.CustomInput{}, &testResponse{})
+	asst.Error(err)
+	asst.Equal(0, p.Len())
+
+	again, err := p.Client(context.Background(), "oauth2")
+	asst.NoError(err)
+	asst.NotSame(uc, again)
+}
+
+func Test_UserPool_EvictIdle(t *testing.T) {
+	asst := assert.New(t)
+
+	current := time.Unix(1700000000, 0)
+	restore := pool.ExportSetNow(func() time.Time { return current })
+	defer restore()
+
+	p, err := pool.NewUserPool(&pool.NewUserPoolInput{
+		APIKey:       "api-key",
+		APIKeySecret: "api-key-secret",
+		Store:        newStore(),
+		IdleTimeout:  time.Minute,
+	})
+	asst.NoError(err)
+
+	_, err = p.Client(context.Background(), "oauth1")
+	asst.NoError(err)
+	current = current.Add(30 * time.Second)
+	_, err = p.Client(context.Background(), "oauth2")
+	asst.NoError(err)
+	asst.Equal(2, p.Len())
+
+	current = current.Add(30 * time.Second)
+	asst.Equal(1, p.EvictIdle())
+	asst.Equal(1, p.Len())
+
+	// the sweep is also done by Client after the idle timeout
+	current = current.Add(time.Minute)
+	_, err = p.Client(context.Background(), "oauth1")
+	asst.NoError(err)
+	asst.Equal(1, p.Len())
+
+	p.Invalidate("oauth1")
+	asst.Equal(0, p.Len())
+}
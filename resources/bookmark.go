@@ -0,0 +1,6 @@
+package resources
+
+type BookmarkFolder struct {
+	ID   *string `json:"id"`
+	Name *string `json:"name"`
+}
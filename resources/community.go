@@ -0,0 +1,17 @@
+package resources
+
+import "time"
+
+type Community struct {
+	ID          *string    `json:"id"`
+	Name        *string    `json:"name"`
+	Description *string    `json:"description,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	MemberCount *int       `json:"member_count,omitempty"`
+	// Access is "Public" or "Closed".
+	Access *string `json:"access,omitempty"`
+	// JoinPolicy is "Open" or "RestrictedJoinRequestsRequireAdminApproval" and so on.
+	JoinPolicy *string `json:"join_policy,omitempty"`
+
+	Extra *ExtraFields `json:"-"`
+}
@@ -21,3 +21,115 @@ type Compliance struct {
 	DownloadURL       string         `json:"download_url"`
 	DownloadExpiresAt *time.Time     `json:"download_expires_at"`
 }
+
+// TweetComplianceEvent is an event of the Tweet compliance stream.
+// Exactly one of the fields is set.
+type TweetComplianceEvent struct {
+	Delete    *TweetEvent         `json:"delete,omitempty"`
+	TweetEdit *TweetEditEvent     `json:"tweet_edit,omitempty"`
+	Withheld  *TweetWithheldEvent `json:"withheld,omitempty"`
+	Drop      *TweetEvent         `json:"drop,omitempty"`
+	Undrop    *TweetEvent         `json:"undrop,omitempty"`
+	ScrubGeo  *TweetScrubGeoEvent `json:"scrub_geo,omitempty"`
+}
+
+type TweetEvent struct {
+	Tweet   Tweet      `json:"tweet"`
+	EventAt *time.Time `json:"event_at"`
+}
+
+type TweetEditEvent struct {
+	Tweet          Tweet      `json:"tweet"`
+	InitialTweetID *string    `json:"initial_tweet_id"`
+	EditTweetIDs   []string   `json:"edit_tweet_ids"`
+	EventAt        *time.Time `json:"event_at"`
+}
+
+type TweetWithheldEvent struct {
+	Tweet               Tweet      `json:"tweet"`
+	WithheldInCountries []string   `json:"withheld_in_countries"`
+	EventAt             *time.Time `json:"event_at"`
+}
+
+type TweetScrubGeoEvent struct {
+	Tweet   Tweet      `json:"tweet"`
+	EventAt *time.Time `json:"event_at"`
+}
+
+// UserComplianceEvent is an event of the User compliance stream.
+// Exactly one of the fields is set.
+type UserComplianceEvent struct {
+	UserDelete    *UserEvent         `json:"user_delete,omitempty"`
+	UserUndelete  *UserEvent         `json:"user_undelete,omitempty"`
+	UserWithheld  *UserWithheldEvent `json:"user_withheld,omitempty"`
+	UserSuspend   *UserEvent         `json:"user_suspend,omitempty"`
+	UserUnsuspend *UserEvent         `json:"user_unsuspend,omitempty"`
+	UserProtect   *UserEvent         `json:"user_protect,omitempty"`
+	UserUnprotect *UserEvent         `json:"user_unprotect,omitempty"`
+	ScrubGeo      *UserScrubGeoEvent `json:"scrub_geo,omitempty"`
+}
+
+type UserEvent struct {
+	User    User       `json:"user"`
+	EventAt *time.Time `json:"event_at"`
+}
+
+type UserWithheldEvent struct {
+	User                User       `json:"user"`
+	WithheldInCountries []string   `json:"withheld_in_countries"`
+	EventAt             *time.Time `json:"event_at"`
+}
+
+type UserScrubGeoEvent struct {
+	User        User       `json:"user"`
+	UpToTweetID *string    `json:"up_to_tweet_id"`
+	EventAt     *time.Time `json:"event_at"`
+}
+
+// LikesComplianceEvent is an event of the Likes compliance stream.
+type LikesComplianceEvent struct {
+	Delete *FavoriteEvent `json:"delete,omitempty"`
+}
+
+type FavoriteEvent struct {
+	Favorite Favorite   `json:"favorite"`
+	EventAt  *time.Time `json:"event_at"`
+}
+
+// Favorite identifies a Like. ID is the ID of the liked Tweet.
+type Favorite struct {
+	ID     *string `json:"id"`
+	UserID *string `json:"user_id"`
+}
+
+type TweetLabelApplication string
+
+const (
+	TweetLabelApplicationApply  TweetLabelApplication = "apply"
+	TweetLabelApplicationRemove TweetLabelApplication = "remove"
+)
+
+// TweetLabelEvent is an event of the Tweet label stream.
+// Exactly one of the fields is set.
+type TweetLabelEvent struct {
+	PublicTweetNotice     *TweetLabel `json:"public_tweet_notice,omitempty"`
+	PublicTweetUnviewable *TweetLabel `json:"public_tweet_unviewable,omitempty"`
+}
+
+type TweetLabel struct {
+	Tweet              Tweet                 `json:"tweet"`
+	EventType          *string               `json:"event_type"`
+	Application        TweetLabelApplication `json:"application"`
+	Details            *string               `json:"details,omitempty"`
+	LabelTitle         *string               `json:"label_title,omitempty"`
+	ExtendedDetailsURL *string               `json:"extended_details_url,omitempty"`
+	EventAt            *time.Time            `json:"event_at"`
+}
+
+// Label returns the label of the event, whichever field is set.
+func (e TweetLabelEvent) Label() *TweetLabel {
+	if e.PublicTweetNotice != nil {
+		return e.PublicTweetNotice
+	}
+	return e.PublicTweetUnviewable
+}
@@ -0,0 +1,18 @@
+package resources
+
+import "time"
+
+type Connection struct {
+	ID               *string    `json:"id,omitempty"`
+	ClientIP         *string    `json:"client_ip,omitempty"`
+	ConnectedAt      *time.Time `json:"connected_at,omitempty"`
+	DisconnectedAt   *time.Time `json:"disconnected_at,omitempty"`
+	DisconnectReason *string    `json:"disconnect_reason,omitempty"`
+	EndpointName     *string    `json:"endpoint_name,omitempty"`
+}
+
+type ConnectionKillResult struct {
+	ConnectionID *string `json:"connection_id,omitempty"`
+	Success      *bool   `json:"success,omitempty"`
+	ErrorMessage *string `json:"error_message,omitempty"`
+}
@@ -5,13 +5,14 @@ import (
 )
 
 type Non2XXError struct {
-	APIErrors     []ErrorInformation         `json:"errors"`
-	Title         string                     `json:"title,omitempty"`
-	Detail        string                     `json:"detail,omitempty"`
-	Type          string                     `json:"type,omitempty"`
-	Status        string                     `json:"-"`
-	StatusCode    int                        `json:"-"`
-	RateLimitInfo *util.RateLimitInformation `json:"-"`
+	APIErrors       []ErrorInformation         `json:"errors"`
+	Title           string                     `json:"title,omitempty"`
+	Detail          string                     `json:"detail,omitempty"`
+	Type            string                     `json:"type,omitempty"`
+	ConnectionIssue string                     `json:"connection_issue,omitempty"`
+	Status          string                     `json:"-"`
+	StatusCode      int                        `json:"-"`
+	RateLimitInfo   *util.RateLimitInformation `json:"-"`
 }
 
 type ErrorInformation struct {
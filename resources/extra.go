@@ -0,0 +1,230 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ExtraFields holds the fields of a JSON object that are not defined in the type of the resource,
+// e.g. a metric added to the API after this version of gotwi.
+// They are kept for forward compatibility, and re-emitted by MarshalJSON.
+//
+// The resources and their nested types (e.g. the metrics and note_tweet) have an Extra field of *ExtraFields,
+// which is nil if there are no unknown fields.
+// It is a pointer so that the types that are comparable with == remain so.
+// Note that two decoded values with unknown fields are not equal, because their Extra pointers differ.
+type ExtraFields struct {
+	fields map[string]json.RawMessage
+}
+
+// NewExtraFields returns ExtraFields that holds a copy of the fields, or nil if fields is empty.
+func NewExtraFields(fields map[string]json.RawMessage) *ExtraFields {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	copied := make(map[string]json.RawMessage, len(fields))
+	for name, raw := range fields {
+		copied[name] = raw
+	}
+	return &ExtraFields{fields: copied}
+}
+
+// Get returns the raw JSON value of the field.
+func (e *ExtraFields) Get(name string) (json.RawMessage, bool) {
+	if e == nil {
+		return nil, false
+	}
+	raw, ok := e.fields[name]
+	return raw, ok
+}
+
+// Names returns the names of the fields in ascending order.
+func (e *ExtraFields) Names() []string {
+	if e == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(e.fields))
+	for name := range e.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Len returns the number of the fields.
+func (e *ExtraFields) Len() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.fields)
+}
+
+// Map returns a copy of the fields.
+func (e *ExtraFields) Map() map[string]json.RawMessage {
+	if e == nil {
+		return nil
+	}
+
+	copied := make(map[string]json.RawMessage, len(e.fields))
+	for name, raw := range e.fields {
+		copied[name] = raw
+	}
+	return copied
+}
+
+// UnknownFieldsError is the error of CheckUnknownFields.
+type UnknownFieldsError struct {
+	// Resource is the name of the type, e.g. "Tweet".
+	Resource string
+	// Fields is the names of the unknown fields in ascending order.
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("unknown fields in %s: %s", e.Resource, strings.Join(e.Fields, ", "))
+}
+
+var extraFieldsType = reflect.TypeOf((*ExtraFields)(nil))
+
+// CheckUnknownFields returns *UnknownFieldsError if v, a decoded response or resource,
+// has a resource with unknown fields at any depth. Otherwise it returns nil.
+// It is used by the strict decoding mode (gotwi.WithStrictDecoding), to find the types that need to be updated.
+func CheckUnknownFields(v any) error {
+	return checkUnknownFields(reflect.ValueOf(v))
+}
+
+func checkUnknownFields(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return checkUnknownFields(v.Elem())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := checkUnknownFields(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, k := range keys {
+			if err := checkUnknownFields(v.MapIndex(k)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			fv := v.Field(i)
+			if f.Type == extraFieldsType {
+				if e := fv.Interface().(*ExtraFields); e.Len() > 0 {
+					return &UnknownFieldsError{Resource: t.Name(), Fields: e.Names()}
+				}
+				continue
+			}
+			if err := checkUnknownFields(fv); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// knownFieldsCache caches the JSON field names of each type.
+var knownFieldsCache sync.Map // map[reflect.Type]map[string]int
+
+// knownFields returns the index of the struct field for each JSON field name.
+func knownFields(t reflect.Type) map[string]int {
+	if v, ok := knownFieldsCache.Load(t); ok {
+		return v.(map[string]int)
+	}
+
+	known := map[string]int{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		known[name] = i
+	}
+
+	knownFieldsCache.Store(t, known)
+	return known
+}
+
+// unmarshalWithExtra decodes data into v, which must be a pointer to a struct without UnmarshalJSON,
+// and returns the fields that are not defined in the struct, to be set to its Extra field.
+// The object is scanned once into its fields, and each defined field is decoded from its raw value.
+func unmarshalWithExtra(data []byte, v any) (*ExtraFields, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	if all == nil {
+		// null
+		return nil, nil
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+	known := knownFields(rv.Type())
+	var extra map[string]json.RawMessage
+	for name, raw := range all {
+		i, ok := known[name]
+		if !ok {
+			if extra == nil {
+				extra = map[string]json.RawMessage{}
+			}
+			extra[name] = raw
+			continue
+		}
+		if err := json.Unmarshal(raw, rv.Field(i).Addr().Interface()); err != nil {
+			return nil, err
+		}
+	}
+
+	if extra == nil {
+		return nil, nil
+	}
+	return &ExtraFields{fields: extra}, nil
+}
+
+// marshalWithExtra encodes v, which must be a struct without MarshalJSON, and adds the extra fields to the object.
+// Fields defined in the struct take precedence over the extra fields with the same name.
+func marshalWithExtra(v any, extra *ExtraFields) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || extra.Len() == 0 {
+		return b, err
+	}
+
+	m := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	for name, raw := range extra.fields {
+		if _, ok := m[name]; !ok {
+			m[name] = raw
+		}
+	}
+
+	return json.Marshal(m)
+}
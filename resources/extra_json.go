@@ -0,0 +1,228 @@
+package resources
+
+// Code below implements json.Unmarshaler and json.Marshaler for the types that keep unknown fields in Extra.
+
+func (t *Tweet) UnmarshalJSON(data []byte) error {
+	type alias Tweet
+	extra, err := unmarshalWithExtra(data, (*alias)(t))
+	if err != nil {
+		return err
+	}
+	t.Extra = extra
+	return nil
+}
+
+func (t Tweet) MarshalJSON() ([]byte, error) {
+	type alias Tweet
+	return marshalWithExtra(alias(t), t.Extra)
+}
+
+func (t *TweetPublicMetrics) UnmarshalJSON(data []byte) error {
+	type alias TweetPublicMetrics
+	extra, err := unmarshalWithExtra(data, (*alias)(t))
+	if err != nil {
+		return err
+	}
+	t.Extra = extra
+	return nil
+}
+
+func (t TweetPublicMetrics) MarshalJSON() ([]byte, error) {
+	type alias TweetPublicMetrics
+	return marshalWithExtra(alias(t), t.Extra)
+}
+
+func (n *NonPublicMetrics) UnmarshalJSON(data []byte) error {
+	type alias NonPublicMetrics
+	extra, err := unmarshalWithExtra(data, (*alias)(n))
+	if err != nil {
+		return err
+	}
+	n.Extra = extra
+	return nil
+}
+
+func (n NonPublicMetrics) MarshalJSON() ([]byte, error) {
+	type alias NonPublicMetrics
+	return marshalWithExtra(alias(n), n.Extra)
+}
+
+func (o *OrganicMetrics) UnmarshalJSON(data []byte) error {
+	type alias OrganicMetrics
+	extra, err := unmarshalWithExtra(data, (*alias)(o))
+	if err != nil {
+		return err
+	}
+	o.Extra = extra
+	return nil
+}
+
+func (o OrganicMetrics) MarshalJSON() ([]byte, error) {
+	type alias OrganicMetrics
+	return marshalWithExtra(alias(o), o.Extra)
+}
+
+func (p *PromotedMetrics) UnmarshalJSON(data []byte) error {
+	type alias PromotedMetrics
+	extra, err := unmarshalWithExtra(data, (*alias)(p))
+	if err != nil {
+		return err
+	}
+	p.Extra = extra
+	return nil
+}
+
+func (p PromotedMetrics) MarshalJSON() ([]byte, error) {
+	type alias PromotedMetrics
+	return marshalWithExtra(alias(p), p.Extra)
+}
+
+func (t *TweetNoteTweet) UnmarshalJSON(data []byte) error {
+	type alias TweetNoteTweet
+	extra, err := unmarshalWithExtra(data, (*alias)(t))
+	if err != nil {
+		return err
+	}
+	t.Extra = extra
+	return nil
+}
+
+func (t TweetNoteTweet) MarshalJSON() ([]byte, error) {
+	type alias TweetNoteTweet
+	return marshalWithExtra(alias(t), t.Extra)
+}
+
+func (u *User) UnmarshalJSON(data []byte) error {
+	type alias User
+	extra, err := unmarshalWithExtra(data, (*alias)(u))
+	if err != nil {
+		return err
+	}
+	u.Extra = extra
+	return nil
+}
+
+func (u User) MarshalJSON() ([]byte, error) {
+	type alias User
+	return marshalWithExtra(alias(u), u.Extra)
+}
+
+func (u *UserPublicMetrics) UnmarshalJSON(data []byte) error {
+	type alias UserPublicMetrics
+	extra, err := unmarshalWithExtra(data, (*alias)(u))
+	if err != nil {
+		return err
+	}
+	u.Extra = extra
+	return nil
+}
+
+func (u UserPublicMetrics) MarshalJSON() ([]byte, error) {
+	type alias UserPublicMetrics
+	return marshalWithExtra(alias(u), u.Extra)
+}
+
+func (p *Place) UnmarshalJSON(data []byte) error {
+	type alias Place
+	extra, err := unmarshalWithExtra(data, (*alias)(p))
+	if err != nil {
+		return err
+	}
+	p.Extra = extra
+	return nil
+}
+
+func (p Place) MarshalJSON() ([]byte, error) {
+	type alias Place
+	return marshalWithExtra(alias(p), p.Extra)
+}
+
+func (m *Media) UnmarshalJSON(data []byte) error {
+	type alias Media
+	extra, err := unmarshalWithExtra(data, (*alias)(m))
+	if err != nil {
+		return err
+	}
+	m.Extra = extra
+	return nil
+}
+
+func (m Media) MarshalJSON() ([]byte, error) {
+	type alias Media
+	return marshalWithExtra(alias(m), m.Extra)
+}
+
+func (p *Poll) UnmarshalJSON(data []byte) error {
+	type alias Poll
+	extra, err := unmarshalWithExtra(data, (*alias)(p))
+	if err != nil {
+		return err
+	}
+	p.Extra = extra
+	return nil
+}
+
+func (p Poll) MarshalJSON() ([]byte, error) {
+	type alias Poll
+	return marshalWithExtra(alias(p), p.Extra)
+}
+
+func (s *Space) UnmarshalJSON(data []byte) error {
+	type alias Space
+	extra, err := unmarshalWithExtra(data, (*alias)(s))
+	if err != nil {
+		return err
+	}
+	s.Extra = extra
+	return nil
+}
+
+func (s Space) MarshalJSON() ([]byte, error) {
+	type alias Space
+	return marshalWithExtra(alias(s), s.Extra)
+}
+
+func (l *List) UnmarshalJSON(data []byte) error {
+	type alias List
+	extra, err := unmarshalWithExtra(data, (*alias)(l))
+	if err != nil {
+		return err
+	}
+	l.Extra = extra
+	return nil
+}
+
+func (l List) MarshalJSON() ([]byte, error) {
+	type alias List
+	return marshalWithExtra(alias(l), l.Extra)
+}
+
+func (c *Community) UnmarshalJSON(data []byte) error {
+	type alias Community
+	extra, err := unmarshalWithExtra(data, (*alias)(c))
+	if err != nil {
+		return err
+	}
+	c.Extra = extra
+	return nil
+}
+
+func (c Community) MarshalJSON() ([]byte, error) {
+	type alias Community
+	return marshalWithExtra(alias(c), c.Extra)
+}
+
+func (t *Trend) UnmarshalJSON(data []byte) error {
+	type alias Trend
+	extra, err := unmarshalWithExtra(data, (*alias)(t))
+	if err != nil {
+		return err
+	}
+	t.Extra = extra
+	return nil
+}
+
+func (t Trend) MarshalJSON() ([]byte, error) {
+	type alias Trend
+	return marshalWithExtra(alias(t), t.Extra)
+}
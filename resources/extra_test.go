@@ -0,0 +1,140 @@
+package resources_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/michimani/gotwi/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Tweet_UnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		name               string
+		data               string
+		expectExtra        map[string]json.RawMessage
+		expectMetricsExtra map[string]json.RawMessage
+		expectLikes        int
+	}{
+		{
+			name:        "no unknown fields",
+			data:        `{"id":"1","text":"hello","public_metrics":{"like_count":3}}`,
+			expectLikes: 3,
+		},
+		{
+			name: "unknown fields",
+			data: `{"id":"1","text":"hello","new_field":{"a":1},"public_metrics":{"like_count":3,"bookmark_count":5}}`,
+			expectExtra: map[string]json.RawMessage{
+				"new_field": json.RawMessage(`{"a":1}`),
+			},
+			expectMetricsExtra: map[string]json.RawMessage{
+				"bookmark_count": json.RawMessage(`5`),
+			},
+			expectLikes: 3,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			tw := resources.Tweet{}
+			assert.NoError(tt, json.Unmarshal([]byte(c.data), &tw))
+			assert.Equal(tt, "1", *tw.ID)
+			assert.Equal(tt, c.expectExtra, tw.Extra.Map())
+			assert.Equal(tt, c.expectMetricsExtra, tw.PublicMetrics.Extra.Map())
+			assert.Equal(tt, c.expectLikes, *tw.PublicMetrics.LikeCount)
+		})
+	}
+}
+
+func Test_Tweet_MarshalJSON(t *testing.T) {
+	data := `{"id":"1","new_field":{"a":1},"public_metrics":{"bookmark_count":5,"like_count":3,"quote_count":null,"reply_count":null,"retweet_count":null},"note_tweet":{"text":"long","entities":{"cashtags":null,"hashtags":null,"mentions":null,"urls":null},"new_entity":[]},"text":"hello"}`
+
+	tw := resources.Tweet{}
+	assert.NoError(t, json.Unmarshal([]byte(data), &tw))
+	raw, ok := tw.NoteTweet.Extra.Get("new_entity")
+	assert.True(t, ok)
+	assert.Equal(t, json.RawMessage(`[]`), raw)
+
+	b, err := json.Marshal(tw)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":"1","text":"hello","edit_history_tweet_ids":null,"new_field":{"a":1},"public_metrics":{"like_count":3,"reply_count":null,"retweet_count":null,"quote_count":null,"bookmark_count":5},"note_tweet":{"text":"long","entities":{"cashtags":null,"hashtags":null,"mentions":null,"urls":null},"new_entity":[]}}`, string(b))
+
+	// the defined field takes precedence over the extra field with the same name
+	tw.Extra = resources.NewExtraFields(map[string]json.RawMessage{"text": json.RawMessage(`"extra"`)})
+	b, err = json.Marshal(&tw)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"text":"hello"`)
+}
+
+func Test_Extra_Comparable(t *testing.T) {
+	// the types without unknown fields are still comparable with ==
+	m := resources.TweetPublicMetrics{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"like_count":3}`), &m))
+	assert.True(t, m == resources.TweetPublicMetrics{LikeCount: m.LikeCount})
+
+	p := resources.Place{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"id":"1"}`), &p))
+	assert.True(t, p == resources.Place{ID: p.ID})
+}
+
+func Test_User_Extra_Null(t *testing.T) {
+	var u *resources.User
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &u))
+	assert.Nil(t, u)
+
+	users := []resources.User{}
+	assert.NoError(t, json.Unmarshal([]byte(`[{"id":"1","username":"gopher","affiliation":{"badge":"x"}}]`), &users))
+	raw, ok := users[0].Extra.Get("affiliation")
+	assert.True(t, ok)
+	assert.Equal(t, json.RawMessage(`{"badge":"x"}`), raw)
+	assert.Equal(t, []string{"affiliation"}, users[0].Extra.Names())
+}
+
+func Test_CheckUnknownFields(t *testing.T) {
+	type output struct {
+		Data []resources.Tweet `json:"data"`
+	}
+
+	cases := []struct {
+		name           string
+		data           string
+		expectResource string
+		expectFields   []string
+	}{
+		{
+			name: "no unknown fields",
+			data: `{"data":[{"id":"1","text":"hello","public_metrics":{"like_count":3}}]}`,
+		},
+		{
+			name:           "unknown fields of a resource",
+			data:           `{"data":[{"id":"1","text":"hello"},{"id":"2","text":"hello","b_field":1,"a_field":2}]}`,
+			expectResource: "Tweet",
+			expectFields:   []string{"a_field", "b_field"},
+		},
+		{
+			name:           "unknown fields of a nested type",
+			data:           `{"data":[{"id":"1","text":"hello","public_metrics":{"like_count":3,"bookmark_count":5}}]}`,
+			expectResource: "TweetPublicMetrics",
+			expectFields:   []string{"bookmark_count"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			out := output{}
+			assert.NoError(tt, json.Unmarshal([]byte(c.data), &out))
+
+			err := resources.CheckUnknownFields(&out)
+			if c.expectResource == "" {
+				assert.NoError(tt, err)
+				return
+			}
+
+			var ufe *resources.UnknownFieldsError
+			if assert.ErrorAs(tt, err, &ufe) {
+				assert.Equal(tt, c.expectResource, ufe.Resource)
+				assert.Equal(tt, c.expectFields, ufe.Fields)
+			}
+		})
+	}
+}
@@ -11,6 +11,8 @@ type Place struct {
 	Geo             *IncludeGeo `json:"geo,omitempty"`
 	Name            *string     `json:"name,omitempty"`
 	PlaceType       *string     `json:"place_type,omitempty"`
+
+	Extra *ExtraFields `json:"-"`
 }
 
 type Media struct {
@@ -27,6 +29,8 @@ type Media struct {
 	Width            *int             `json:"width,omitempty"`
 	AltText          *string          `json:"alt_text,omitempty"`
 	Variants         []IncludeVariant `json:"variants,omitempty"`
+
+	Extra *ExtraFields `json:"-"`
 }
 
 type Poll struct {
@@ -35,6 +39,8 @@ type Poll struct {
 	DurationMinutes *int         `json:"duration_minutes,omitempty"`
 	EndDatetime     *time.Time   `json:"end_datetime,omitempty"`
 	VotingStatus    *string      `json:"voting_status,omitempty"`
+
+	Extra *ExtraFields `json:"-"`
 }
 
 type IncludeGeo struct {
@@ -11,4 +11,6 @@ type List struct {
 	MemberCount   *int       `json:"member_count,omitempty"`
 	OwnerID       *string    `json:"owner_id,omitempty"`
 	Description   *string    `json:"description,omitempty"`
+
+	Extra *ExtraFields `json:"-"`
 }
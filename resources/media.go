@@ -36,3 +36,52 @@ type UploadedMedia struct {
 	// Size of the upload
 	Size uint `json:"size"`
 }
+
+type MediaMetadata struct {
+	// Alternative text that describes the media for accessibility.
+	AltText *MediaAltText `json:"alt_text,omitempty"`
+
+	// Content warnings for the media.
+	SensitiveMediaWarning *SensitiveMediaWarning `json:"sensitive_media_warning,omitempty"`
+}
+
+type MediaAltText struct {
+	// Description of the media. Up to 1000 characters.
+	Text string `json:"text"`
+}
+
+type SensitiveMediaWarning struct {
+	AdultContent    bool `json:"adult_content,omitempty"`
+	GraphicViolence bool `json:"graphic_violence,omitempty"`
+	Other           bool `json:"other,omitempty"`
+}
+
+type MediaWithMetadata struct {
+	// The unique identifier of this Media.
+	MediaID string `json:"id"`
+
+	// Metadata associated with the media.
+	AssociatedMetadata MediaMetadata `json:"associated_metadata"`
+}
+
+type Subtitle struct {
+	// Language name in a human readable form, e.g. "English".
+	DisplayName string `json:"display_name"`
+
+	// The media identifier of the uploaded subtitle file.
+	ID string `json:"id"`
+
+	// BCP47 language code, e.g. "EN".
+	LanguageCode string `json:"language_code"`
+}
+
+type MediaWithSubtitles struct {
+	// The unique identifier of the video Media.
+	MediaID string `json:"id"`
+
+	// The media category of the video.
+	MediaCategory string `json:"media_category"`
+
+	// Subtitles associated with the video.
+	AssociatedSubtitles []Subtitle `json:"associated_subtitles"`
+}
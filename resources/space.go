@@ -19,4 +19,6 @@ type Space struct {
 	CreatedAt        *time.Time `json:"created_at,omitempty"`
 	StartedAt        *time.Time `json:"started_at,omitempty"`
 	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+
+	Extra *ExtraFields `json:"-"`
 }
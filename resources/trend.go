@@ -0,0 +1,13 @@
+package resources
+
+type Trend struct {
+	TrendName *string `json:"trend_name"`
+	// TweetCount is returned by the trends by WOEID endpoint.
+	TweetCount *int `json:"tweet_count,omitempty"`
+	// PostCount is returned by the personalized trends endpoint, as a formatted string such as "12.3K posts".
+	PostCount     *string `json:"post_count,omitempty"`
+	Category      *string `json:"category,omitempty"`
+	TrendingSince *string `json:"trending_since,omitempty"`
+
+	Extra *ExtraFields `json:"-"`
+}
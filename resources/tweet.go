@@ -25,6 +25,8 @@ type Tweet struct {
 	Source              *string             `json:"source,omitempty"`
 	Withheld            *TweetWithheld      `json:"withheld,omitempty"`
 	NoteTweet           *TweetNoteTweet     `json:"note_tweet,omitempty"`
+
+	Extra *ExtraFields `json:"-"`
 }
 
 type TweetAttachments struct {
@@ -104,6 +106,8 @@ type NonPublicMetrics struct {
 	ImpressionCount   *int `json:"impression_count"`
 	UrlLinkClicks     *int `json:"url_link_clicks"`
 	UserProfileClicks *int `json:"user_profile_clicks"`
+
+	Extra *ExtraFields `json:"-"`
 }
 
 type OrganicMetrics struct {
@@ -113,6 +117,8 @@ type OrganicMetrics struct {
 	RetweetCount      *int `json:"retweet_count"`
 	UrlLinkClicks     *int `json:"url_link_clicks"`
 	UserProfileClicks *int `json:"user_profile_clicks"`
+
+	Extra *ExtraFields `json:"-"`
 }
 
 type PromotedMetrics struct {
@@ -122,6 +128,8 @@ type PromotedMetrics struct {
 	RetweetCount      *int `json:"retweet_count"`
 	UrlLinkClicks     *int `json:"url_link_clicks"`
 	UserProfileClicks *int `json:"user_profile_clicks"`
+
+	Extra *ExtraFields `json:"-"`
 }
 
 type TweetPublicMetrics struct {
@@ -129,6 +137,8 @@ type TweetPublicMetrics struct {
 	ReplyCount   *int `json:"reply_count"`
 	LikeCount    *int `json:"like_count"`
 	QuoteCount   *int `json:"quote_count"`
+
+	Extra *ExtraFields `json:"-"`
 }
 
 type ReferencedTweet struct {
@@ -149,4 +159,6 @@ type TweetNoteTweet struct {
 		URLs     []URL                `json:"urls"`
 	} `json:"entities"`
 	Text *string `json:"text"`
+
+	Extra *ExtraFields `json:"-"`
 }
@@ -0,0 +1,30 @@
+package resources
+
+import "time"
+
+// Usage is the Post consumption of a Project.
+// The API returns the counts as strings, so they are kept as strings.
+type Usage struct {
+	CapResetDay         *int             `json:"cap_reset_day,omitempty"`
+	DailyClientAppUsage []ClientAppUsage `json:"daily_client_app_usage,omitempty"`
+	DailyProjectUsage   *ProjectUsage    `json:"daily_project_usage,omitempty"`
+	ProjectCap          *string          `json:"project_cap,omitempty"`
+	ProjectID           *string          `json:"project_id,omitempty"`
+	ProjectUsage        *string          `json:"project_usage,omitempty"`
+}
+
+type ClientAppUsage struct {
+	ClientAppID      *string      `json:"client_app_id"`
+	Usage            []DailyUsage `json:"usage"`
+	UsageResultCount *int         `json:"usage_result_count,omitempty"`
+}
+
+type ProjectUsage struct {
+	ProjectID *string      `json:"project_id"`
+	Usage     []DailyUsage `json:"usage"`
+}
+
+type DailyUsage struct {
+	Date  *time.Time `json:"date"`
+	Usage *string    `json:"usage"`
+}
@@ -18,6 +18,8 @@ type User struct {
 	Verified          *bool              `json:"verified,omitempty"`
 	Withheld          *UserWithheld      `json:"withheld,omitempty"`
 	MostRecentTweetID *string            `json:"most_recent_tweet_id,omitempty"`
+
+	Extra *ExtraFields `json:"-"`
 }
 
 type UserEntities struct {
@@ -59,6 +61,8 @@ type UserPublicMetrics struct {
 	FollowingCount *int `json:"following_count"`
 	TweetCount     *int `json:"tweet_count"`
 	ListedCount    *int `json:"listed_count"`
+
+	Extra *ExtraFields `json:"-"`
 }
 
 type UserWithheld struct {
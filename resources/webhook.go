@@ -0,0 +1,14 @@
+package resources
+
+import "time"
+
+type WebhookConfig struct {
+	ID        *string    `json:"id"`
+	URL       *string    `json:"url"`
+	Valid     *bool      `json:"valid"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+type WebhookSubscription struct {
+	UserID *string `json:"user_id"`
+}
@@ -2,7 +2,6 @@ package spacelookup
 
 import (
 	"context"
-	"errors"
 
 	"github.com/michimani/gotwi"
 	"github.com/michimani/gotwi/space/spacelookup/types"
@@ -18,77 +17,32 @@ const (
 
 // Returns a variety of information about a single Space specified by the requested ID.
 // https://developer.twitter.com/en/docs/twitter-api/spaces/lookup/api-reference/get-spaces-id
-func Get(ctx context.Context, c gotwi.IClient, p *types.GetInput) (*types.GetOutput, error) {
-	if p == nil {
-		return nil, errors.New("parameters is nil")
-	}
-
-	res := &types.GetOutput{}
-	if err := c.CallAPI(ctx, getEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func Get(ctx context.Context, c gotwi.IClient, p *types.GetInput, opts ...gotwi.CallOption) (*types.GetOutput, error) {
+	return gotwi.Do[*types.GetOutput](ctx, c, "GET", getEndpoint, p, opts...)
 }
 
 // Returns details about multiple Spaces. Up to 100 comma-separated Spaces IDs can be looked up using this endpoint
 // https://developer.twitter.com/en/docs/twitter-api/spaces/lookup/api-reference/get-spaces
-func List(ctx context.Context, c gotwi.IClient, p *types.ListInput) (*types.ListOutput, error) {
-	if p == nil {
-		return nil, errors.New("parameters is nil")
-	}
-
-	res := &types.ListOutput{}
-	if err := c.CallAPI(ctx, listEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func List(ctx context.Context, c gotwi.IClient, p *types.ListInput, opts ...gotwi.CallOption) (*types.ListOutput, error) {
+	return gotwi.Do[*types.ListOutput](ctx, c, "GET", listEndpoint, p, opts...)
 }
 
 // Returns live or scheduled Spaces created by the specified user IDs.
 // Up to 100 comma-separated IDs can be looked up using this endpoint.
 // https://developer.twitter.com/en/docs/twitter-api/spaces/lookup/api-reference/get-spaces-by-creator-ids
-func ListByCreatorIDs(ctx context.Context, c gotwi.IClient, p *types.ListByCreatorIDsInput) (*types.ListByCreatorIDsOutput, error) {
-	if p == nil {
-		return nil, errors.New("parameters is nil")
-	}
-
-	res := &types.ListByCreatorIDsOutput{}
-	if err := c.CallAPI(ctx, listByCreatorIDsEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func ListByCreatorIDs(ctx context.Context, c gotwi.IClient, p *types.ListByCreatorIDsInput, opts ...gotwi.CallOption) (*types.ListByCreatorIDsOutput, error) {
+	return gotwi.Do[*types.ListByCreatorIDsOutput](ctx, c, "GET", listByCreatorIDsEndpoint, p, opts...)
 }
 
 // Returns a list of user who purchased a ticket to the requested Space.
 // You must authenticate the request using the access token of the creator of the requested Space.
 // https://developer.twitter.com/en/docs/twitter-api/spaces/lookup/api-reference/get-spaces-id-buyers
-func ListBuyers(ctx context.Context, c gotwi.IClient, p *types.ListBuyersInput) (*types.ListBuyersOutput, error) {
-	if p == nil {
-		return nil, errors.New("parameters is nil")
-	}
-
-	res := &types.ListBuyersOutput{}
-	if err := c.CallAPI(ctx, listBuyersEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func ListBuyers(ctx context.Context, c gotwi.IClient, p *types.ListBuyersInput, opts ...gotwi.CallOption) (*types.ListBuyersOutput, error) {
+	return gotwi.Do[*types.ListBuyersOutput](ctx, c, "GET", listBuyersEndpoint, p, opts...)
 }
 
 // Returns Tweets shared in the requested Spaces.
 // https://developer.twitter.com/en/docs/twitter-api/spaces/lookup/api-reference/get-spaces-id-tweets
-func ListTweets(ctx context.Context, c gotwi.IClient, p *types.ListTweetsInput) (*types.ListTweetsOutput, error) {
-	if p == nil {
-		return nil, errors.New("parameters is nil")
-	}
-
-	res := &types.ListTweetsOutput{}
-	if err := c.CallAPI(ctx, listTweetsEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func ListTweets(ctx context.Context, c gotwi.IClient, p *types.ListTweetsInput, opts ...gotwi.CallOption) (*types.ListTweetsOutput, error) {
+	return gotwi.Do[*types.ListTweetsOutput](ctx, c, "GET", listTweetsEndpoint, p, opts...)
 }
@@ -4,7 +4,10 @@ import (
 	"bufio"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
+	"sync/atomic"
+	"time"
 
 	"github.com/michimani/gotwi/internal/util"
 )
@@ -38,6 +41,15 @@ func (s *StreamClient[T]) Receive() bool {
 	return s.stream.Scan()
 }
 
+// Err returns the error that stopped receiving, or nil if the stream reached EOF.
+// It returns ErrStreamIdleTimeout if the stream was closed because no data was received within the idle timeout.
+func (s *StreamClient[T]) Err() error {
+	if s == nil {
+		return nil
+	}
+	return s.stream.Err()
+}
+
 func (s *StreamClient[T]) Stop() {
 	if s == nil {
 		return
@@ -45,6 +57,50 @@ func (s *StreamClient[T]) Stop() {
 	s.response.Body.Close()
 }
 
+// ErrStreamIdleTimeout is the error when no data is received from a stream within the idle timeout.
+var ErrStreamIdleTimeout = errors.New("no data received from the stream within the idle timeout")
+
+// idleTimeoutReader closes the body when no data arrives within the timeout.
+// It makes a blocked Read return, so that a stalled stream does not hang forever.
+// The timer runs only while Read waits for the body, so the time the consumer spends between reads
+// (e.g. handling a Tweet after Receive) is not counted as idle.
+type idleTimeoutReader struct {
+	body     io.ReadCloser
+	timeout  time.Duration
+	timer    *time.Timer
+	timedOut atomic.Bool
+}
+
+func newIdleTimeoutReader(body io.ReadCloser, timeout time.Duration) *idleTimeoutReader {
+	r := &idleTimeoutReader{
+		body:    body,
+		timeout: timeout,
+	}
+	r.timer = time.AfterFunc(timeout, func() {
+		r.timedOut.Store(true)
+		r.body.Close()
+	})
+	r.timer.Stop()
+
+	return r
+}
+
+func (r *idleTimeoutReader) Read(p []byte) (int, error) {
+	r.timer.Reset(r.timeout)
+	n, err := r.body.Read(p)
+	r.timer.Stop()
+
+	if r.timedOut.Load() {
+		return n, ErrStreamIdleTimeout
+	}
+	return n, err
+}
+
+func (r *idleTimeoutReader) Close() error {
+	r.timer.Stop()
+	return r.body.Close()
+}
+
 func safeUnmarshal(input []byte, target interface{}) error {
 	if len(input) == 0 {
 		return nil
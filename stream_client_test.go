@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/michimani/gotwi"
 	"github.com/stretchr/testify/assert"
@@ -204,3 +205,52 @@ func Test_Read(t *testing.T) {
 		})
 	}
 }
+
+func Test_StreamClient_IdleTimeout(t *testing.T) {
+	asst := assert.New(t)
+
+	pr, pw := io.Pipe()
+	body := gotwi.ExportNewIdleTimeoutReader(pr, time.Duration(50)*time.Millisecond)
+	st, err := gotwi.ExportNewStreamClient(&http.Response{Body: body})
+	asst.NoError(err)
+
+	go func() {
+		pw.Write([]byte("{\"text\":\"test\"}\n"))
+	}()
+
+	asst.True(st.Receive())
+	r, err := st.Read()
+	asst.NoError(err)
+	asst.Equal("test", r.Text)
+
+	// no more data is written, so the stream is closed by the idle timeout
+	asst.False(st.Receive())
+	asst.ErrorIs(st.Err(), gotwi.ErrStreamIdleTimeout)
+}
+
+func Test_StreamClient_IdleTimeout_SlowConsumer(t *testing.T) {
+	asst := assert.New(t)
+
+	timeout := time.Duration(50) * time.Millisecond
+	pr, pw := io.Pipe()
+	body := gotwi.ExportNewIdleTimeoutReader(pr, timeout)
+	st, err := gotwi.ExportNewStreamClient(&http.Response{Body: body})
+	asst.NoError(err)
+
+	go func() {
+		for range 3 {
+			pw.Write([]byte("{\"text\":\"test\"}\n"))
+		}
+		pw.Close()
+	}()
+
+	// the consumer takes longer than the timeout to handle each data,
+	// but the stream is not closed because the data is arriving
+	received := 0
+	for st.Receive() {
+		received++
+		time.Sleep(3 * timeout)
+	}
+	asst.NoError(st.Err())
+	asst.Equal(3, received)
+}
@@ -0,0 +1,189 @@
+package text
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/michimani/gotwi/resources"
+)
+
+// A URL without scheme is linked by X only if its TLD is generic, or it is followed by a path.
+// .co and .tv are exceptions of the ccTLDs, which are linked without a path.
+const (
+	urlDomain        = `[a-z0-9][a-z0-9-]*(?:\.[a-z0-9-]+)*`
+	urlGenericTLDs   = `com|net|org|edu|gov|info|biz|dev|app|co|tv`
+	urlCountryTLDs   = `io|me|ai|jp|uk|de|fr|us|ca|au|in|br|es|it|kr|cn|ru|nl`
+	urlPathCharacter = `[^\s<>"]`
+)
+
+var (
+	urlPattern = regexp.MustCompile(`(?i)(?:https?://|www\.)` + urlPathCharacter + `+` +
+		`|\b` + urlDomain + `\.(?:` + urlCountryTLDs + `)/` + urlPathCharacter + `*` +
+		`|\b` + urlDomain + `\.(?:` + urlGenericTLDs + `)(?:/` + urlPathCharacter + `*)?\b/?`)
+	mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_!#$%&*@＠])([@＠]([A-Za-z0-9_]{1,15}))`)
+	hashTagPattern = regexp.MustCompile(`(?:^|[^&\p{L}\p{M}\p{Nd}_])([#＃]([\p{L}\p{M}\p{Nd}_]*\p{L}[\p{L}\p{M}\p{Nd}_]*))`)
+	cashTagPattern = regexp.MustCompile(`(?:^|\s)(\$([A-Za-z]{1,6}(?:[._][A-Za-z]{1,2})?))`)
+)
+
+const urlTrailingPunctuation = `.,:;!?'"`
+
+// ExtractEntities extracts mentions, hashtags, cashtags and URLs from the text.
+// Start and End of each entity are the code point offsets in the text, same as entities of Tweet objects.
+func ExtractEntities(s string) *resources.TweetEntities {
+	return &resources.TweetEntities{
+		CashTags: ExtractCashTags(s),
+		HashTags: ExtractHashTags(s),
+		Mentions: ExtractMentions(s),
+		URLs:     ExtractURLs(s),
+	}
+}
+
+// ExtractURLs extracts URLs from the text.
+func ExtractURLs(s string) []resources.URL {
+	urls := []resources.URL{}
+	for _, loc := range urlIndexes(s) {
+		start, end := codePointOffsets(s, loc[0], loc[1])
+		urls = append(urls, resources.URL{
+			Start: &start,
+			End:   &end,
+			URL:   stringPtr(s[loc[0]:loc[1]]),
+		})
+	}
+
+	return urls
+}
+
+// ExtractMentions extracts mentions from the text.
+// Username of each mention does not include the leading '@'.
+func ExtractMentions(s string) []resources.TweetEntityMention {
+	mentions := []resources.TweetEntityMention{}
+	for _, m := range entityMatches(s, mentionPattern, func(next rune) bool {
+		return next == '@' || next == '＠' || isWordRune(next)
+	}) {
+		mentions = append(mentions, resources.TweetEntityMention{
+			Start:    m.start,
+			End:      m.end,
+			Username: m.value,
+		})
+	}
+
+	return mentions
+}
+
+// ExtractHashTags extracts hashtags from the text.
+// Tag of each hashtag does not include the leading '#'.
+func ExtractHashTags(s string) []resources.TweetEntityTag {
+	return entityTags(entityMatches(s, hashTagPattern, func(next rune) bool {
+		return next == '#' || next == '＃'
+	}))
+}
+
+// ExtractCashTags extracts cashtags from the text.
+// Tag of each cashtag does not include the leading '$'.
+func ExtractCashTags(s string) []resources.TweetEntityTag {
+	return entityTags(entityMatches(s, cashTagPattern, isWordRune))
+}
+
+type entityMatch struct {
+	start *int
+	end   *int
+	value *string
+}
+
+// entityMatches returns the entities that match pattern.
+// The pattern must have two groups, the first is the whole entity and the second is the value of it.
+// Entities followed by a rune for which invalidNext returns true, or followed by "://",
+// or overlapping URLs are ignored.
+func entityMatches(s string, pattern *regexp.Regexp, invalidNext func(next rune) bool) []entityMatch {
+	urls := urlIndexes(s)
+	matches := []entityMatch{}
+	for _, loc := range pattern.FindAllStringSubmatchIndex(s, -1) {
+		start, end := loc[2], loc[3]
+		if next, _ := utf8.DecodeRuneInString(s[end:]); end < len(s) && invalidNext(next) {
+			continue
+		}
+		if strings.HasPrefix(s[end:], "://") || overlaps(urls, start, end) {
+			continue
+		}
+
+		cs, ce := codePointOffsets(s, start, end)
+		matches = append(matches, entityMatch{
+			start: &cs,
+			end:   &ce,
+			value: stringPtr(s[loc[4]:loc[5]]),
+		})
+	}
+
+	return matches
+}
+
+func entityTags(matches []entityMatch) []resources.TweetEntityTag {
+	tags := []resources.TweetEntityTag{}
+	for _, m := range matches {
+		tags = append(tags, resources.TweetEntityTag{
+			Start: m.start,
+			End:   m.end,
+			Tag:   m.value,
+		})
+	}
+
+	return tags
+}
+
+// urlIndexes returns the byte offsets of URLs in the text.
+// Trailing punctuation and unbalanced closing parentheses are not included in URLs.
+func urlIndexes(s string) [][2]int {
+	indexes := [][2]int{}
+	for _, loc := range urlPattern.FindAllStringIndex(s, -1) {
+		start, end := loc[0], loc[1]
+		// a part of a longer domain, e.g. "example.co" of "example.co.jp"
+		if next := s[end:]; len(next) > 1 && next[0] == '.' && isWordRune(rune(next[1])) {
+			continue
+		}
+		if start > 0 {
+			if prev, _ := utf8.DecodeLastRuneInString(s[:start]); prev == '@' || prev == '$' || prev == '#' {
+				continue
+			}
+		}
+
+		for end > start {
+			last := s[end-1]
+			if strings.IndexByte(urlTrailingPunctuation, last) >= 0 {
+				end--
+				continue
+			}
+			if last == ')' && strings.Count(s[start:end], "(") < strings.Count(s[start:end], ")") {
+				end--
+				continue
+			}
+			break
+		}
+
+		indexes = append(indexes, [2]int{start, end})
+	}
+
+	return indexes
+}
+
+func overlaps(indexes [][2]int, start, end int) bool {
+	for _, idx := range indexes {
+		if start < idx[1] && idx[0] < end {
+			return true
+		}
+	}
+	return false
+}
+
+func codePointOffsets(s string, start, end int) (int, int) {
+	cs := utf8.RuneCountInString(s[:start])
+	return cs, cs + utf8.RuneCountInString(s[start:end])
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9')
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,187 @@
+package text_test
+
+import (
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExtractMentions(t *testing.T) {
+	cases := []struct {
+		name   string
+		s      string
+		expect []resources.TweetEntityMention
+	}{
+		{
+			name: "ok",
+			s:    "@alice hi @bob_2",
+			expect: []resources.TweetEntityMention{
+				{Start: gotwi.Int(0), End: gotwi.Int(6), Username: gotwi.String("alice")},
+				{Start: gotwi.Int(10), End: gotwi.Int(16), Username: gotwi.String("bob_2")},
+			},
+		},
+		{
+			name: "ok: offsets are code points",
+			s:    "こんにちは @alice",
+			expect: []resources.TweetEntityMention{
+				{Start: gotwi.Int(6), End: gotwi.Int(12), Username: gotwi.String("alice")},
+			},
+		},
+		{
+			name:   "ignored: email address",
+			s:      "mail to alice@example.com",
+			expect: []resources.TweetEntityMention{},
+		},
+		{
+			name:   "ignored: too long username",
+			s:      "@abcdefghijklmnopq",
+			expect: []resources.TweetEntityMention{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, text.ExtractMentions(c.s))
+		})
+	}
+}
+
+func Test_ExtractHashTags(t *testing.T) {
+	cases := []struct {
+		name   string
+		s      string
+		expect []resources.TweetEntityTag
+	}{
+		{
+			name: "ok",
+			s:    "#golang and #日本語",
+			expect: []resources.TweetEntityTag{
+				{Start: gotwi.Int(0), End: gotwi.Int(7), Tag: gotwi.String("golang")},
+				{Start: gotwi.Int(12), End: gotwi.Int(16), Tag: gotwi.String("日本語")},
+			},
+		},
+		{
+			name:   "ignored: only digits",
+			s:      "#123",
+			expect: []resources.TweetEntityTag{},
+		},
+		{
+			name:   "ignored: in URL",
+			s:      "https://example.com/#anchor",
+			expect: []resources.TweetEntityTag{},
+		},
+		{
+			name:   "ignored: HTML entity",
+			s:      "&#39;",
+			expect: []resources.TweetEntityTag{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, text.ExtractHashTags(c.s))
+		})
+	}
+}
+
+func Test_ExtractCashTags(t *testing.T) {
+	cases := []struct {
+		name   string
+		s      string
+		expect []resources.TweetEntityTag
+	}{
+		{
+			name: "ok",
+			s:    "$TWTR and $BRK.A",
+			expect: []resources.TweetEntityTag{
+				{Start: gotwi.Int(0), End: gotwi.Int(5), Tag: gotwi.String("TWTR")},
+				{Start: gotwi.Int(10), End: gotwi.Int(16), Tag: gotwi.String("BRK.A")},
+			},
+		},
+		{
+			name:   "ignored: price",
+			s:      "it costs $100",
+			expect: []resources.TweetEntityTag{},
+		},
+		{
+			name:   "ignored: too long",
+			s:      "$ABCDEFG",
+			expect: []resources.TweetEntityTag{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, text.ExtractCashTags(c.s))
+		})
+	}
+}
+
+func Test_ExtractURLs(t *testing.T) {
+	cases := []struct {
+		name   string
+		s      string
+		expect []resources.URL
+	}{
+		{
+			name: "ok",
+			s:    "see https://example.com/path?q=1.",
+			expect: []resources.URL{
+				{Start: gotwi.Int(4), End: gotwi.Int(32), URL: gotwi.String("https://example.com/path?q=1")},
+			},
+		},
+		{
+			name: "ok: without scheme",
+			s:    "(www.example.com) and example.org/a",
+			expect: []resources.URL{
+				{Start: gotwi.Int(1), End: gotwi.Int(16), URL: gotwi.String("www.example.com")},
+				{Start: gotwi.Int(22), End: gotwi.Int(35), URL: gotwi.String("example.org/a")},
+			},
+		},
+		{
+			name: "ok: balanced parentheses",
+			s:    "https://en.wikipedia.org/wiki/Go_(programming_language)",
+			expect: []resources.URL{
+				{Start: gotwi.Int(0), End: gotwi.Int(55), URL: gotwi.String("https://en.wikipedia.org/wiki/Go_(programming_language)")},
+			},
+		},
+		{
+			name: "ok: ccTLD needs a path without scheme",
+			s:    "index.in and example.jp/a and example.co.jp/b and example.tv",
+			expect: []resources.URL{
+				{Start: gotwi.Int(13), End: gotwi.Int(25), URL: gotwi.String("example.jp/a")},
+				{Start: gotwi.Int(30), End: gotwi.Int(45), URL: gotwi.String("example.co.jp/b")},
+				{Start: gotwi.Int(50), End: gotwi.Int(60), URL: gotwi.String("example.tv")},
+			},
+		},
+		{
+			name:   "none: ccTLD domains without path",
+			s:      "file.go and index.in and example.co.jp here",
+			expect: []resources.URL{},
+		},
+		{
+			name:   "none",
+			s:      "no links here.",
+			expect: []resources.URL{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, text.ExtractURLs(c.s))
+		})
+	}
+}
+
+func Test_ExtractEntities(t *testing.T) {
+	e := text.ExtractEntities("@alice #go $GO https://go.dev")
+
+	assert.Len(t, e.Mentions, 1)
+	assert.Len(t, e.HashTags, 1)
+	assert.Len(t, e.CashTags, 1)
+	assert.Len(t, e.URLs, 1)
+	assert.Nil(t, e.Annotations)
+}
@@ -0,0 +1,209 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// MaxWeightedTweetLength is the maximum weighted length of the text of a Tweet.
+	MaxWeightedTweetLength = 280
+
+	// TransformedURLLength is the length of a URL that is shortened by t.co.
+	TransformedURLLength = 23
+
+	emojiWeight   = 2
+	defaultWeight = 2
+)
+
+// weightedRanges are the code point ranges whose characters are counted as 1.
+// All other characters are counted as defaultWeight.
+var weightedRanges = [][2]rune{
+	{0x0000, 0x10FF},
+	{0x2000, 0x200D},
+	{0x2010, 0x201F},
+	{0x2032, 0x2037},
+}
+
+// invalidCharacters are the characters that cannot be contained in the text of a Tweet.
+var invalidCharacters = []rune{0xFFFE, 0xFEFF, 0xFFFF}
+
+// ParseResult is the result of parsing the text of a Tweet.
+type ParseResult struct {
+	// Weighted length of the text.
+	WeightedLength int
+
+	// Weighted length of the text per mille of MaxWeightedTweetLength.
+	Permillage int
+
+	// Whether the text can be posted as a Tweet.
+	Valid bool
+}
+
+// Normalize returns the text normalized to NFC, that is the form used for counting the length.
+func Normalize(s string) string {
+	return norm.NFC.String(s)
+}
+
+// Parse parses the text of a Tweet with the weighted length rules.
+// https://developer.x.com/en/docs/counting-characters
+func Parse(s string) *ParseResult {
+	l := WeightedLength(s)
+
+	return &ParseResult{
+		WeightedLength: l,
+		Permillage:     l * 1000 / MaxWeightedTweetLength,
+		Valid:          l > 0 && l <= MaxWeightedTweetLength && strings.TrimSpace(s) != "" && !hasInvalidCharacters(s),
+	}
+}
+
+// Valid returns whether the text can be posted as a Tweet.
+func Valid(s string) bool {
+	return Parse(s).Valid
+}
+
+// WeightedLength returns the length of the text counted with the weighted length rules.
+// The text is normalized to NFC, each URL is counted as TransformedURLLength,
+// each emoji sequence is counted as 2, and other characters are counted as 1 or 2 according to their code point.
+func WeightedLength(s string) int {
+	s = Normalize(s)
+
+	l := 0
+	last := 0
+	for _, loc := range urlIndexes(s) {
+		l += runesWeightedLength([]rune(s[last:loc[0]])) + TransformedURLLength
+		last = loc[1]
+	}
+
+	return l + runesWeightedLength([]rune(s[last:]))
+}
+
+// Graphemes splits the text into the characters that must not be split, i.e. the emoji sequences
+// counted by WeightedLength, and the characters with their combining marks.
+func Graphemes(s string) []string {
+	rs := []rune(s)
+	gs := []string{}
+	for i := 0; i < len(rs); {
+		n := emojiSequenceLength(rs, i)
+		if n == 0 {
+			n = 1
+		}
+		if rs[i] == '\r' && i+1 < len(rs) && rs[i+1] == '\n' {
+			n = 2
+		}
+		for i+n < len(rs) && isExtending(rs[i+n]) {
+			n++
+		}
+
+		gs = append(gs, string(rs[i:i+n]))
+		i += n
+	}
+
+	return gs
+}
+
+func runesWeightedLength(rs []rune) int {
+	l := 0
+	for i := 0; i < len(rs); {
+		if n := emojiSequenceLength(rs, i); n > 0 {
+			l += emojiWeight
+			i += n
+			continue
+		}
+
+		l += runeWeight(rs[i])
+		i++
+	}
+
+	return l
+}
+
+func runeWeight(r rune) int {
+	for _, wr := range weightedRanges {
+		if wr[0] <= r && r <= wr[1] {
+			return 1
+		}
+	}
+	return defaultWeight
+}
+
+func hasInvalidCharacters(s string) bool {
+	for _, r := range invalidCharacters {
+		if strings.ContainsRune(s, r) {
+			return true
+		}
+	}
+	return !utf8.ValidString(s)
+}
+
+const (
+	zeroWidthJoiner   = 0x200D
+	variationSelector = 0xFE0F
+	combiningKeycap   = 0x20E3
+)
+
+func isEmojiBase(r rune) bool {
+	return (0x1F000 <= r && r <= 0x1FAFF) ||
+		(0x2600 <= r && r <= 0x27BF) ||
+		(0x2B00 <= r && r <= 0x2BFF)
+}
+
+func isKeycapBase(r rune) bool {
+	return ('0' <= r && r <= '9') || r == '#' || r == '*'
+}
+
+func isRegionalIndicator(r rune) bool {
+	return 0x1F1E6 <= r && r <= 0x1F1FF
+}
+
+func isEmojiModifier(r rune) bool {
+	return r == variationSelector ||
+		r == combiningKeycap ||
+		(0x1F3FB <= r && r <= 0x1F3FF) || // skin tones
+		(0xE0020 <= r && r <= 0xE007F) // tags
+}
+
+// isExtending reports whether r belongs to the preceding character, e.g. a combining mark.
+func isExtending(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me) || r == zeroWidthJoiner || isEmojiModifier(r)
+}
+
+// emojiSequenceLength returns the number of runes of the emoji sequence that starts at rs[i].
+// It returns 0 if rs[i] is not the start of an emoji sequence.
+func emojiSequenceLength(rs []rune, i int) int {
+	if isRegionalIndicator(rs[i]) && i+1 < len(rs) && isRegionalIndicator(rs[i+1]) {
+		return 2
+	}
+
+	if isKeycapBase(rs[i]) {
+		switch {
+		case i+1 < len(rs) && rs[i+1] == combiningKeycap:
+			return 2
+		case i+2 < len(rs) && rs[i+1] == variationSelector && rs[i+2] == combiningKeycap:
+			return 3
+		}
+		return 0
+	}
+
+	if !isEmojiBase(rs[i]) {
+		return 0
+	}
+
+	j := i + 1
+	for j < len(rs) {
+		if isEmojiModifier(rs[j]) {
+			j++
+			continue
+		}
+		if rs[j] == zeroWidthJoiner && j+1 < len(rs) {
+			j += 2
+			continue
+		}
+		break
+	}
+
+	return j - i
+}
@@ -0,0 +1,116 @@
+package text_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/michimani/gotwi/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WeightedLength(t *testing.T) {
+	cases := []struct {
+		name   string
+		s      string
+		expect int
+	}{
+		{name: "empty", s: "", expect: 0},
+		{name: "ascii", s: "Hello, world!", expect: 13},
+		{name: "latin with accents", s: "café", expect: 4},
+		{name: "decomposed characters are normalized", s: "café", expect: 4},
+		{name: "CJK", s: "こんにちは世界", expect: 14},
+		{name: "hangul", s: "안녕", expect: 4},
+		{name: "emoji", s: "😀", expect: 2},
+		{name: "emoji with skin tone", s: "👍🏽", expect: 2},
+		{name: "ZWJ sequence", s: "👨‍👩‍👧‍👦", expect: 2},
+		{name: "flag", s: "🇯🇵", expect: 2},
+		{name: "keycap", s: "1️⃣", expect: 2},
+		{name: "ZWJ between letters is not an emoji", s: "a\u200db", expect: 3},
+		{name: "general punctuation", s: "“quoted”…", expect: 10},
+		{name: "URL", s: "https://example.com/a/very/long/path/that/is/counted/as/23", expect: 23},
+		{name: "URL without scheme", s: "see example.com", expect: 27},
+		{name: "ccTLD domain without path is not a URL", s: "file.go and index.in here", expect: 25},
+		{name: "ccTLD domain with path", s: "see index.in/path", expect: 27},
+		{name: "co domain without path", s: "see example.co", expect: 27},
+		{name: "text and URLs", s: "a https://example.com b www.example.org", expect: 51},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, text.WeightedLength(c.s))
+		})
+	}
+}
+
+func Test_Parse(t *testing.T) {
+	cases := []struct {
+		name   string
+		s      string
+		expect *text.ParseResult
+	}{
+		{
+			name:   "valid",
+			s:      "Hello",
+			expect: &text.ParseResult{WeightedLength: 5, Permillage: 17, Valid: true},
+		},
+		{
+			name:   "valid: maximum length",
+			s:      strings.Repeat("a", 280),
+			expect: &text.ParseResult{WeightedLength: 280, Permillage: 1000, Valid: true},
+		},
+		{
+			name:   "invalid: too long",
+			s:      strings.Repeat("あ", 141),
+			expect: &text.ParseResult{WeightedLength: 282, Permillage: 1007, Valid: false},
+		},
+		{
+			name:   "invalid: empty",
+			s:      "",
+			expect: &text.ParseResult{WeightedLength: 0, Permillage: 0, Valid: false},
+		},
+		{
+			name:   "invalid: only spaces",
+			s:      "   ",
+			expect: &text.ParseResult{WeightedLength: 3, Permillage: 10, Valid: false},
+		},
+		{
+			name:   "invalid: invalid character",
+			s:      "a￾",
+			expect: &text.ParseResult{WeightedLength: 3, Permillage: 10, Valid: false},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, text.Parse(c.s))
+			assert.Equal(tt, c.expect.Valid, text.Valid(c.s))
+		})
+	}
+}
+
+func Test_Normalize(t *testing.T) {
+	assert.Equal(t, "café", text.Normalize("café"))
+}
+
+func Test_Graphemes(t *testing.T) {
+	cases := []struct {
+		name   string
+		s      string
+		expect []string
+	}{
+		{name: "empty", s: "", expect: []string{}},
+		{name: "ascii", s: "ab", expect: []string{"a", "b"}},
+		{name: "combining mark", s: "e\u0301x", expect: []string{"e\u0301", "x"}},
+		{name: "ZWJ sequence", s: "a👩\u200d💻b", expect: []string{"a", "👩\u200d💻", "b"}},
+		{name: "skin tone", s: "👍🏽!", expect: []string{"👍🏽", "!"}},
+		{name: "flags", s: "🇯🇵🇺🇸", expect: []string{"🇯🇵", "🇺🇸"}},
+		{name: "keycap", s: "1\ufe0f\u20e32", expect: []string{"1\ufe0f\u20e3", "2"}},
+		{name: "CRLF", s: "a\r\nb", expect: []string{"a", "\r\n", "b"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, text.Graphemes(c.s))
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package trends
+
+import (
+	"context"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/trends/types"
+)
+
+const (
+	listByWOEIDEndpoint      = "https://api.twitter.com/2/trends/by/woeid/:woeid"
+	listPersonalizedEndpoint = "https://api.twitter.com/2/users/personalized_trends"
+)
+
+// Returns the trends for the location specified by WOEID.
+// https://docs.x.com/x-api/trends/get-trends-by-woeid
+func ListByWOEID(ctx context.Context, c gotwi.IClient, p *types.ListByWOEIDInput, opts ...gotwi.CallOption) (*types.ListByWOEIDOutput, error) {
+	return gotwi.Do[*types.ListByWOEIDOutput](ctx, c, "GET", listByWOEIDEndpoint, p, opts...)
+}
+
+// Returns the trends personalized for the authenticated user.
+// https://docs.x.com/x-api/trends/get-personalized-trends
+func ListPersonalized(ctx context.Context, c gotwi.IClient, p *types.ListPersonalizedInput, opts ...gotwi.CallOption) (*types.ListPersonalizedOutput, error) {
+	return gotwi.Do[*types.ListPersonalizedOutput](ctx, c, "GET", listPersonalizedEndpoint, p, opts...)
+}
@@ -0,0 +1,103 @@
+package trends_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/trends"
+	"github.com/michimani/gotwi/trends/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ListByWOEID(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *types.ListByWOEIDInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			params: &types.ListByWOEIDInput{WOEID: 1},
+		},
+		{
+			name:    "error",
+			params:  &types.ListByWOEIDInput{WOEID: 1},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "error: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return c.mockErr
+				},
+			})
+
+			res, err := trends.ListByWOEID(context.Background(), mockClient, c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, res)
+		})
+	}
+}
+
+func Test_ListPersonalized(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *types.ListPersonalizedInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			params: &types.ListPersonalizedInput{},
+		},
+		{
+			name:    "error",
+			params:  &types.ListPersonalizedInput{},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "error: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return c.mockErr
+				},
+			})
+
+			res, err := trends.ListPersonalized(context.Background(), mockClient, c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, res)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package trends
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/trends/types"
+)
+
+const DefaultPollInterval = time.Duration(5) * time.Minute
+
+type DiffType string
+
+const (
+	DiffTypeNew         DiffType = "new"
+	DiffTypeDropped     DiffType = "dropped"
+	DiffTypeRankChanged DiffType = "rank_changed"
+)
+
+// Diff is a change of the trends of a location between two polls.
+// Rank starts from 1. Rank is 0 for a dropped trend, and PreviousRank is 0 for a new trend.
+type Diff struct {
+	WOEID        int
+	Type         DiffType
+	Trend        resources.Trend
+	Rank         int
+	PreviousRank int
+}
+
+type NewPollerInput struct {
+	Client gotwi.IClient
+	// WOEIDs is the list of locations to poll. Required.
+	WOEIDs []int
+	// Interval is the interval of Run. The default is DefaultPollInterval.
+	Interval  time.Duration
+	MaxTrends types.ListByWOEIDMaxTrends
+	// OnDiff is called by Run with the diffs of each poll that has any.
+	OnDiff func(ctx context.Context, diffs []Diff)
+	// OnError is called by Run when polling a location fails. If it is nil, the error is ignored
+	// and the location is polled again on the next tick.
+	OnError func(ctx context.Context, err error)
+}
+
+// Poller polls the trends of a set of locations and reports the differences from the previous poll.
+type Poller struct {
+	client    gotwi.IClient
+	woeids    []int
+	interval  time.Duration
+	maxTrends types.ListByWOEIDMaxTrends
+	onDiff    func(ctx context.Context, diffs []Diff)
+	onError   func(ctx context.Context, err error)
+
+	mu       sync.Mutex
+	previous map[int][]resources.Trend
+}
+
+func NewPoller(in *NewPollerInput) (*Poller, error) {
+	if in == nil {
+		return nil, errors.New("NewPollerInput is nil")
+	}
+	if in.Client == nil {
+		return nil, errors.New("NewPollerInput.Client is nil")
+	}
+	if len(in.WOEIDs) == 0 {
+		return nil, errors.New("NewPollerInput.WOEIDs is empty")
+	}
+
+	interval := in.Interval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	return &Poller{
+		client:    in.Client,
+		woeids:    in.WOEIDs,
+		interval:  interval,
+		maxTrends: in.MaxTrends,
+		onDiff:    in.OnDiff,
+		onError:   in.OnError,
+		previous:  map[int][]resources.Trend{},
+	}, nil
+}
+
+// Poll fetches the trends of all locations once and returns the diffs from the previous poll.
+// On the first poll of a location, all of its trends are reported as new.
+// A location that fails is skipped, and the errors are returned together with the diffs of the others.
+func (p *Poller) Poll(ctx context.Context) ([]Diff, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	diffs := []Diff{}
+	var errs []error
+	for _, woeid := range p.woeids {
+		res, err := ListByWOEID(ctx, p.client, &types.ListByWOEIDInput{
+			WOEID:     woeid,
+			MaxTrends: p.maxTrends,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("woeid %d: %w", woeid, err))
+			continue
+		}
+
+		diffs = append(diffs, Compare(woeid, p.previous[woeid], res.Data)...)
+		p.previous[woeid] = res.Data
+	}
+
+	return diffs, errors.Join(errs...)
+}
+
+// Run polls on the interval until ctx is done.
+func (p *Poller) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		diffs, err := p.Poll(ctx)
+		if err != nil && ctx.Err() == nil && p.onError != nil {
+			p.onError(ctx, err)
+		}
+		if len(diffs) > 0 && p.onDiff != nil {
+			p.onDiff(ctx, diffs)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Compare returns the diffs from previous to current, which are lists of trends in rank order.
+// Trends are identified by name, and only the first occurrence of a name in each list is compared.
+func Compare(woeid int, previous, current []resources.Trend) []Diff {
+	previousRanks := ranks(previous)
+	currentRanks := ranks(current)
+
+	diffs := []Diff{}
+	for i, t := range current {
+		rank := i + 1
+		name := trendName(t)
+		if currentRanks[name] != rank {
+			// duplicate
+			continue
+		}
+		prev, ok := previousRanks[name]
+		switch {
+		case !ok:
+			diffs = append(diffs, Diff{WOEID: woeid, Type: DiffTypeNew, Trend: t, Rank: rank})
+		case prev != rank:
+			diffs = append(diffs, Diff{WOEID: woeid, Type: DiffTypeRankChanged, Trend: t, Rank: rank, PreviousRank: prev})
+		}
+	}
+
+	for i, t := range previous {
+		name := trendName(t)
+		if previousRanks[name] != i+1 {
+			// duplicate
+			continue
+		}
+		if _, ok := currentRanks[name]; !ok {
+			diffs = append(diffs, Diff{WOEID: woeid, Type: DiffTypeDropped, Trend: t, PreviousRank: i + 1})
+		}
+	}
+
+	return diffs
+}
+
+// ranks returns the rank of the first occurrence of each trend name.
+func ranks(trends []resources.Trend) map[string]int {
+	m := make(map[string]int, len(trends))
+	for i, t := range trends {
+		name := trendName(t)
+		if _, ok := m[name]; !ok {
+			m[name] = i + 1
+		}
+	}
+
+	return m
+}
+
+func trendName(t resources.Trend) string {
+	if t.TrendName == nil {
+		return ""
+	}
+	return *t.TrendName
+}
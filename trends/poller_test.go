@@ -0,0 +1,176 @@
+package trends_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/trends"
+	"github.com/michimani/gotwi/trends/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func trendList(names ...string) []resources.Trend {
+	l := []resources.Trend{}
+	for _, n := range names {
+		l = append(l, resources.Trend{TrendName: &n})
+	}
+	return l
+}
+
+type diffSummary struct {
+	Type         trends.DiffType
+	Name         string
+	Rank         int
+	PreviousRank int
+}
+
+func summarize(diffs []trends.Diff) []diffSummary {
+	s := []diffSummary{}
+	for _, d := range diffs {
+		s = append(s, diffSummary{Type: d.Type, Name: *d.Trend.TrendName, Rank: d.Rank, PreviousRank: d.PreviousRank})
+	}
+	return s
+}
+
+func Test_Compare(t *testing.T) {
+	cases := []struct {
+		name     string
+		previous []resources.Trend
+		current  []resources.Trend
+		expect   []diffSummary
+	}{
+		{
+			name:     "first poll",
+			previous: nil,
+			current:  trendList("a", "b"),
+			expect: []diffSummary{
+				{Type: trends.DiffTypeNew, Name: "a", Rank: 1},
+				{Type: trends.DiffTypeNew, Name: "b", Rank: 2},
+			},
+		},
+		{
+			name:     "no change",
+			previous: trendList("a", "b"),
+			current:  trendList("a", "b"),
+			expect:   []diffSummary{},
+		},
+		{
+			name:     "new, dropped and rank changed",
+			previous: trendList("a", "b", "c"),
+			current:  trendList("b", "a", "d"),
+			expect: []diffSummary{
+				{Type: trends.DiffTypeRankChanged, Name: "b", Rank: 1, PreviousRank: 2},
+				{Type: trends.DiffTypeRankChanged, Name: "a", Rank: 2, PreviousRank: 1},
+				{Type: trends.DiffTypeNew, Name: "d", Rank: 3},
+				{Type: trends.DiffTypeDropped, Name: "c", PreviousRank: 3},
+			},
+		},
+		{
+			name:     "duplicated names",
+			previous: trendList("a", "c", "a", "c"),
+			current:  trendList("b", "a", "b", "a"),
+			expect: []diffSummary{
+				{Type: trends.DiffTypeNew, Name: "b", Rank: 1},
+				{Type: trends.DiffTypeRankChanged, Name: "a", Rank: 2, PreviousRank: 1},
+				{Type: trends.DiffTypeDropped, Name: "c", PreviousRank: 2},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			diffs := trends.Compare(1, c.previous, c.current)
+			assert.Equal(tt, c.expect, summarize(diffs))
+		})
+	}
+}
+
+func Test_Poller_Poll(t *testing.T) {
+	asst := assert.New(t)
+
+	responses := map[int][][]resources.Trend{
+		1:        {trendList("a", "b"), trendList("b", "c")},
+		23424856: {trendList("x"), trendList("x")},
+	}
+	calls := map[int]int{}
+	failing := false
+
+	mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+		MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+			woeid := p.(*types.ListByWOEIDInput).WOEID
+			if failing && woeid == 23424856 {
+				return errors.New("error")
+			}
+			i.(*types.ListByWOEIDOutput).Data = responses[woeid][calls[woeid]]
+			calls[woeid]++
+			return nil
+		},
+	})
+
+	p, err := trends.NewPoller(&trends.NewPollerInput{
+		Client: mockClient,
+		WOEIDs: []int{1, 23424856},
+	})
+	asst.NoError(err)
+
+	diffs, err := p.Poll(context.Background())
+	asst.NoError(err)
+	asst.Len(diffs, 3)
+
+	failing = true
+	diffs, err = p.Poll(context.Background())
+	asst.Error(err)
+	asst.Equal([]diffSummary{
+		{Type: trends.DiffTypeRankChanged, Name: "b", Rank: 1, PreviousRank: 2},
+		{Type: trends.DiffTypeNew, Name: "c", Rank: 2},
+		{Type: trends.DiffTypeDropped, Name: "a", PreviousRank: 1},
+	}, summarize(diffs))
+}
+
+func Test_NewPoller(t *testing.T) {
+	mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{})
+
+	cases := []struct {
+		name    string
+		in      *trends.NewPollerInput
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			in:   &trends.NewPollerInput{Client: mockClient, WOEIDs: []int{1}},
+		},
+		{
+			name:    "error: no locations",
+			in:      &trends.NewPollerInput{Client: mockClient},
+			wantErr: true,
+		},
+		{
+			name:    "error: no client",
+			in:      &trends.NewPollerInput{WOEIDs: []int{1}},
+			wantErr: true,
+		},
+		{
+			name:    "error: nil",
+			in:      nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			p, err := trends.NewPoller(c.in)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, p)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, p)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/internal/util"
+)
+
+type ListByWOEIDMaxTrends int
+
+func (m ListByWOEIDMaxTrends) Valid() bool {
+	return m >= 1 && m <= 50
+}
+
+func (m ListByWOEIDMaxTrends) String() string {
+	return strconv.Itoa(int(m))
+}
+
+type ListByWOEIDInput struct {
+	accessToken string
+
+	// Path parameter
+	WOEID int // required: Yahoo! Where On Earth ID of the location. 1 is worldwide.
+
+	// Query parameters
+	MaxTrends   ListByWOEIDMaxTrends
+	TrendFields fields.TrendFieldList
+}
+
+var listByWOEIDQueryParameters = map[string]struct{}{
+	"max_trends":   {},
+	"trend.fields": {},
+}
+
+func (p *ListByWOEIDInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *ListByWOEIDInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *ListByWOEIDInput) ResolveEndpoint(endpointBase string) string {
+	if p.WOEID <= 0 {
+		return ""
+	}
+
+	endpoint := strings.Replace(endpointBase, ":woeid", strconv.Itoa(p.WOEID), 1)
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, listByWOEIDQueryParameters)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func (p *ListByWOEIDInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *ListByWOEIDInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+	m = fields.SetFieldsParams(m, p.TrendFields)
+
+	if p.MaxTrends.Valid() {
+		m["max_trends"] = p.MaxTrends.String()
+	}
+
+	return m
+}
+
+type ListPersonalizedInput struct {
+	accessToken string
+
+	// Query parameters
+	PersonalizedTrendFields fields.PersonalizedTrendFieldList
+}
+
+var listPersonalizedQueryParameters = map[string]struct{}{
+	"personalized_trend.fields": {},
+}
+
+func (p *ListPersonalizedInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *ListPersonalizedInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *ListPersonalizedInput) ResolveEndpoint(endpointBase string) string {
+	endpoint := endpointBase
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, listPersonalizedQueryParameters)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func (p *ListPersonalizedInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *ListPersonalizedInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+	m = fields.SetFieldsParams(m, p.PersonalizedTrendFields)
+	return m
+}
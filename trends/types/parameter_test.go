@@ -0,0 +1,79 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/trends/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ListByWOEIDInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint/:woeid"
+
+	cases := []struct {
+		name   string
+		params *types.ListByWOEIDInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.ListByWOEIDInput{WOEID: 1},
+			expect: "test/endpoint/1",
+		},
+		{
+			name: "all query parameters",
+			params: &types.ListByWOEIDInput{
+				WOEID:       23424856,
+				MaxTrends:   10,
+				TrendFields: fields.TrendFieldList{fields.TrendFieldTrendName, fields.TrendFieldTweetCount},
+			},
+			expect: "test/endpoint/23424856?max_trends=10&trend.fields=trend_name%2Ctweet_count",
+		},
+		{
+			name:   "invalid max_trends",
+			params: &types.ListByWOEIDInput{WOEID: 1, MaxTrends: 51},
+			expect: "test/endpoint/1",
+		},
+		{
+			name:   "woeid is not set",
+			params: &types.ListByWOEIDInput{},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, c.params.ResolveEndpoint(endpoint))
+		})
+	}
+}
+
+func Test_ListPersonalizedInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint"
+
+	cases := []struct {
+		name   string
+		params *types.ListPersonalizedInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.ListPersonalizedInput{},
+			expect: endpoint,
+		},
+		{
+			name: "with personalized_trend.fields",
+			params: &types.ListPersonalizedInput{
+				PersonalizedTrendFields: fields.PersonalizedTrendFieldList{fields.PersonalizedTrendFieldCategory, fields.PersonalizedTrendFieldPostCount},
+			},
+			expect: endpoint + "?personalized_trend.fields=category%2Cpost_count",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, c.params.ResolveEndpoint(endpoint))
+		})
+	}
+}
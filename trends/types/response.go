@@ -0,0 +1,21 @@
+package types
+
+import "github.com/michimani/gotwi/resources"
+
+type ListByWOEIDOutput struct {
+	Data   []resources.Trend        `json:"data"`
+	Errors []resources.PartialError `json:"errors"`
+}
+
+func (r *ListByWOEIDOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+type ListPersonalizedOutput struct {
+	Data   []resources.Trend        `json:"data"`
+	Errors []resources.PartialError `json:"errors"`
+}
+
+func (r *ListPersonalizedOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
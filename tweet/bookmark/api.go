@@ -8,41 +8,40 @@ import (
 )
 
 const (
-	listEndpoint   = "https://api.twitter.com/2/users/:id/bookmarks"
-	createEndpoint = "https://api.twitter.com/2/users/:id/bookmarks"
-	deleteEndpoint = "https://api.twitter.com/2/users/:id/bookmarks/:tweet_id"
+	listEndpoint         = "https://api.twitter.com/2/users/:id/bookmarks"
+	createEndpoint       = "https://api.twitter.com/2/users/:id/bookmarks"
+	deleteEndpoint       = "https://api.twitter.com/2/users/:id/bookmarks/:tweet_id"
+	listFoldersEndpoint  = "https://api.twitter.com/2/users/:id/bookmarks/folders"
+	listByFolderEndpoint = "https://api.twitter.com/2/users/:id/bookmarks/folders/:folder_id"
 )
 
 // Allows you to get information about a authenticated user’s 800 most recent bookmarked Tweets
 // https://developer.twitter.com/en/docs/twitter-api/tweets/bookmarks/api-reference/get-users-id-bookmarks
-func List(ctx context.Context, c gotwi.IClient, p *types.ListInput) (*types.ListOutput, error) {
-	res := &types.ListOutput{}
-	if err := c.CallAPI(ctx, listEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func List(ctx context.Context, c gotwi.IClient, p *types.ListInput, opts ...gotwi.CallOption) (*types.ListOutput, error) {
+	return gotwi.Do[*types.ListOutput](ctx, c, "GET", listEndpoint, p, opts...)
 }
 
 // Causes the user ID of an authenticated user identified in the path parameter
 // to Bookmark the target Tweet provided in the request body.
 // https://developer.twitter.com/en/docs/twitter-api/tweets/bookmarks/api-reference/post-users-id-bookmarks
-func Create(ctx context.Context, c gotwi.IClient, p *types.CreateInput) (*types.CreateOutput, error) {
-	res := &types.CreateOutput{}
-	if err := c.CallAPI(ctx, createEndpoint, "POST", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func Create(ctx context.Context, c gotwi.IClient, p *types.CreateInput, opts ...gotwi.CallOption) (*types.CreateOutput, error) {
+	return gotwi.Do[*types.CreateOutput](ctx, c, "POST", createEndpoint, p, opts...)
 }
 
 // Allows a user or authenticated user ID to remove a Bookmark of a Tweet.
 // https://developer.twitter.com/en/docs/twitter-api/tweets/bookmarks/api-reference/delete-users-id-bookmarks-tweet_id
-func Delete(ctx context.Context, c gotwi.IClient, p *types.DeleteInput) (*types.DeleteOutput, error) {
-	res := &types.DeleteOutput{}
-	if err := c.CallAPI(ctx, deleteEndpoint, "DELETE", p, res); err != nil {
-		return nil, err
-	}
+func Delete(ctx context.Context, c gotwi.IClient, p *types.DeleteInput, opts ...gotwi.CallOption) (*types.DeleteOutput, error) {
+	return gotwi.Do[*types.DeleteOutput](ctx, c, "DELETE", deleteEndpoint, p, opts...)
+}
+
+// Returns the bookmark folders of the authenticated user.
+// https://docs.x.com/x-api/users/get-bookmark-folders
+func ListFolders(ctx context.Context, c gotwi.IClient, p *types.ListFoldersInput, opts ...gotwi.CallOption) (*types.ListFoldersOutput, error) {
+	return gotwi.Do[*types.ListFoldersOutput](ctx, c, "GET", listFoldersEndpoint, p, opts...)
+}
 
-	return res, nil
+// Returns the IDs of the Tweets bookmarked in the specified bookmark folder of the authenticated user.
+// https://docs.x.com/x-api/users/get-bookmarks-by-folder-id
+func ListByFolder(ctx context.Context, c gotwi.IClient, p *types.ListByFolderInput, opts ...gotwi.CallOption) (*types.ListByFolderOutput, error) {
+	return gotwi.Do[*types.ListByFolderOutput](ctx, c, "GET", listByFolderEndpoint, p, opts...)
 }
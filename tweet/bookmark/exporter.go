@@ -0,0 +1,239 @@
+package bookmark
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/bookmark/types"
+)
+
+// ExportInput is struct for exporting the bookmarks of the authenticated user.
+type ExportInput struct {
+	UserID string // required: The authenticated user ID
+
+	// Fields of the bookmarked Tweets. Default is created_at and author_id.
+	TweetFields fields.TweetFieldList
+}
+
+// Export is the bookmarks of a user organized by folder.
+type Export struct {
+	UserID     string         `json:"user_id"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Folders    []ExportFolder `json:"folders"`
+
+	// Unfiled is the bookmarks that are not in any folder.
+	Unfiled []ExportBookmark `json:"unfiled"`
+}
+
+type ExportFolder struct {
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	Bookmarks []ExportBookmark `json:"bookmarks"`
+}
+
+type ExportBookmark struct {
+	TweetID string `json:"tweet_id"`
+	URL     string `json:"url"`
+
+	// Tweet is nil if the Tweet is in a folder but not returned by List,
+	// which returns only the 800 most recent bookmarks.
+	Tweet  *resources.Tweet `json:"tweet,omitempty"`
+	Author *resources.User  `json:"author,omitempty"`
+}
+
+// ExportAll walks all pages of the bookmarks and the bookmark folders of the user,
+// and returns them organized by folder.
+func ExportAll(ctx context.Context, c gotwi.IClient, p *ExportInput) (*Export, error) {
+	if p == nil {
+		return nil, errors.New("ExportInput is nil")
+	}
+	if p.UserID == "" {
+		return nil, errors.New("ExportInput.UserID is required")
+	}
+
+	tweetFields := p.TweetFields
+	if len(tweetFields) == 0 {
+		tweetFields = fields.TweetFieldList{fields.TweetFieldCreatedAt, fields.TweetFieldAuthorID}
+	}
+
+	tweets := []resources.Tweet{}
+	users := map[string]resources.User{}
+	token := ""
+	for {
+		res, err := List(ctx, c, &types.ListInput{
+			ID:              p.UserID,
+			MaxResults:      types.ListMaxResults(100),
+			PaginationToken: token,
+			Expansions:      fields.ExpansionList{fields.ExpansionAuthorID},
+			TweetFields:     tweetFields,
+			UserFields:      fields.UserFieldList{fields.UserFieldUsername, fields.UserFieldName},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		tweets = append(tweets, res.Data...)
+		for _, u := range res.Includes.Users {
+			if u.ID != nil {
+				users[*u.ID] = u
+			}
+		}
+
+		token = gotwi.StringValue(res.Meta.NextToken)
+		if token == "" {
+			break
+		}
+	}
+
+	folders := []resources.BookmarkFolder{}
+	token = ""
+	for {
+		res, err := ListFolders(ctx, c, &types.ListFoldersInput{
+			ID:              p.UserID,
+			MaxResults:      types.ListFoldersMaxResults(100),
+			PaginationToken: token,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		folders = append(folders, res.Data...)
+
+		token = gotwi.StringValue(res.Meta.NextToken)
+		if token == "" {
+			break
+		}
+	}
+
+	byID := make(map[string]resources.Tweet, len(tweets))
+	for _, t := range tweets {
+		byID[gotwi.StringValue(t.ID)] = t
+	}
+
+	bookmark := func(tweetID string) ExportBookmark {
+		b := ExportBookmark{TweetID: tweetID}
+		t, ok := byID[tweetID]
+		if !ok {
+			b.URL = tweetURL("", tweetID)
+			return b
+		}
+
+		b.Tweet = &t
+		if u, ok := users[gotwi.StringValue(t.AuthorID)]; ok {
+			b.Author = &u
+		}
+		b.URL = tweetURL(gotwi.StringValue(b.authorUsername()), tweetID)
+		return b
+	}
+
+	out := &Export{
+		UserID:     p.UserID,
+		ExportedAt: time.Now(),
+		Folders:    []ExportFolder{},
+		Unfiled:    []ExportBookmark{},
+	}
+	filed := map[string]struct{}{}
+	for _, f := range folders {
+		folderID := gotwi.StringValue(f.ID)
+		res, err := ListByFolder(ctx, c, &types.ListByFolderInput{ID: p.UserID, FolderID: folderID})
+		if err != nil {
+			return nil, fmt.Errorf("folder %s: %w", folderID, err)
+		}
+
+		ef := ExportFolder{ID: folderID, Name: gotwi.StringValue(f.Name), Bookmarks: []ExportBookmark{}}
+		for _, t := range res.Data {
+			id := gotwi.StringValue(t.ID)
+			ef.Bookmarks = append(ef.Bookmarks, bookmark(id))
+			filed[id] = struct{}{}
+		}
+		out.Folders = append(out.Folders, ef)
+	}
+
+	for _, t := range tweets {
+		id := gotwi.StringValue(t.ID)
+		if _, ok := filed[id]; !ok {
+			out.Unfiled = append(out.Unfiled, bookmark(id))
+		}
+	}
+
+	return out, nil
+}
+
+// WriteJSON writes the export as indented JSON.
+func (e *Export) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(e)
+}
+
+// WriteMarkdown writes the export as a Markdown document that has a section for each folder.
+func (e *Export) WriteMarkdown(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# Bookmarks\n\nExported at %s\n", e.ExportedAt.UTC().Format(time.RFC3339))
+	for _, f := range e.Folders {
+		writeMarkdownSection(bw, f.Name, f.Bookmarks)
+	}
+	if len(e.Unfiled) > 0 {
+		writeMarkdownSection(bw, "Unfiled", e.Unfiled)
+	}
+
+	return bw.Flush()
+}
+
+func writeMarkdownSection(w io.Writer, title string, bookmarks []ExportBookmark) {
+	fmt.Fprintf(w, "\n## %s\n\n", escapeMarkdown(title))
+	for _, b := range bookmarks {
+		text := b.TweetID
+		if b.Tweet != nil && b.Tweet.Text != nil {
+			text = escapeMarkdown(strings.Join(strings.Fields(*b.Tweet.Text), " "))
+		}
+
+		line := fmt.Sprintf("- %s ([link](%s))", text, b.URL)
+		if username := b.authorUsername(); username != nil {
+			line = fmt.Sprintf("- @%s: %s ([link](%s))", escapeMarkdown(*username), text, b.URL)
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"`", "\\`",
+)
+
+// escapeMarkdown escapes the Markdown metacharacters in s, so that it is rendered as is.
+func escapeMarkdown(s string) string {
+	s = markdownReplacer.Replace(s)
+	if strings.HasPrefix(s, "#") {
+		s = `\` + s
+	}
+	return s
+}
+
+func (b ExportBookmark) authorUsername() *string {
+	if b.Author == nil {
+		return nil
+	}
+	return b.Author.Username
+}
+
+func tweetURL(username, tweetID string) string {
+	if username == "" {
+		return "https://x.com/i/web/status/" + tweetID
+	}
+	return fmt.Sprintf("https://x.com/%s/status/%s", username, tweetID)
+}
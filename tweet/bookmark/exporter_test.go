@@ -0,0 +1,141 @@
+package bookmark_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/bookmark"
+	"github.com/michimani/gotwi/tweet/bookmark/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newExportMockClient(listErr error) *gotwi.MockGotwiClient {
+	return gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+		MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+			switch in := p.(type) {
+			case *types.ListInput:
+				if listErr != nil {
+					return listErr
+				}
+				out := i.(*types.ListOutput)
+				if in.PaginationToken == "" {
+					out.Data = []resources.Tweet{
+						{ID: gotwi.String("1"), Text: gotwi.String("first\nbookmark"), AuthorID: gotwi.String("u1")},
+						{ID: gotwi.String("2"), Text: gotwi.String("second bookmark"), AuthorID: gotwi.String("u2")},
+					}
+					out.Includes.Users = []resources.User{{ID: gotwi.String("u1"), Username: gotwi.String("alice")}}
+					out.Meta.NextToken = gotwi.String("next")
+					return nil
+				}
+				out.Data = []resources.Tweet{{ID: gotwi.String("3"), Text: gotwi.String("third bookmark")}}
+			case *types.ListFoldersInput:
+				out := i.(*types.ListFoldersOutput)
+				out.Data = []resources.BookmarkFolder{{ID: gotwi.String("f1"), Name: gotwi.String("Go")}}
+			case *types.ListByFolderInput:
+				out := i.(*types.ListByFolderOutput)
+				out.Data = []resources.Tweet{{ID: gotwi.String("1")}, {ID: gotwi.String("999")}}
+			}
+			return nil
+		},
+	})
+}
+
+func Test_ExportAll(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *bookmark.ExportInput
+		listErr error
+		wantErr bool
+	}{
+		{
+			name:   "ok",
+			params: &bookmark.ExportInput{UserID: "me"},
+		},
+		{
+			name:    "ng: error",
+			params:  &bookmark.ExportInput{UserID: "me"},
+			listErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "ng: user id is empty",
+			params:  &bookmark.ExportInput{},
+			wantErr: true,
+		},
+		{
+			name:    "ng: params is nil",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			got, err := bookmark.ExportAll(context.Background(), newExportMockClient(c.listErr), c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, got)
+				return
+			}
+
+			assert.NoError(tt, err)
+			if assert.Len(tt, got.Folders, 1) {
+				f := got.Folders[0]
+				assert.Equal(tt, "Go", f.Name)
+				if assert.Len(tt, f.Bookmarks, 2) {
+					assert.Equal(tt, "https://x.com/alice/status/1", f.Bookmarks[0].URL)
+					assert.Equal(tt, "alice", gotwi.StringValue(f.Bookmarks[0].Author.Username))
+					assert.Nil(tt, f.Bookmarks[1].Tweet)
+					assert.Equal(tt, "https://x.com/i/web/status/999", f.Bookmarks[1].URL)
+				}
+			}
+			if assert.Len(tt, got.Unfiled, 2) {
+				assert.Equal(tt, "2", got.Unfiled[0].TweetID)
+				assert.Nil(tt, got.Unfiled[0].Author)
+				assert.Equal(tt, "3", got.Unfiled[1].TweetID)
+			}
+		})
+	}
+}
+
+func Test_Export_Write(t *testing.T) {
+	e, err := bookmark.ExportAll(context.Background(), newExportMockClient(nil), &bookmark.ExportInput{UserID: "me"})
+	assert.NoError(t, err)
+
+	var j bytes.Buffer
+	assert.NoError(t, e.WriteJSON(&j))
+	decoded := bookmark.Export{}
+	assert.NoError(t, json.Unmarshal(j.Bytes(), &decoded))
+	assert.Equal(t, e.Folders[0].Bookmarks[0].URL, decoded.Folders[0].Bookmarks[0].URL)
+
+	var md bytes.Buffer
+	assert.NoError(t, e.WriteMarkdown(&md))
+	s := md.String()
+	assert.True(t, strings.HasPrefix(s, "# Bookmarks\n"))
+	assert.Contains(t, s, "\n## Go\n\n- @alice: first bookmark ([link](https://x.com/alice/status/1))\n- 999 ([link](https://x.com/i/web/status/999))\n")
+	assert.Contains(t, s, "\n## Unfiled\n\n- second bookmark ([link](https://x.com/i/web/status/2))\n- third bookmark ([link](https://x.com/i/web/status/3))\n")
+}
+
+func Test_Export_WriteMarkdown_Escape(t *testing.T) {
+	e := &bookmark.Export{
+		Folders: []bookmark.ExportFolder{{
+			Name: "[go]",
+			Bookmarks: []bookmark.ExportBookmark{{
+				TweetID: "1",
+				URL:     "https://x.com/a_b/status/1",
+				Tweet:   &resources.Tweet{Text: gotwi.String("#gotwi is *great* `code` [x](y) \\o/")},
+				Author:  &resources.User{Username: gotwi.String("a_b")},
+			}},
+		}},
+	}
+
+	var md bytes.Buffer
+	assert.NoError(t, e.WriteMarkdown(&md))
+	assert.Contains(t, md.String(), "\n## \\[go\\]\n\n- @a\\_b: \\#gotwi is \\*great\\* \\`code\\` \\[x\\](y) \\\\o/ ([link](https://x.com/a_b/status/1))\n")
+}
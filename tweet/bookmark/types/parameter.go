@@ -172,3 +172,109 @@ func (p *DeleteInput) Body() (io.Reader, error) {
 func (p *DeleteInput) ParameterMap() map[string]string {
 	return map[string]string{}
 }
+
+type ListFoldersMaxResults int
+
+func (m ListFoldersMaxResults) Valid() bool {
+	return m >= 1 && m <= 100
+}
+
+func (m ListFoldersMaxResults) String() string {
+	return strconv.Itoa(int(m))
+}
+
+type ListFoldersInput struct {
+	accessToken string
+
+	// Path parameter
+	ID string // The authenticated user ID
+
+	// Query parameters
+	MaxResults      ListFoldersMaxResults
+	PaginationToken string
+}
+
+var listFoldersQueryParameters = map[string]struct{}{
+	"max_results":      {},
+	"pagination_token": {},
+}
+
+func (p *ListFoldersInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *ListFoldersInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *ListFoldersInput) ResolveEndpoint(endpointBase string) string {
+	if p.ID == "" {
+		return ""
+	}
+
+	encoded := url.QueryEscape(p.ID)
+	endpoint := strings.Replace(endpointBase, ":id", encoded, 1)
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, listFoldersQueryParameters)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func (p *ListFoldersInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *ListFoldersInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+
+	if p.MaxResults.Valid() {
+		m["max_results"] = p.MaxResults.String()
+	}
+
+	if p.PaginationToken != "" {
+		m["pagination_token"] = p.PaginationToken
+	}
+
+	return m
+}
+
+type ListByFolderInput struct {
+	accessToken string
+
+	// Path parameters
+	ID       string // The authenticated user ID
+	FolderID string
+}
+
+func (p *ListByFolderInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *ListByFolderInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *ListByFolderInput) ResolveEndpoint(endpointBase string) string {
+	if p.ID == "" || p.FolderID == "" {
+		return ""
+	}
+
+	escapedID := url.QueryEscape(p.ID)
+	endpoint := strings.Replace(endpointBase, ":id", escapedID, 1)
+	escapedFolderID := url.QueryEscape(p.FolderID)
+	endpoint = strings.Replace(endpoint, ":folder_id", escapedFolderID, 1)
+
+	return endpoint
+}
+
+func (p *ListByFolderInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *ListByFolderInput) ParameterMap() map[string]string {
+	return map[string]string{}
+}
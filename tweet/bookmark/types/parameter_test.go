@@ -426,3 +426,72 @@ func Test_DeleteInput_ParameterMap(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListFoldersInput_ResolveEndpoint(t *testing.T) {
+	const endpointBase = "test/endpoint/:id/folders"
+	cases := []struct {
+		name   string
+		params *types.ListFoldersInput
+		expect string
+	}{
+		{
+			name:   "only required parameter",
+			params: &types.ListFoldersInput{ID: "test-id"},
+			expect: "test/endpoint/test-id/folders",
+		},
+		{
+			name:   "all query parameters",
+			params: &types.ListFoldersInput{ID: "test-id", MaxResults: 50, PaginationToken: "p-token"},
+			expect: "test/endpoint/test-id/folders?max_results=50&pagination_token=p-token",
+		},
+		{
+			name:   "with invalid max_results",
+			params: &types.ListFoldersInput{ID: "test-id", MaxResults: 101},
+			expect: "test/endpoint/test-id/folders",
+		},
+		{
+			name:   "has no required parameter",
+			params: &types.ListFoldersInput{MaxResults: 50},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			ep := c.params.ResolveEndpoint(endpointBase)
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
+
+func Test_ListByFolderInput_ResolveEndpoint(t *testing.T) {
+	const endpointBase = "test/endpoint/:id/folders/:folder_id"
+	cases := []struct {
+		name   string
+		params *types.ListByFolderInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.ListByFolderInput{ID: "test-id", FolderID: "folder-id"},
+			expect: "test/endpoint/test-id/folders/folder-id",
+		},
+		{
+			name:   "has no folder id",
+			params: &types.ListByFolderInput{ID: "test-id"},
+			expect: "",
+		},
+		{
+			name:   "has no user id",
+			params: &types.ListByFolderInput{FolderID: "folder-id"},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			ep := c.params.ResolveEndpoint(endpointBase)
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
@@ -6,6 +6,7 @@ type ListOutput struct {
 	Data     []resources.Tweet `json:"data"`
 	Meta     resources.PaginationMeta
 	Includes struct {
+		Users  []resources.User  `json:"users,omitempty"`
 		Tweets []resources.Tweet `json:"tweets,omitempty"`
 		Places []resources.Place `json:"places,omitempty"`
 		Media  []resources.Media `json:"media,omitempty"`
@@ -37,3 +38,24 @@ type DeleteOutput struct {
 func (r *DeleteOutput) HasPartialError() bool {
 	return false
 }
+
+type ListFoldersOutput struct {
+	Data   []resources.BookmarkFolder `json:"data"`
+	Meta   resources.PaginationMeta   `json:"meta"`
+	Errors []resources.PartialError   `json:"errors,omitempty"`
+}
+
+func (r *ListFoldersOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+type ListByFolderOutput struct {
+	// Only the IDs of the Tweets are returned.
+	Data   []resources.Tweet        `json:"data"`
+	Meta   resources.PaginationMeta `json:"meta"`
+	Errors []resources.PartialError `json:"errors,omitempty"`
+}
+
+func (r *ListByFolderOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
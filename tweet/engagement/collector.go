@@ -0,0 +1,493 @@
+package engagement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/tweetlookup"
+	"github.com/michimani/gotwi/tweet/tweetlookup/types"
+)
+
+const (
+	DefaultCollectInterval = time.Duration(15) * time.Minute
+
+	// NonPublicMetricsWindow is the period after posting during which the non-public metrics of a Tweet are available.
+	NonPublicMetricsWindow = time.Duration(30*24) * time.Hour
+
+	// twitterEpoch is the epoch of Tweet IDs (Snowflake IDs) in milliseconds.
+	twitterEpoch = 1288834974657
+
+	// problemNotAuthorizedForField is the suffix of the type of the partial error for a field that cannot be read,
+	// e.g. the non-public metrics of a Tweet of another user.
+	problemNotAuthorizedForField = "/problems/not-authorized-for-field"
+)
+
+// Metrics is the engagement counts of a Tweet.
+// Impressions, URLLinkClicks and UserProfileClicks are the non-public metrics,
+// which are zero when they are not collected.
+type Metrics struct {
+	Likes             int
+	Retweets          int
+	Replies           int
+	Quotes            int
+	Impressions       int
+	URLLinkClicks     int
+	UserProfileClicks int
+}
+
+// Velocity is the change of each metric per hour.
+type Velocity struct {
+	Likes             float64
+	Retweets          float64
+	Replies           float64
+	Quotes            float64
+	Impressions       float64
+	URLLinkClicks     float64
+	UserProfileClicks float64
+}
+
+// Snapshot is the metrics of a Tweet at a point in time.
+type Snapshot struct {
+	TweetID     string
+	CollectedAt time.Time
+	Metrics     Metrics
+	// NonPublic is true if the non-public metrics were collected.
+	NonPublic bool
+}
+
+// Delta is the change of the metrics of a Tweet between two consecutive snapshots.
+type Delta struct {
+	TweetID  string
+	From     time.Time
+	To       time.Time
+	Change   Metrics
+	Velocity Velocity
+	// NonPublic is true if the non-public metrics were collected in both snapshots.
+	// Otherwise, the change of the non-public metrics is zero.
+	NonPublic bool
+}
+
+// NewCollectorInput is struct for creating a Collector.
+type NewCollectorInput struct {
+	Client gotwi.IClient // required
+
+	// IDs of the Tweets to collect. More can be added later with Add.
+	TweetIDs []string
+
+	// Interval of collecting in Run. Default is DefaultCollectInterval.
+	Interval time.Duration
+
+	// NonPublicMetrics enables collecting the non-public metrics. It requires OAuth 1.0a User context,
+	// and the metrics are available only for the Tweets of the authenticated user posted within NonPublicMetricsWindow.
+	// Older Tweets are collected with the public metrics only.
+	// Tweets of other users are looked up again with the public metrics only when the non-public metrics are refused,
+	// and are collected with the public metrics only from then on.
+	NonPublicMetrics bool
+
+	// Sink for the snapshots and deltas. Default is MemorySink.
+	Sink Sink
+
+	// Maximum number of concurrent requests. See tweetlookup.BulkList.
+	Parallelism int
+
+	// OnDelta is called by Run with the deltas of each collection that has any.
+	OnDelta func(ctx context.Context, deltas []Delta)
+
+	// OnError is called by Run when a collection fails. If it is nil, the error is ignored.
+	OnError func(ctx context.Context, err error)
+}
+
+// Collector periodically looks up the metrics of a set of Tweets,
+// and computes the change from the previous collection.
+type Collector struct {
+	client      gotwi.IClient
+	interval    time.Duration
+	nonPublic   bool
+	sink        Sink
+	parallelism int
+	onDelta     func(ctx context.Context, deltas []Delta)
+	onError     func(ctx context.Context, err error)
+
+	// collectMu serializes Collect. mu is not held during the lookups and the writing to the sink,
+	// so Add, Remove and TweetIDs are not blocked by Collect.
+	collectMu sync.Mutex
+
+	mu       sync.Mutex
+	tweetIDs []string
+	previous map[string]Snapshot
+	// notOwned is the set of the Tweets whose non-public metrics were refused.
+	notOwned map[string]struct{}
+	now      func() time.Time
+}
+
+func NewCollector(in *NewCollectorInput) (*Collector, error) {
+	if in == nil {
+		return nil, errors.New("NewCollectorInput is nil")
+	}
+	if in.Client == nil {
+		return nil, errors.New("Client is required")
+	}
+	if in.NonPublicMetrics && in.Client.AuthenticationMethod() != gotwi.AuthenMethodOAuth1UserContext {
+		return nil, fmt.Errorf("non-public metrics require %s", gotwi.AuthenMethodOAuth1UserContext)
+	}
+
+	c := &Collector{
+		client:      in.Client,
+		interval:    in.Interval,
+		nonPublic:   in.NonPublicMetrics,
+		sink:        in.Sink,
+		parallelism: in.Parallelism,
+		onDelta:     in.OnDelta,
+		onError:     in.OnError,
+		previous:    map[string]Snapshot{},
+		notOwned:    map[string]struct{}{},
+		now:         time.Now,
+	}
+
+	if c.interval <= 0 {
+		c.interval = DefaultCollectInterval
+	}
+	if c.sink == nil {
+		c.sink = NewMemorySink()
+	}
+	c.Add(in.TweetIDs...)
+
+	return c, nil
+}
+
+// Add starts collecting the metrics of the Tweets. IDs that are already collected are ignored.
+func (c *Collector) Add(tweetIDs ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	seen := make(map[string]struct{}, len(c.tweetIDs))
+	for _, id := range c.tweetIDs {
+		seen[id] = struct{}{}
+	}
+	for _, id := range tweetIDs {
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		c.tweetIDs = append(c.tweetIDs, id)
+	}
+}
+
+// Remove stops collecting the metrics of the Tweets, e.g. Tweets that have been deleted.
+func (c *Collector) Remove(tweetIDs ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	remove := make(map[string]struct{}, len(tweetIDs))
+	for _, id := range tweetIDs {
+		remove[id] = struct{}{}
+		delete(c.previous, id)
+		delete(c.notOwned, id)
+	}
+
+	ids := []string{}
+	for _, id := range c.tweetIDs {
+		if _, ok := remove[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	c.tweetIDs = ids
+}
+
+// TweetIDs returns the IDs of the Tweets being collected.
+func (c *Collector) TweetIDs() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string{}, c.tweetIDs...)
+}
+
+// Collect looks up the metrics of all Tweets once, writes the snapshots and the deltas to the sink,
+// and returns the deltas. Tweets that cannot be looked up (e.g. deleted) are skipped,
+// and the errors are returned together with the deltas of the others.
+// Each Tweet has at most one snapshot for each collection time.
+func (c *Collector) Collect(ctx context.Context) ([]Delta, error) {
+	c.collectMu.Lock()
+	defer c.collectMu.Unlock()
+
+	c.mu.Lock()
+	now := c.now()
+	withNonPublic, publicOnly := c.split(now)
+	c.mu.Unlock()
+
+	var errs []error
+	tweets := []resources.Tweet{}
+
+	// A Tweet whose non-public metrics are refused (e.g. a Tweet of another user) is returned as an error
+	// of not-authorized-for-field, so it is looked up again with the public metrics only.
+	refused := map[string]struct{}{}
+	if len(withNonPublic) > 0 {
+		res, err := tweetlookup.BulkList(ctx, c.client, &types.ListInput{
+			IDs:         withNonPublic,
+			TweetFields: fields.TweetFieldList{fields.TweetFieldPublicMetrics, fields.TweetFieldNonPublicMetrics},
+		}, c.parallelism)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			for _, id := range withNonPublic {
+				if pe, ok := res.Errors[id]; ok && isNotAuthorizedForField(pe) {
+					refused[id] = struct{}{}
+					publicOnly = append(publicOnly, id)
+				}
+			}
+			for id, pe := range res.Errors {
+				if _, ok := refused[id]; !ok {
+					errs = append(errs, fmt.Errorf("tweet %s: %s", id, partialErrorMessage(pe)))
+				}
+			}
+			for _, t := range res.Data {
+				if t.ID == nil {
+					continue
+				}
+				if _, ok := refused[*t.ID]; !ok {
+					tweets = append(tweets, t)
+				}
+			}
+		}
+	}
+
+	notOwned := []string{}
+	if len(publicOnly) > 0 {
+		res, err := tweetlookup.BulkList(ctx, c.client, &types.ListInput{
+			IDs:         publicOnly,
+			TweetFields: fields.TweetFieldList{fields.TweetFieldPublicMetrics},
+		}, c.parallelism)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			tweets = append(tweets, res.Data...)
+			for _, t := range res.Data {
+				if t.ID == nil {
+					continue
+				}
+				if _, ok := refused[*t.ID]; ok {
+					notOwned = append(notOwned, *t.ID)
+				}
+			}
+			for id, pe := range res.Errors {
+				errs = append(errs, fmt.Errorf("tweet %s: %s", id, partialErrorMessage(pe)))
+			}
+		}
+	}
+
+	// Tweets removed during the collection are skipped.
+	c.mu.Lock()
+	collecting := c.tweetIDSet()
+	for _, id := range notOwned {
+		if _, ok := collecting[id]; ok {
+			c.notOwned[id] = struct{}{}
+		}
+	}
+	snapshots, deltas := c.snapshots(now, tweets, collecting)
+	c.mu.Unlock()
+
+	if len(snapshots) > 0 {
+		if err := c.sink.Write(ctx, snapshots, deltas); err != nil {
+			errs = append(errs, fmt.Errorf("failed to write to the sink: %w", err))
+			return deltas, errors.Join(errs...)
+		}
+	}
+
+	c.mu.Lock()
+	collecting = c.tweetIDSet()
+	for _, s := range snapshots {
+		if _, ok := collecting[s.TweetID]; ok {
+			c.previous[s.TweetID] = s
+		}
+	}
+	c.mu.Unlock()
+
+	return deltas, errors.Join(errs...)
+}
+
+// snapshots returns the snapshots of the Tweets in collecting, and the deltas from their previous snapshots.
+// A Tweet that already has a snapshot at the time is skipped. It must be called with mu held.
+func (c *Collector) snapshots(now time.Time, tweets []resources.Tweet, collecting map[string]struct{}) ([]Snapshot, []Delta) {
+	snapshots := make([]Snapshot, 0, len(tweets))
+	deltas := []Delta{}
+	seen := make(map[string]struct{}, len(tweets))
+	for _, t := range tweets {
+		if t.ID == nil {
+			continue
+		}
+		if _, ok := collecting[*t.ID]; !ok {
+			continue
+		}
+		if _, ok := seen[*t.ID]; ok {
+			continue
+		}
+		seen[*t.ID] = struct{}{}
+
+		prev, ok := c.previous[*t.ID]
+		if ok && !prev.CollectedAt.Before(now) {
+			continue
+		}
+
+		s := newSnapshot(*t.ID, now, t)
+		snapshots = append(snapshots, s)
+		if ok {
+			deltas = append(deltas, Compare(prev, s))
+		}
+	}
+
+	return snapshots, deltas
+}
+
+// tweetIDSet returns the set of the Tweets being collected. It must be called with mu held.
+func (c *Collector) tweetIDSet() map[string]struct{} {
+	set := make(map[string]struct{}, len(c.tweetIDs))
+	for _, id := range c.tweetIDs {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+// Run collects on the interval until ctx is done.
+func (c *Collector) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		deltas, err := c.Collect(ctx)
+		if err != nil && ctx.Err() == nil && c.onError != nil {
+			c.onError(ctx, err)
+		}
+		if len(deltas) > 0 && c.onDelta != nil {
+			c.onDelta(ctx, deltas)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// split splits the Tweets into those whose non-public metrics may be available and the others.
+func (c *Collector) split(now time.Time) (withNonPublic, publicOnly []string) {
+	if !c.nonPublic {
+		return nil, append([]string{}, c.tweetIDs...)
+	}
+
+	for _, id := range c.tweetIDs {
+		_, notOwned := c.notOwned[id]
+		postedAt, ok := PostedAt(id)
+		if ok && !notOwned && now.Sub(postedAt) < NonPublicMetricsWindow {
+			withNonPublic = append(withNonPublic, id)
+			continue
+		}
+		publicOnly = append(publicOnly, id)
+	}
+
+	return withNonPublic, publicOnly
+}
+
+// PostedAt returns the time when the Tweet was posted, which is encoded in its ID.
+// It returns false if the ID is not a Snowflake ID.
+func PostedAt(tweetID string) (time.Time, bool) {
+	id, err := strconv.ParseUint(tweetID, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	ms := id >> 22
+	if ms == 0 {
+		return time.Time{}, false
+	}
+
+	return time.UnixMilli(int64(ms) + twitterEpoch), true
+}
+
+// Compare returns the change from previous to current, which are snapshots of the same Tweet.
+func Compare(previous, current Snapshot) Delta {
+	d := Delta{
+		TweetID:   current.TweetID,
+		From:      previous.CollectedAt,
+		To:        current.CollectedAt,
+		NonPublic: previous.NonPublic && current.NonPublic,
+		Change: Metrics{
+			Likes:    current.Metrics.Likes - previous.Metrics.Likes,
+			Retweets: current.Metrics.Retweets - previous.Metrics.Retweets,
+			Replies:  current.Metrics.Replies - previous.Metrics.Replies,
+			Quotes:   current.Metrics.Quotes - previous.Metrics.Quotes,
+		},
+	}
+	if d.NonPublic {
+		d.Change.Impressions = current.Metrics.Impressions - previous.Metrics.Impressions
+		d.Change.URLLinkClicks = current.Metrics.URLLinkClicks - previous.Metrics.URLLinkClicks
+		d.Change.UserProfileClicks = current.Metrics.UserProfileClicks - previous.Metrics.UserProfileClicks
+	}
+
+	hours := d.To.Sub(d.From).Hours()
+	if hours > 0 {
+		d.Velocity = Velocity{
+			Likes:             float64(d.Change.Likes) / hours,
+			Retweets:          float64(d.Change.Retweets) / hours,
+			Replies:           float64(d.Change.Replies) / hours,
+			Quotes:            float64(d.Change.Quotes) / hours,
+			Impressions:       float64(d.Change.Impressions) / hours,
+			URLLinkClicks:     float64(d.Change.URLLinkClicks) / hours,
+			UserProfileClicks: float64(d.Change.UserProfileClicks) / hours,
+		}
+	}
+
+	return d
+}
+
+func newSnapshot(tweetID string, collectedAt time.Time, t resources.Tweet) Snapshot {
+	s := Snapshot{
+		TweetID:     tweetID,
+		CollectedAt: collectedAt,
+	}
+
+	if m := t.PublicMetrics; m != nil {
+		s.Metrics.Likes = intValue(m.LikeCount)
+		s.Metrics.Retweets = intValue(m.RetweetCount)
+		s.Metrics.Replies = intValue(m.ReplyCount)
+		s.Metrics.Quotes = intValue(m.QuoteCount)
+	}
+
+	if m := t.NonPublicMetrics; m != nil {
+		s.NonPublic = true
+		s.Metrics.Impressions = intValue(m.ImpressionCount)
+		s.Metrics.URLLinkClicks = intValue(m.UrlLinkClicks)
+		s.Metrics.UserProfileClicks = intValue(m.UserProfileClicks)
+	}
+
+	return s
+}
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+func isNotAuthorizedForField(e resources.PartialError) bool {
+	return strings.HasSuffix(gotwi.StringValue(e.Type), problemNotAuthorizedForField)
+}
+
+func partialErrorMessage(e resources.PartialError) string {
+	if e.Detail != nil {
+		return *e.Detail
+	}
+	if e.Title != nil {
+		return *e.Title
+	}
+	return "unknown error"
+}
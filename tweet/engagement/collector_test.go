@@ -0,0 +1,315 @@
+package engagement_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/engagement"
+	"github.com/michimani/gotwi/tweet/tweetlookup/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// tweetIDAt returns a Snowflake ID of a Tweet posted at t.
+func tweetIDAt(t time.Time) string {
+	return strconv.FormatUint(uint64(t.UnixMilli()-1288834974657)<<22, 10)
+}
+
+func Test_NewCollector(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      *engagement.NewCollectorInput
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			in:   &engagement.NewCollectorInput{Client: &gotwi.Client{}, TweetIDs: []string{"1", "1", "2"}},
+		},
+		{
+			name: "ok: non-public metrics with user context",
+			in: &engagement.NewCollectorInput{
+				Client: gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+					MockAuthenticationMethod: func() gotwi.AuthenticationMethod { return gotwi.AuthenMethodOAuth1UserContext },
+				}),
+				NonPublicMetrics: true,
+			},
+		},
+		{
+			name: "ng: non-public metrics with bearer token",
+			in: &engagement.NewCollectorInput{
+				Client: gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+					MockAuthenticationMethod: func() gotwi.AuthenticationMethod { return gotwi.AuthenMethodOAuth2BearerToken },
+				}),
+				NonPublicMetrics: true,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "ng: client is nil",
+			in:      &engagement.NewCollectorInput{},
+			wantErr: true,
+		},
+		{
+			name:    "ng: input is nil",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			col, err := engagement.NewCollector(c.in)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, col)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, col)
+		})
+	}
+}
+
+func Test_Collector_AddRemove(t *testing.T) {
+	col, err := engagement.NewCollector(&engagement.NewCollectorInput{Client: &gotwi.Client{}, TweetIDs: []string{"1", "2"}})
+	assert.NoError(t, err)
+
+	col.Add("2", "3", "")
+	assert.Equal(t, []string{"1", "2", "3"}, col.TweetIDs())
+
+	col.Remove("1", "3")
+	assert.Equal(t, []string{"2"}, col.TweetIDs())
+}
+
+func Test_Collector_Collect(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	recentID := tweetIDAt(now.Add(-24 * time.Hour))
+	oldID := tweetIDAt(now.Add(-60 * 24 * time.Hour))
+
+	likes := 10
+	calls := []*types.ListInput{}
+	mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+		MockAuthenticationMethod: func() gotwi.AuthenticationMethod { return gotwi.AuthenMethodOAuth1UserContext },
+		MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+			in := p.(*types.ListInput)
+			calls = append(calls, in)
+			out := i.(*types.ListOutput)
+			for _, id := range in.IDs {
+				if id == "404" {
+					out.Errors = append(out.Errors, resources.PartialError{Value: gotwi.String(id), Detail: gotwi.String("Could not find tweet")})
+					continue
+				}
+				tw := resources.Tweet{
+					ID:            gotwi.String(id),
+					PublicMetrics: &resources.TweetPublicMetrics{LikeCount: gotwi.Int(likes), RetweetCount: gotwi.Int(2)},
+				}
+				if len(in.TweetFields) == 2 {
+					tw.NonPublicMetrics = &resources.NonPublicMetrics{ImpressionCount: gotwi.Int(likes * 100)}
+				}
+				out.Data = append(out.Data, tw)
+			}
+			return nil
+		},
+	})
+
+	sink := engagement.NewMemorySink()
+	col, err := engagement.NewCollector(&engagement.NewCollectorInput{
+		Client:           mockClient,
+		TweetIDs:         []string{recentID, oldID, "404"},
+		NonPublicMetrics: true,
+		Sink:             sink,
+	})
+	assert.NoError(t, err)
+	engagement.ExportSetNow(col, func() time.Time { return now })
+
+	// first collection: snapshots only
+	deltas, err := col.Collect(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "tweet 404: Could not find tweet")
+	assert.Empty(t, deltas)
+	if assert.Len(t, calls, 2) {
+		assert.Equal(t, []string{recentID}, calls[0].IDs)
+		assert.Equal(t, []string{oldID, "404"}, calls[1].IDs)
+	}
+	assert.Len(t, sink.Snapshots(recentID), 1)
+	assert.True(t, sink.Snapshots(recentID)[0].NonPublic)
+	assert.False(t, sink.Snapshots(oldID)[0].NonPublic)
+
+	// second collection, 30 minutes later
+	col.Remove("404")
+	likes = 16
+	now = now.Add(30 * time.Minute)
+	deltas, err = col.Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, deltas, 2)
+	assert.Equal(t, sink.Deltas(recentID), deltas[:1])
+
+	d := deltas[0]
+	assert.Equal(t, recentID, d.TweetID)
+	assert.Equal(t, 30*time.Minute, d.To.Sub(d.From))
+	assert.True(t, d.NonPublic)
+	assert.Equal(t, engagement.Metrics{Likes: 6, Impressions: 600}, d.Change)
+	assert.Equal(t, 12.0, d.Velocity.Likes)
+	assert.Equal(t, 1200.0, d.Velocity.Impressions)
+
+	assert.Equal(t, oldID, deltas[1].TweetID)
+	assert.False(t, deltas[1].NonPublic)
+	assert.Equal(t, engagement.Metrics{Likes: 6}, deltas[1].Change)
+}
+
+func Test_Collector_Collect_OtherUsersTweet(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	ownID := tweetIDAt(now.Add(-24 * time.Hour))
+	otherID := tweetIDAt(now.Add(-23 * time.Hour))
+	goneID := tweetIDAt(now.Add(-22 * time.Hour))
+
+	calls := []*types.ListInput{}
+	mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+		MockAuthenticationMethod: func() gotwi.AuthenticationMethod { return gotwi.AuthenMethodOAuth1UserContext },
+		MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+			in := p.(*types.ListInput)
+			calls = append(calls, in)
+			out := i.(*types.ListOutput)
+			for _, id := range in.IDs {
+				tw := resources.Tweet{
+					ID:            gotwi.String(id),
+					PublicMetrics: &resources.TweetPublicMetrics{LikeCount: gotwi.Int(5)},
+				}
+				if len(in.TweetFields) == 2 {
+					if id == otherID {
+						// the Tweet is returned without the refused field
+						out.Data = append(out.Data, tw)
+						out.Errors = append(out.Errors, resources.PartialError{
+							ResourceID: gotwi.String(id),
+							Title:      gotwi.String("Field Authorization Error"),
+							Type:       gotwi.String("https://api.twitter.com/2/problems/not-authorized-for-field"),
+						})
+						continue
+					}
+					if id == goneID {
+						out.Errors = append(out.Errors, resources.PartialError{
+							ResourceID: gotwi.String(id),
+							Title:      gotwi.String("Not Found Error"),
+							Type:       gotwi.String("https://api.twitter.com/2/problems/resource-not-found"),
+						})
+						continue
+					}
+					tw.NonPublicMetrics = &resources.NonPublicMetrics{ImpressionCount: gotwi.Int(100)}
+				}
+				out.Data = append(out.Data, tw)
+			}
+			return nil
+		},
+	})
+
+	sink := engagement.NewMemorySink()
+	col, err := engagement.NewCollector(&engagement.NewCollectorInput{
+		Client:           mockClient,
+		TweetIDs:         []string{ownID, otherID, goneID},
+		NonPublicMetrics: true,
+		Sink:             sink,
+	})
+	assert.NoError(t, err)
+	engagement.ExportSetNow(col, func() time.Time { return now })
+
+	// the Tweet of the other user is looked up again with the public metrics only,
+	// and the other errors are reported
+	_, err = col.Collect(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "tweet "+goneID+": Not Found Error")
+	assert.NotContains(t, err.Error(), otherID)
+	if assert.Len(t, calls, 2) {
+		assert.Equal(t, []string{ownID, otherID, goneID}, calls[0].IDs)
+		assert.Equal(t, []string{otherID}, calls[1].IDs)
+		assert.Len(t, calls[1].TweetFields, 1)
+	}
+	if assert.Len(t, sink.Snapshots(otherID), 1) {
+		assert.False(t, sink.Snapshots(otherID)[0].NonPublic)
+		assert.Equal(t, 5, sink.Snapshots(otherID)[0].Metrics.Likes)
+	}
+	assert.True(t, sink.Snapshots(ownID)[0].NonPublic)
+
+	// and then it is collected with the public metrics only from the start
+	col.Remove(goneID)
+	calls = nil
+	now = now.Add(time.Minute)
+	_, err = col.Collect(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, calls, 2) {
+		assert.Equal(t, []string{ownID}, calls[0].IDs)
+		assert.Equal(t, []string{otherID}, calls[1].IDs)
+	}
+
+	// no snapshot is added for the same time
+	_, err = col.Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, sink.Snapshots(otherID), 2)
+	assert.Len(t, sink.Snapshots(ownID), 2)
+}
+
+func Test_Collector_Collect_SinkError(t *testing.T) {
+	mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+		MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+			out := i.(*types.ListOutput)
+			out.Data = []resources.Tweet{{ID: gotwi.String("1"), PublicMetrics: &resources.TweetPublicMetrics{}}}
+			return nil
+		},
+	})
+
+	col, err := engagement.NewCollector(&engagement.NewCollectorInput{
+		Client:   mockClient,
+		TweetIDs: []string{"1"},
+		Sink:     errorSink{},
+	})
+	assert.NoError(t, err)
+
+	_, err = col.Collect(context.Background())
+	assert.ErrorIs(t, err, errSink)
+}
+
+var errSink = errors.New("sink error")
+
+type errorSink struct{}
+
+func (errorSink) Write(ctx context.Context, snapshots []engagement.Snapshot, deltas []engagement.Delta) error {
+	return errSink
+}
+
+func Test_PostedAt(t *testing.T) {
+	cases := []struct {
+		name   string
+		id     string
+		expect time.Time
+		ok     bool
+	}{
+		{
+			name:   "ok",
+			id:     "1460323737035677698",
+			expect: time.Date(2021, 11, 15, 19, 8, 5, 69*int(time.Millisecond), time.UTC),
+			ok:     true,
+		},
+		{
+			name: "ng: not a snowflake id",
+			id:   "20",
+		},
+		{
+			name: "ng: not a number",
+			id:   "abc",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			got, ok := engagement.PostedAt(c.id)
+			assert.Equal(tt, c.ok, ok)
+			if c.ok {
+				assert.True(tt, c.expect.Equal(got), got.UTC().String())
+			}
+		})
+	}
+}
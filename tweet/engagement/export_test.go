@@ -0,0 +1,7 @@
+package engagement
+
+import "time"
+
+func ExportSetNow(c *Collector, now func() time.Time) {
+	c.now = now
+}
@@ -0,0 +1,73 @@
+package engagement
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// Sink stores the snapshots and deltas of each collection.
+// Implementations must be safe for concurrent use.
+type Sink interface {
+	// Write stores the snapshots of one collection and the deltas from the previous collection.
+	// deltas is empty on the first collection of a Tweet.
+	Write(ctx context.Context, snapshots []Snapshot, deltas []Delta) error
+}
+
+// MemorySink is a Sink that keeps all snapshots and deltas in memory.
+type MemorySink struct {
+	mu        sync.Mutex
+	snapshots map[string][]Snapshot
+	deltas    map[string][]Delta
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{
+		snapshots: map[string][]Snapshot{},
+		deltas:    map[string][]Delta{},
+	}
+}
+
+func (s *MemorySink) Write(ctx context.Context, snapshots []Snapshot, deltas []Delta) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sn := range snapshots {
+		s.snapshots[sn.TweetID] = append(s.snapshots[sn.TweetID], sn)
+	}
+	for _, d := range deltas {
+		s.deltas[d.TweetID] = append(s.deltas[d.TweetID], d)
+	}
+
+	return nil
+}
+
+// Snapshots returns the snapshots of the Tweet in the order of collection.
+func (s *MemorySink) Snapshots(tweetID string) []Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Snapshot{}, s.snapshots[tweetID]...)
+}
+
+// Deltas returns the deltas of the Tweet in the order of collection.
+func (s *MemorySink) Deltas(tweetID string) []Delta {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Delta{}, s.deltas[tweetID]...)
+}
+
+// TweetIDs returns the IDs of the Tweets that have any snapshot, in ascending order.
+func (s *MemorySink) TweetIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.snapshots))
+	for id := range s.snapshots {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
@@ -2,9 +2,10 @@ package filteredstream
 
 import (
 	"context"
-	"errors"
 
 	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/connection"
+	connectiontypes "github.com/michimani/gotwi/connection/types"
 	"github.com/michimani/gotwi/tweet/filteredstream/types"
 )
 
@@ -16,47 +17,20 @@ const (
 
 // Return a list of rules currently active on the streaming endpoint, either as a list or individually.
 // https://developer.twitter.com/en/docs/twitter-api/tweets/filtered-stream/api-reference/get-tweets-search-stream-rules
-func ListRules(ctx context.Context, c gotwi.IClient, p *types.ListRulesInput) (*types.ListRulesOutput, error) {
-	if p == nil {
-		return nil, errors.New("params is required")
-	}
-
-	res := &types.ListRulesOutput{}
-	if err := c.CallAPI(ctx, listRulesEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func ListRules(ctx context.Context, c gotwi.IClient, p *types.ListRulesInput, opts ...gotwi.CallOption) (*types.ListRulesOutput, error) {
+	return gotwi.Do[*types.ListRulesOutput](ctx, c, "GET", listRulesEndpoint, p, opts...)
 }
 
 // Add rules to your stream.
 // https://developer.twitter.com/en/docs/twitter-api/tweets/filtered-stream/api-reference/post-tweets-search-stream-rules
-func CreateRules(ctx context.Context, c gotwi.IClient, p *types.CreateRulesInput) (*types.CreateRulesOutput, error) {
-	if p == nil {
-		return nil, errors.New("params is required")
-	}
-
-	res := &types.CreateRulesOutput{}
-	if err := c.CallAPI(ctx, createOrDeleteRulesEndpoint, "POST", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func CreateRules(ctx context.Context, c gotwi.IClient, p *types.CreateRulesInput, opts ...gotwi.CallOption) (*types.CreateRulesOutput, error) {
+	return gotwi.Do[*types.CreateRulesOutput](ctx, c, "POST", createOrDeleteRulesEndpoint, p, opts...)
 }
 
 // Delete rules to your stream.
 // https://developer.twitter.com/en/docs/twitter-api/tweets/filtered-stream/api-reference/post-tweets-search-stream-rules
-func DeleteRules(ctx context.Context, c gotwi.IClient, p *types.DeleteRulesInput) (*types.DeleteRulesOutput, error) {
-	if p == nil {
-		return nil, errors.New("params is required")
-	}
-
-	res := &types.DeleteRulesOutput{}
-	if err := c.CallAPI(ctx, createOrDeleteRulesEndpoint, "POST", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func DeleteRules(ctx context.Context, c gotwi.IClient, p *types.DeleteRulesInput, opts ...gotwi.CallOption) (*types.DeleteRulesOutput, error) {
+	return gotwi.Do[*types.DeleteRulesOutput](ctx, c, "POST", createOrDeleteRulesEndpoint, p, opts...)
 }
 
 // Streams Tweets in real-time that match the rules that you added to the stream using the POST /tweets/search/stream/rules endpoint.
@@ -64,7 +38,15 @@ func DeleteRules(ctx context.Context, c gotwi.IClient, p *types.DeleteRulesInput
 // https://developer.twitter.com/en/docs/twitter-api/tweets/filtered-stream/api-reference/get-tweets-search-stream
 func SearchStream(ctx context.Context, c *gotwi.Client, p *types.SearchStreamInput) (*gotwi.StreamClient[*types.SearchStreamOutput], error) {
 	tc := gotwi.NewTypedClient[*types.SearchStreamOutput](c)
-	s, err := tc.CallStreamAPI(ctx, searchStreamEndpoint, "GET", p)
+	connect := func(ctx context.Context) (*gotwi.StreamClient[*types.SearchStreamOutput], error) {
+		return tc.CallStreamAPI(ctx, searchStreamEndpoint, "GET", p)
+	}
+
+	if p != nil && p.TerminateStaleConnections {
+		return connection.ConnectTerminatingStale(ctx, c, connectiontypes.EndpointIDFilteredStream, connect)
+	}
+
+	s, err := connect(ctx)
 	if err != nil {
 		return nil, err
 	}
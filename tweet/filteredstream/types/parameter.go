@@ -186,6 +186,10 @@ type SearchStreamInput struct {
 	PollFields      fields.PollFieldList
 	TweetFields     fields.TweetFieldList
 	UserFields      fields.UserFieldList
+
+	// TerminateStaleConnections terminates the active connections of the endpoint and reconnects
+	// when the connection fails because the stream is at its connection limit (TooManyConnections).
+	TerminateStaleConnections bool
 }
 
 var searchStreamQueryParameters = map[string]struct{}{
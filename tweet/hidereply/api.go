@@ -2,7 +2,6 @@ package hidereply
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/michimani/gotwi"
 	"github.com/michimani/gotwi/tweet/hidereply/types"
@@ -12,15 +11,6 @@ const updateEndpoint = "https://api.twitter.com/2/tweets/:id/hidden"
 
 // Hides or unhides a reply to a Tweet.
 // https://developer.twitter.com/en/docs/twitter-api/tweets/hide-replies/api-reference/put-tweets-id-hidden
-func Update(ctx context.Context, c gotwi.IClient, p *types.UpdateInput) (*types.UpdateOutput, error) {
-	if p == nil {
-		return nil, fmt.Errorf("parameters is required")
-	}
-
-	res := &types.UpdateOutput{}
-	if err := c.CallAPI(ctx, updateEndpoint, "PUT", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func Update(ctx context.Context, c gotwi.IClient, p *types.UpdateInput, opts ...gotwi.CallOption) (*types.UpdateOutput, error) {
+	return gotwi.Do[*types.UpdateOutput](ctx, c, "PUT", updateEndpoint, p, opts...)
 }
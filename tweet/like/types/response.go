@@ -10,6 +10,7 @@ type ListUsersOutput struct {
 		Media  []resources.Media `json:"media,omitempty"`
 		Polls  []resources.Poll  `json:"polls,omitempty"`
 	} `json:"includes,omitempty"`
+	Meta   resources.PaginationMeta `json:"meta"`
 	Errors []resources.PartialError `json:"errors,omitempty"`
 }
 
@@ -0,0 +1,7 @@
+package publisher
+
+import "time"
+
+func ExportSetNow(p *Publisher, now func() time.Time) {
+	p.now = now
+}
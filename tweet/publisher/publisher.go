@@ -0,0 +1,523 @@
+package publisher
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/posting"
+	"github.com/michimani/gotwi/tweet/managetweet"
+	"github.com/michimani/gotwi/tweet/managetweet/types"
+	"github.com/michimani/gotwi/tweet/timeline"
+	timelinetypes "github.com/michimani/gotwi/tweet/timeline/types"
+	"github.com/michimani/gotwi/user/userlookup"
+	userlookuptypes "github.com/michimani/gotwi/user/userlookup/types"
+)
+
+const (
+	defaultInterval            = 10 * time.Second
+	defaultMaxAttempts         = 3
+	defaultRetryBackoff        = time.Minute
+	defaultPostingLimitBackoff = 15 * time.Minute
+
+	// Tweets created after this duration before the publish time are checked for verifying.
+	verifyClockSkew  = time.Minute
+	verifyMaxResults = 100
+)
+
+// NewPublisherInput is struct for creating a Publisher.
+type NewPublisherInput struct {
+	Client gotwi.IClient // required
+
+	// Storage for the queue. Default is MemoryStorage.
+	Storage Storage
+
+	// Interval of dispatching in Run. Default is 10 seconds.
+	Interval time.Duration
+
+	// Minimum interval between posting Tweets. Default is 0.
+	MinPostInterval time.Duration
+
+	// Maximum number of attempts for each entry. Default is 3.
+	MaxAttempts int
+
+	// Wait time before the next attempt, multiplied by the number of attempts. Default is 1 minute.
+	RetryBackoff time.Duration
+
+	// Wait time after the posting limit is reached and the reset time is unknown. Default is 15 minutes.
+	PostingLimitBackoff time.Duration
+}
+
+// Publisher posts the Tweets in the queue at their publish time.
+type Publisher struct {
+	client              gotwi.IClient
+	storage             Storage
+	interval            time.Duration
+	minPostInterval     time.Duration
+	maxAttempts         int
+	retryBackoff        time.Duration
+	postingLimitBackoff time.Duration
+
+	// mu serializes the changes of the entries, e.g. Cancel and the claim of an entry by Dispatch.
+	mu sync.Mutex
+
+	// dispatchMu serializes Dispatch, and guards the fields below.
+	// mu is not held during the API calls, so the queue can be changed while dispatching.
+	dispatchMu   sync.Mutex
+	pausedUntil  time.Time
+	lastPostedAt time.Time
+	userID       string
+
+	now func() time.Time
+}
+
+func NewPublisher(in *NewPublisherInput) (*Publisher, error) {
+	if in == nil {
+		return nil, errors.New("NewPublisherInput is nil")
+	}
+
+	if in.Client == nil {
+		return nil, errors.New("Client is required")
+	}
+
+	p := &Publisher{
+		client:              in.Client,
+		storage:             in.Storage,
+		interval:            in.Interval,
+		minPostInterval:     in.MinPostInterval,
+		maxAttempts:         in.MaxAttempts,
+		retryBackoff:        in.RetryBackoff,
+		postingLimitBackoff: in.PostingLimitBackoff,
+		now:                 time.Now,
+	}
+
+	if p.storage == nil {
+		p.storage = NewMemoryStorage()
+	}
+	if p.interval <= 0 {
+		p.interval = defaultInterval
+	}
+	if p.maxAttempts <= 0 {
+		p.maxAttempts = defaultMaxAttempts
+	}
+	if p.retryBackoff <= 0 {
+		p.retryBackoff = defaultRetryBackoff
+	}
+	if p.postingLimitBackoff <= 0 {
+		p.postingLimitBackoff = defaultPostingLimitBackoff
+	}
+
+	return p, nil
+}
+
+// ScheduleInput is struct for scheduling a Tweet.
+type ScheduleInput struct {
+	// Unique identifier of the entry. If it is empty, a random ID is generated.
+	// If an entry with the same ID already exists, Schedule returns it without any change.
+	ID string
+
+	Input *types.CreateInput // required
+
+	// Time to publish the Tweet. If it is zero, the Tweet is published at the next dispatching.
+	PublishAt time.Time
+}
+
+// Schedule adds a Tweet to the queue.
+func (p *Publisher) Schedule(ctx context.Context, in *ScheduleInput) (*Entry, error) {
+	if in == nil {
+		return nil, errors.New("ScheduleInput is nil")
+	}
+
+	if in.Input == nil {
+		return nil, errors.New("Input is required")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	id := in.ID
+	if id == "" {
+		generated, err := generateID()
+		if err != nil {
+			return nil, err
+		}
+		id = generated
+	} else {
+		e, err := p.storage.Get(ctx, id)
+		if err == nil {
+			return e, nil
+		}
+		if !errors.Is(err, ErrEntryNotFound) {
+			return nil, err
+		}
+	}
+
+	publishAt := in.PublishAt
+	if publishAt.IsZero() {
+		publishAt = p.now()
+	}
+
+	e := &Entry{
+		ID:            id,
+		Input:         in.Input,
+		PublishAt:     publishAt,
+		NextAttemptAt: publishAt,
+		Status:        StatusPending,
+	}
+	if err := p.storage.Save(ctx, e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Edit updates the parameters and the publish time of a pending entry.
+// Input is not changed if it is nil, and PublishAt is not changed if it is zero.
+func (p *Publisher) Edit(ctx context.Context, in *ScheduleInput) (*Entry, error) {
+	if in == nil {
+		return nil, errors.New("ScheduleInput is nil")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e, err := p.pendingEntry(ctx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Input != nil {
+		e.Input = in.Input
+	}
+	if !in.PublishAt.IsZero() {
+		e.PublishAt = in.PublishAt
+		e.NextAttemptAt = in.PublishAt
+	}
+
+	if err := p.storage.Save(ctx, e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Cancel cancels a pending entry.
+func (p *Publisher) Cancel(ctx context.Context, id string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e, err := p.pendingEntry(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	e.Status = StatusCanceled
+
+	return p.storage.Save(ctx, e)
+}
+
+// Get returns the entry.
+func (p *Publisher) Get(ctx context.Context, id string) (*Entry, error) {
+	return p.storage.Get(ctx, id)
+}
+
+func (p *Publisher) pendingEntry(ctx context.Context, id string) (*Entry, error) {
+	e, err := p.storage.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.Status != StatusPending {
+		return nil, fmt.Errorf("entry '%s' is not pending. status=%s", id, e.Status)
+	}
+
+	return e, nil
+}
+
+// Dispatch posts the entries whose time has come, and returns the processed entries.
+// Dispatching stops when the posting limit is reached, and resumes after the limit is reset.
+//
+// When posting fails ambiguously (e.g. network error, timeout or 5XX status), the Tweet may have been posted.
+// Before sending it again, the recent Tweets of the authenticated user are searched for the same text.
+// Entries left in StatusSending, e.g. by a crash during posting, are verified in the same way at the beginning of Dispatch.
+// An entry without text cannot be verified, so it is sent again.
+// When posting is rejected with a 4XX status other than the posting limit and duplicate content, the entry fails without retrying.
+//
+// The queue is not locked during the API calls, so Schedule, Edit and Cancel are not blocked by Dispatch.
+// An entry being posted is in StatusSending, and cannot be edited or canceled.
+func (p *Publisher) Dispatch(ctx context.Context) ([]*Entry, error) {
+	p.dispatchMu.Lock()
+	defer p.dispatchMu.Unlock()
+
+	processed, err := p.recoverSending(ctx)
+	if err != nil {
+		return processed, err
+	}
+
+	if p.now().Before(p.pausedUntil) {
+		return processed, nil
+	}
+
+	p.mu.Lock()
+	pending, err := p.storage.ListPending(ctx)
+	p.mu.Unlock()
+	if err != nil {
+		return processed, err
+	}
+
+	for _, pe := range pending {
+		if err := ctx.Err(); err != nil {
+			return processed, err
+		}
+
+		now := p.now()
+		if pe.NextAttemptAt.After(now) {
+			continue
+		}
+		if p.minPostInterval > 0 && now.Before(p.lastPostedAt.Add(p.minPostInterval)) {
+			break
+		}
+
+		e, err := p.claim(ctx, pe.ID, now)
+		if err != nil {
+			return processed, err
+		}
+		if e == nil {
+			// edited or canceled after listing
+			continue
+		}
+
+		res, err := managetweet.Create(ctx, p.client, e.Input)
+		limited := p.resolve(ctx, e, res, err)
+		if err := p.save(ctx, e); err != nil {
+			return processed, err
+		}
+
+		processed = append(processed, e)
+		if limited {
+			break
+		}
+	}
+
+	return processed, nil
+}
+
+// claim marks the entry as StatusSending if it is still pending and its time has come, and returns it.
+// It returns nil if the entry is not to be sent now.
+// Once claimed, the entry cannot be edited or canceled, so it is updated by Dispatch without holding mu.
+func (p *Publisher) claim(ctx context.Context, id string, now time.Time) (*Entry, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e, err := p.storage.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.Status != StatusPending || e.NextAttemptAt.After(now) {
+		return nil, nil
+	}
+
+	e.Status = StatusSending
+	e.Attempts++
+	e.LastAttemptAt = now
+	if err := p.storage.Save(ctx, e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (p *Publisher) save(ctx context.Context, e *Entry) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.storage.Save(ctx, e)
+}
+
+// recoverSending verifies the entries left in StatusSending, and returns them.
+// An entry that cannot be verified now stays in StatusSending, and is verified again at the next dispatching.
+func (p *Publisher) recoverSending(ctx context.Context) ([]*Entry, error) {
+	recovered := []*Entry{}
+
+	p.mu.Lock()
+	sending, err := p.storage.ListSending(ctx)
+	p.mu.Unlock()
+	if err != nil {
+		return recovered, err
+	}
+
+	for _, e := range sending {
+		if err := ctx.Err(); err != nil {
+			return recovered, err
+		}
+
+		p.verify(ctx, e)
+		if err := p.save(ctx, e); err != nil {
+			return recovered, err
+		}
+		recovered = append(recovered, e)
+	}
+
+	return recovered, nil
+}
+
+// resolve updates the entry according to the result of posting.
+// It returns true if the posting limit is reached.
+func (p *Publisher) resolve(ctx context.Context, e *Entry, res *types.CreateOutput, err error) bool {
+	now := p.now()
+	p.lastPostedAt = now
+
+	if err == nil {
+		p.markSent(e, gotwi.StringValue(res.Data.ID))
+		return false
+	}
+
+	e.LastError = err.Error()
+
+	var ge *gotwi.GotwiError
+	if errors.As(err, &ge) && ge.OnAPI {
+		if posting.IsDuplicate(ge) {
+			// The duplicate may be the Tweet posted by a previous attempt whose response was lost.
+			if id, ferr := p.findPostedTweet(ctx, e); ferr == nil && id != "" {
+				p.markSent(e, id)
+				return false
+			}
+			e.Status = StatusDuplicate
+			return false
+		}
+
+		if posting.IsPostingLimit(ge) {
+			// An attempt that hits the limit is not counted.
+			e.Status = StatusPending
+			e.Attempts--
+			p.pausedUntil = now.Add(p.postingLimitBackoff)
+			if ge.RateLimitInfo != nil && ge.RateLimitInfo.ResetAt != nil {
+				p.pausedUntil = *ge.RateLimitInfo.ResetAt
+			}
+			return true
+		}
+
+		if posting.IsRejected(ge) {
+			// The request is rejected (e.g. invalid parameters or no permission), so sending it again does not succeed.
+			e.Status = StatusFailed
+			return false
+		}
+	}
+
+	// The Tweet may have been posted. Verify it before sending again.
+	p.verify(ctx, e)
+
+	return false
+}
+
+// verify searches the timeline for the Tweet of the entry, and marks it sent if it is found.
+// If it is not found, the entry is retried. If the timeline cannot be read, the entry stays in StatusSending.
+func (p *Publisher) verify(ctx context.Context, e *Entry) {
+	id, err := p.findPostedTweet(ctx, e)
+	switch {
+	case err != nil:
+		e.Status = StatusSending
+		e.LastError = err.Error()
+	case id != "":
+		p.markSent(e, id)
+	default:
+		p.retryOrFail(e)
+	}
+}
+
+func (p *Publisher) markSent(e *Entry, tweetID string) {
+	e.Status = StatusSent
+	e.TweetID = tweetID
+	e.LastError = ""
+}
+
+func (p *Publisher) retryOrFail(e *Entry) {
+	if e.Attempts >= p.maxAttempts {
+		e.Status = StatusFailed
+		return
+	}
+
+	e.Status = StatusPending
+	e.NextAttemptAt = p.now().Add(p.retryBackoff * time.Duration(e.Attempts))
+}
+
+// findPostedTweet returns the ID of the Tweet of the authenticated user that has the same text as the entry
+// and was created after its publish time. It returns "" if there is no such Tweet, or the entry has no text.
+// The text of each Tweet is normalized by posting.PostedText, e.g. the t.co links are expanded, before comparing.
+func (p *Publisher) findPostedTweet(ctx context.Context, e *Entry) (string, error) {
+	text := gotwi.StringValue(e.Input.Text)
+	if strings.TrimSpace(text) == "" {
+		return "", nil
+	}
+
+	userID, err := p.authenticatedUserID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	startTime := e.PublishAt.Add(-verifyClockSkew)
+	res, err := timeline.ListTweets(ctx, p.client, &timelinetypes.ListTweetsInput{
+		ID:          userID,
+		StartTime:   &startTime,
+		MaxResults:  verifyMaxResults,
+		TweetFields: posting.TweetFields,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, t := range res.Data {
+		if posting.SameText(t, text) {
+			return gotwi.StringValue(t.ID), nil
+		}
+	}
+
+	return "", nil
+}
+
+func (p *Publisher) authenticatedUserID(ctx context.Context) (string, error) {
+	if p.userID != "" {
+		return p.userID, nil
+	}
+
+	res, err := userlookup.GetMe(ctx, p.client, &userlookuptypes.GetMeInput{})
+	if err != nil {
+		return "", err
+	}
+
+	p.userID = gotwi.StringValue(res.Data.ID)
+	return p.userID, nil
+}
+
+// Run dispatches the queue at the interval until the context is done.
+func (p *Publisher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Dispatch(ctx); err != nil && ctx.Err() == nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func generateID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
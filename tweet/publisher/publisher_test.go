@@ -0,0 +1,494 @@
+package publisher_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/managetweet/types"
+	"github.com/michimani/gotwi/tweet/publisher"
+	timelinetypes "github.com/michimani/gotwi/tweet/timeline/types"
+	userlookuptypes "github.com/michimani/gotwi/user/userlookup/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var baseTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// mockAPI posts Tweets, and returns the posted Tweets as the timeline of the authenticated user.
+type mockAPI struct {
+	createErr func(text string) error
+
+	// lost is the set of texts whose Tweets are posted, but whose responses are lost.
+	lost map[string]bool
+
+	timelineErr error
+	posted      []resources.Tweet
+}
+
+func (m *mockAPI) client() *gotwi.MockGotwiClient {
+	return gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+		MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+			switch in := p.(type) {
+			case *types.CreateInput:
+				text := gotwi.StringValue(in.Text)
+				if err := m.createErr(text); err != nil {
+					return err
+				}
+				id := gotwi.String("tweet-" + text)
+				m.posted = append(m.posted, resources.Tweet{ID: id, Text: in.Text})
+				if m.lost[text] {
+					return errors.New("connection reset")
+				}
+				i.(*types.CreateOutput).Data.ID = id
+			case *userlookuptypes.GetMeInput:
+				i.(*userlookuptypes.GetMeOutput).Data.ID = gotwi.String("me")
+			case *timelinetypes.ListTweetsInput:
+				if m.timelineErr != nil {
+					return m.timelineErr
+				}
+				i.(*timelinetypes.ListTweetsOutput).Data = m.posted
+			}
+			return nil
+		},
+	})
+}
+
+func newPublisher(t *testing.T, now *time.Time, createErr func(text string) error) *publisher.Publisher {
+	return newPublisherWithAPI(t, now, &mockAPI{createErr: createErr}, nil)
+}
+
+func newPublisherWithAPI(t *testing.T, now *time.Time, m *mockAPI, s publisher.Storage) *publisher.Publisher {
+	p, err := publisher.NewPublisher(&publisher.NewPublisherInput{Client: m.client(), Storage: s})
+	assert.NoError(t, err)
+	publisher.ExportSetNow(p, func() time.Time { return *now })
+
+	return p
+}
+
+func noError(string) error { return nil }
+
+func Test_NewPublisher(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      *publisher.NewPublisherInput
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			in:   &publisher.NewPublisherInput{Client: gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{})},
+		},
+		{
+			name:    "ng: client is nil",
+			in:      &publisher.NewPublisherInput{},
+			wantErr: true,
+		},
+		{
+			name:    "ng: input is nil",
+			in:      nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			p, err := publisher.NewPublisher(c.in)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, p)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, p)
+		})
+	}
+}
+
+func Test_Schedule(t *testing.T) {
+	ctx := context.Background()
+	now := baseTime
+	p := newPublisher(t, &now, noError)
+
+	e, err := p.Schedule(ctx, &publisher.ScheduleInput{
+		ID:        "key",
+		Input:     &types.CreateInput{Text: gotwi.String("first")},
+		PublishAt: baseTime.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, publisher.StatusPending, e.Status)
+	assert.Equal(t, baseTime.Add(time.Hour), e.NextAttemptAt)
+
+	// same ID returns the existing entry
+	again, err := p.Schedule(ctx, &publisher.ScheduleInput{
+		ID:    "key",
+		Input: &types.CreateInput{Text: gotwi.String("second")},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "first", gotwi.StringValue(again.Input.Text))
+
+	generated, err := p.Schedule(ctx, &publisher.ScheduleInput{Input: &types.CreateInput{}})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, generated.ID)
+	assert.Equal(t, baseTime, generated.PublishAt)
+
+	_, err = p.Schedule(ctx, &publisher.ScheduleInput{})
+	assert.Error(t, err)
+	_, err = p.Schedule(ctx, nil)
+	assert.Error(t, err)
+}
+
+func Test_EditAndCancel(t *testing.T) {
+	ctx := context.Background()
+	now := baseTime
+	p := newPublisher(t, &now, noError)
+
+	_, err := p.Schedule(ctx, &publisher.ScheduleInput{
+		ID:        "key",
+		Input:     &types.CreateInput{Text: gotwi.String("before")},
+		PublishAt: baseTime.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+
+	e, err := p.Edit(ctx, &publisher.ScheduleInput{ID: "key", Input: &types.CreateInput{Text: gotwi.String("after")}})
+	assert.NoError(t, err)
+	assert.Equal(t, "after", gotwi.StringValue(e.Input.Text))
+	assert.Equal(t, baseTime.Add(time.Hour), e.PublishAt)
+
+	e, err = p.Edit(ctx, &publisher.ScheduleInput{ID: "key", PublishAt: baseTime.Add(2 * time.Hour)})
+	assert.NoError(t, err)
+	assert.Equal(t, baseTime.Add(2*time.Hour), e.NextAttemptAt)
+
+	assert.NoError(t, p.Cancel(ctx, "key"))
+	e, err = p.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, publisher.StatusCanceled, e.Status)
+
+	// canceled entry can be neither edited nor canceled
+	_, err = p.Edit(ctx, &publisher.ScheduleInput{ID: "key"})
+	assert.Error(t, err)
+	assert.Error(t, p.Cancel(ctx, "key"))
+	assert.ErrorIs(t, p.Cancel(ctx, "unknown"), publisher.ErrEntryNotFound)
+}
+
+func Test_Dispatch(t *testing.T) {
+	ctx := context.Background()
+	now := baseTime
+
+	errs := map[string]error{
+		"duplicate": &gotwi.GotwiError{OnAPI: true, Non2XXError: resources.Non2XXError{
+			StatusCode: http.StatusForbidden,
+			Detail:     "You are not allowed to create a Tweet with duplicate content.",
+		}},
+		"flaky": errors.New("network error"),
+		"invalid": &gotwi.GotwiError{OnAPI: true, Non2XXError: resources.Non2XXError{
+			StatusCode: http.StatusBadRequest,
+		}},
+	}
+	p := newPublisher(t, &now, func(text string) error { return errs[text] })
+
+	for i, text := range []string{"ok", "duplicate", "flaky", "invalid", "future"} {
+		_, err := p.Schedule(ctx, &publisher.ScheduleInput{
+			ID:        text,
+			Input:     &types.CreateInput{Text: gotwi.String(text)},
+			PublishAt: baseTime.Add(time.Duration(i) * time.Second),
+		})
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, p.Cancel(ctx, "future"))
+	_, err := p.Schedule(ctx, &publisher.ScheduleInput{
+		ID:        "later",
+		Input:     &types.CreateInput{Text: gotwi.String("later")},
+		PublishAt: baseTime.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+
+	now = baseTime.Add(time.Minute)
+	processed, err := p.Dispatch(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, processed, 4)
+
+	expect := map[string]publisher.Status{
+		"ok":        publisher.StatusSent,
+		"duplicate": publisher.StatusDuplicate,
+		"flaky":     publisher.StatusPending,
+		"invalid":   publisher.StatusFailed,
+		"future":    publisher.StatusCanceled,
+		"later":     publisher.StatusPending,
+	}
+	for id, status := range expect {
+		e, err := p.Get(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, status, e.Status, id)
+	}
+
+	ok, _ := p.Get(ctx, "ok")
+	assert.Equal(t, "tweet-ok", ok.TweetID)
+
+	flaky, _ := p.Get(ctx, "flaky")
+	assert.Equal(t, 1, flaky.Attempts)
+	assert.Equal(t, now.Add(time.Minute), flaky.NextAttemptAt)
+
+	// rejected requests are not retried
+	invalid, _ := p.Get(ctx, "invalid")
+	assert.Equal(t, 1, invalid.Attempts)
+
+	// retry until max attempts
+	for i := 0; i < 2; i++ {
+		now = now.Add(time.Hour)
+		_, err := p.Dispatch(ctx)
+		assert.NoError(t, err)
+	}
+	flaky, _ = p.Get(ctx, "flaky")
+	assert.Equal(t, publisher.StatusFailed, flaky.Status)
+	assert.Equal(t, 3, flaky.Attempts)
+
+	later, _ := p.Get(ctx, "later")
+	assert.Equal(t, publisher.StatusSent, later.Status)
+}
+
+func Test_Dispatch_RateLimit(t *testing.T) {
+	ctx := context.Background()
+	now := baseTime
+	resetAt := baseTime.Add(10 * time.Minute)
+	limited := true
+
+	p := newPublisher(t, &now, func(text string) error {
+		if limited {
+			return &gotwi.GotwiError{OnAPI: true, Non2XXError: resources.Non2XXError{
+				StatusCode:    http.StatusTooManyRequests,
+				RateLimitInfo: &util.RateLimitInformation{ResetAt: &resetAt},
+			}}
+		}
+		return nil
+	})
+
+	for _, id := range []string{"a", "b"} {
+		_, err := p.Schedule(ctx, &publisher.ScheduleInput{ID: id, Input: &types.CreateInput{Text: gotwi.String(id)}})
+		assert.NoError(t, err)
+	}
+
+	processed, err := p.Dispatch(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, processed, 1)
+	assert.Equal(t, publisher.StatusPending, processed[0].Status)
+	assert.Equal(t, 0, processed[0].Attempts)
+
+	// paused until reset
+	limited = false
+	now = baseTime.Add(5 * time.Minute)
+	processed, err = p.Dispatch(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, processed)
+
+	now = resetAt
+	processed, err = p.Dispatch(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, processed, 2)
+}
+
+func Test_Dispatch_LostResponse(t *testing.T) {
+	ctx := context.Background()
+	now := baseTime
+	m := &mockAPI{createErr: noError, lost: map[string]bool{"a": true}}
+	p := newPublisherWithAPI(t, &now, m, nil)
+
+	_, err := p.Schedule(ctx, &publisher.ScheduleInput{ID: "a", Input: &types.CreateInput{Text: gotwi.String("a")}})
+	assert.NoError(t, err)
+
+	processed, err := p.Dispatch(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, processed, 1)
+
+	// the Tweet is found in the timeline, so it is not sent again
+	e, _ := p.Get(ctx, "a")
+	assert.Equal(t, publisher.StatusSent, e.Status)
+	assert.Equal(t, "tweet-a", e.TweetID)
+
+	_, err = p.Dispatch(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, m.posted, 1)
+}
+
+func Test_Dispatch_DuplicateOfPreviousAttempt(t *testing.T) {
+	ctx := context.Background()
+	now := baseTime
+	m := &mockAPI{
+		createErr: func(string) error {
+			return &gotwi.GotwiError{OnAPI: true, Non2XXError: resources.Non2XXError{
+				StatusCode: http.StatusForbidden,
+				APIErrors:  []resources.ErrorInformation{{Code: 187}},
+			}}
+		},
+		// posted by a previous attempt whose result was not recorded
+		posted: []resources.Tweet{{ID: gotwi.String("tweet-a"), Text: gotwi.String("a")}},
+	}
+	p := newPublisherWithAPI(t, &now, m, nil)
+
+	_, err := p.Schedule(ctx, &publisher.ScheduleInput{ID: "a", Input: &types.CreateInput{Text: gotwi.String("a")}})
+	assert.NoError(t, err)
+
+	_, err = p.Dispatch(ctx)
+	assert.NoError(t, err)
+
+	e, _ := p.Get(ctx, "a")
+	assert.Equal(t, publisher.StatusSent, e.Status)
+	assert.Equal(t, "tweet-a", e.TweetID)
+}
+
+func Test_Dispatch_DuplicateOfPreviousAttempt_NormalizedText(t *testing.T) {
+	ctx := context.Background()
+	now := baseTime
+	m := &mockAPI{
+		createErr: func(string) error {
+			return &gotwi.GotwiError{OnAPI: true, Non2XXError: resources.Non2XXError{
+				StatusCode: http.StatusForbidden,
+				APIErrors:  []resources.ErrorInformation{{Code: 187}},
+			}}
+		},
+		// the link is shortened and & is escaped in the posted Tweet
+		posted: []resources.Tweet{{
+			ID:   gotwi.String("tweet-a"),
+			Text: gotwi.String("Q&amp;A https://t.co/abc"),
+			Entities: &resources.TweetEntities{URLs: []resources.URL{
+				{URL: gotwi.String("https://t.co/abc"), ExpandedURL: gotwi.String("https://example.com/qa")},
+			}},
+		}},
+	}
+	p := newPublisherWithAPI(t, &now, m, nil)
+
+	_, err := p.Schedule(ctx, &publisher.ScheduleInput{ID: "a", Input: &types.CreateInput{Text: gotwi.String("Q&A https://example.com/qa")}})
+	assert.NoError(t, err)
+
+	_, err = p.Dispatch(ctx)
+	assert.NoError(t, err)
+
+	e, _ := p.Get(ctx, "a")
+	assert.Equal(t, publisher.StatusSent, e.Status)
+	assert.Equal(t, "tweet-a", e.TweetID)
+}
+
+func Test_Dispatch_RecoverSending(t *testing.T) {
+	ctx := context.Background()
+	now := baseTime
+	m := &mockAPI{
+		createErr:   noError,
+		timelineErr: errors.New("timeline error"),
+		posted:      []resources.Tweet{{ID: gotwi.String("tweet-crashed"), Text: gotwi.String("crashed")}},
+	}
+
+	// entries left in sending by a crash
+	s := publisher.NewMemoryStorage()
+	for _, text := range []string{"crashed", "unsent"} {
+		assert.NoError(t, s.Save(ctx, &publisher.Entry{
+			ID:            text,
+			Input:         &types.CreateInput{Text: gotwi.String(text)},
+			PublishAt:     baseTime,
+			NextAttemptAt: baseTime,
+			Status:        publisher.StatusSending,
+			Attempts:      1,
+		}))
+	}
+	p := newPublisherWithAPI(t, &now, m, s)
+
+	// the timeline cannot be read, so the entries stay in sending
+	_, err := p.Dispatch(ctx)
+	assert.NoError(t, err)
+	for _, id := range []string{"crashed", "unsent"} {
+		e, _ := p.Get(ctx, id)
+		assert.Equal(t, publisher.StatusSending, e.Status)
+		assert.Equal(t, "timeline error", e.LastError)
+	}
+
+	m.timelineErr = nil
+	processed, err := p.Dispatch(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, processed, 2)
+
+	crashed, _ := p.Get(ctx, "crashed")
+	assert.Equal(t, publisher.StatusSent, crashed.Status)
+	assert.Equal(t, "tweet-crashed", crashed.TweetID)
+
+	unsent, _ := p.Get(ctx, "unsent")
+	assert.Equal(t, publisher.StatusPending, unsent.Status)
+	assert.Equal(t, now.Add(time.Minute), unsent.NextAttemptAt)
+
+	now = now.Add(time.Minute)
+	_, err = p.Dispatch(ctx)
+	assert.NoError(t, err)
+	unsent, _ = p.Get(ctx, "unsent")
+	assert.Equal(t, publisher.StatusSent, unsent.Status)
+	assert.Equal(t, 2, unsent.Attempts)
+	assert.Len(t, m.posted, 2)
+}
+
+func Test_Dispatch_NotBlocking(t *testing.T) {
+	ctx := context.Background()
+	now := baseTime
+	posting := make(chan struct{})
+	release := make(chan struct{})
+	p := newPublisher(t, &now, func(text string) error {
+		if text == "a" {
+			close(posting)
+			<-release
+		}
+		return nil
+	})
+
+	for i, id := range []string{"a", "b"} {
+		_, err := p.Schedule(ctx, &publisher.ScheduleInput{
+			ID:        id,
+			Input:     &types.CreateInput{Text: gotwi.String(id)},
+			PublishAt: baseTime.Add(time.Duration(i) * time.Second),
+		})
+		assert.NoError(t, err)
+	}
+	now = baseTime.Add(time.Minute)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := p.Dispatch(ctx)
+		assert.NoError(t, err)
+	}()
+	<-posting
+
+	// the queue can be changed while a Tweet is being posted
+	a, err := p.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, publisher.StatusSending, a.Status)
+	assert.Error(t, p.Cancel(ctx, "a"))
+	assert.NoError(t, p.Cancel(ctx, "b"))
+	_, err = p.Schedule(ctx, &publisher.ScheduleInput{ID: "c", Input: &types.CreateInput{Text: gotwi.String("c")}})
+	assert.NoError(t, err)
+
+	close(release)
+	<-done
+
+	expect := map[string]publisher.Status{
+		"a": publisher.StatusSent,
+		"b": publisher.StatusCanceled,
+		"c": publisher.StatusPending,
+	}
+	for id, status := range expect {
+		e, err := p.Get(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, status, e.Status, id)
+	}
+}
+
+func Test_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	now := baseTime
+	p := newPublisher(t, &now, noError)
+
+	_, err := p.Schedule(ctx, &publisher.ScheduleInput{ID: "a", Input: &types.CreateInput{Text: gotwi.String("a")}})
+	assert.NoError(t, err)
+
+	cancel()
+	assert.ErrorIs(t, p.Run(ctx), context.Canceled)
+}
@@ -0,0 +1,128 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/michimani/gotwi/tweet/managetweet/types"
+)
+
+// ErrEntryNotFound is returned by Storage when the entry does not exist.
+var ErrEntryNotFound = errors.New("entry is not found")
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSending   Status = "sending"
+	StatusSent      Status = "sent"
+	StatusDuplicate Status = "duplicate"
+	StatusFailed    Status = "failed"
+	StatusCanceled  Status = "canceled"
+)
+
+// Entry is a Tweet in the queue.
+type Entry struct {
+	// Unique identifier of the entry. It is also used as the idempotency key of scheduling.
+	ID string
+
+	// Parameters for posting the Tweet.
+	Input *types.CreateInput
+
+	// Time to publish the Tweet.
+	PublishAt time.Time
+
+	// Time of the next attempt. It equals PublishAt until the first attempt fails.
+	NextAttemptAt time.Time
+
+	Status    Status
+	Attempts  int
+	LastError string
+
+	// Time of the last attempt.
+	LastAttemptAt time.Time
+
+	// ID of the posted Tweet. It is set when Status is StatusSent.
+	TweetID string
+}
+
+// Storage persists the entries of the queue.
+// Implementations must be safe for concurrent use.
+type Storage interface {
+	// Save creates or updates the entry.
+	Save(ctx context.Context, e *Entry) error
+
+	// Get returns the entry. It returns ErrEntryNotFound if the entry does not exist.
+	Get(ctx context.Context, id string) (*Entry, error)
+
+	// ListPending returns the entries whose Status is StatusPending.
+	ListPending(ctx context.Context) ([]*Entry, error)
+
+	// ListSending returns the entries whose Status is StatusSending.
+	ListSending(ctx context.Context) ([]*Entry, error)
+}
+
+// MemoryStorage is a Storage that keeps the entries in memory.
+type MemoryStorage struct {
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		entries: map[string]Entry{},
+	}
+}
+
+func (s *MemoryStorage) Save(ctx context.Context, e *Entry) error {
+	if e == nil {
+		return errors.New("Entry is nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[e.ID] = *e
+
+	return nil
+}
+
+func (s *MemoryStorage) Get(ctx context.Context, id string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[id]
+	if !ok {
+		return nil, ErrEntryNotFound
+	}
+
+	return &e, nil
+}
+
+func (s *MemoryStorage) ListPending(ctx context.Context) ([]*Entry, error) {
+	return s.list(StatusPending), nil
+}
+
+func (s *MemoryStorage) ListSending(ctx context.Context) ([]*Entry, error) {
+	return s.list(StatusSending), nil
+}
+
+func (s *MemoryStorage) list(status Status) []*Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := []*Entry{}
+	for _, e := range s.entries {
+		if e.Status == status {
+			entries = append(entries, &e)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].NextAttemptAt.Before(entries[j].NextAttemptAt)
+	})
+
+	return entries
+}
@@ -197,3 +197,117 @@ func Test_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListRetweets(t *testing.T) {
+	cases := []struct {
+		name    string
+		client  gotwi.IClient
+		params  *types.ListRetweetsInput
+		wantErr bool
+	}{
+		{
+			name: "success",
+			client: gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return nil
+				},
+			}),
+			params:  &types.ListRetweetsInput{ID: "1234567890"},
+			wantErr: false,
+		},
+		{
+			name: "error",
+			client: gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return fmt.Errorf("CallAPI error")
+				},
+			}),
+			params:  &types.ListRetweetsInput{ID: "1234567890"},
+			wantErr: true,
+		},
+		{
+			name: "error: params is nil",
+			client: gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return nil
+				},
+			}),
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			ctx := context.Background()
+			res, err := ListRetweets(ctx, c.client, c.params)
+
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(res)
+				return
+			}
+
+			asst.NoError(err)
+			asst.NotNil(res)
+		})
+	}
+}
+
+func Test_ListRepostsOfMe(t *testing.T) {
+	cases := []struct {
+		name    string
+		client  gotwi.IClient
+		params  *types.ListRepostsOfMeInput
+		wantErr bool
+	}{
+		{
+			name: "success",
+			client: gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return nil
+				},
+			}),
+			params:  &types.ListRepostsOfMeInput{},
+			wantErr: false,
+		},
+		{
+			name: "error",
+			client: gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return fmt.Errorf("CallAPI error")
+				},
+			}),
+			params:  &types.ListRepostsOfMeInput{},
+			wantErr: true,
+		},
+		{
+			name: "error: params is nil",
+			client: gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return nil
+				},
+			}),
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			ctx := context.Background()
+			res, err := ListRepostsOfMe(ctx, c.client, c.params)
+
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(res)
+				return
+			}
+
+			asst.NoError(err)
+			asst.NotNil(res)
+		})
+	}
+}
@@ -89,6 +89,164 @@ func (p *ListUsersInput) ParameterMap() map[string]string {
 	return m
 }
 
+type ListRetweetsMaxResults int
+
+func (m ListRetweetsMaxResults) Valid() bool {
+	return m > 0 && m <= 100
+}
+
+func (m ListRetweetsMaxResults) String() string {
+	return strconv.Itoa(int(m))
+}
+
+type ListRetweetsInput struct {
+	accessToken string
+
+	// Path parameter
+	ID string // Tweet ID
+
+	// Query parameters
+	Expansions      fields.ExpansionList
+	MaxResults      ListRetweetsMaxResults // default 100
+	PaginationToken string
+	MediaFields     fields.MediaFieldList
+	PlaceFields     fields.PlaceFieldList
+	PollFields      fields.PollFieldList
+	TweetFields     fields.TweetFieldList
+	UserFields      fields.UserFieldList
+}
+
+var listRetweetsQueryParameters = map[string]struct{}{
+	"expansions":       {},
+	"max_results":      {},
+	"pagination_token": {},
+	"media.fields":     {},
+	"place.fields":     {},
+	"poll.fields":      {},
+	"tweet.fields":     {},
+	"user.fields":      {},
+}
+
+func (p *ListRetweetsInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *ListRetweetsInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *ListRetweetsInput) ResolveEndpoint(endpointBase string) string {
+	if p.ID == "" {
+		return ""
+	}
+
+	encoded := url.QueryEscape(p.ID)
+	endpoint := strings.Replace(endpointBase, ":id", encoded, 1)
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, listRetweetsQueryParameters)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func (p *ListRetweetsInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *ListRetweetsInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+
+	if p.MaxResults.Valid() {
+		m["max_results"] = p.MaxResults.String()
+	}
+
+	if p.PaginationToken != "" {
+		m["pagination_token"] = p.PaginationToken
+	}
+
+	m = fields.SetFieldsParams(m, p.Expansions, p.MediaFields, p.PlaceFields, p.PollFields, p.TweetFields, p.UserFields)
+
+	return m
+}
+
+type ListRepostsOfMeMaxResults int
+
+func (m ListRepostsOfMeMaxResults) Valid() bool {
+	return m > 0 && m <= 100
+}
+
+func (m ListRepostsOfMeMaxResults) String() string {
+	return strconv.Itoa(int(m))
+}
+
+type ListRepostsOfMeInput struct {
+	accessToken string
+
+	// Query parameters
+	Expansions      fields.ExpansionList
+	MaxResults      ListRepostsOfMeMaxResults // default 100
+	PaginationToken string
+	MediaFields     fields.MediaFieldList
+	PlaceFields     fields.PlaceFieldList
+	PollFields      fields.PollFieldList
+	TweetFields     fields.TweetFieldList
+	UserFields      fields.UserFieldList
+}
+
+var listRepostsOfMeQueryParameters = map[string]struct{}{
+	"expansions":       {},
+	"max_results":      {},
+	"pagination_token": {},
+	"media.fields":     {},
+	"place.fields":     {},
+	"poll.fields":      {},
+	"tweet.fields":     {},
+	"user.fields":      {},
+}
+
+func (p *ListRepostsOfMeInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *ListRepostsOfMeInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *ListRepostsOfMeInput) ResolveEndpoint(endpointBase string) string {
+	endpoint := endpointBase
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, listRepostsOfMeQueryParameters)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func (p *ListRepostsOfMeInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *ListRepostsOfMeInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+
+	if p.MaxResults.Valid() {
+		m["max_results"] = p.MaxResults.String()
+	}
+
+	if p.PaginationToken != "" {
+		m["pagination_token"] = p.PaginationToken
+	}
+
+	m = fields.SetFieldsParams(m, p.Expansions, p.MediaFields, p.PlaceFields, p.PollFields, p.TweetFields, p.UserFields)
+
+	return m
+}
+
 type CreateInput struct {
 	accessToken string
 
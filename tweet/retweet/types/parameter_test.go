@@ -388,3 +388,99 @@ func Test_DeleteInput_ParameterMap(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListRetweetsInput_ResolveEndpoint(t *testing.T) {
+	const endpointRoot = "test/endpoint/"
+	const endpointBase = "test/endpoint/:id"
+	cases := []struct {
+		name   string
+		params *types.ListRetweetsInput
+		expect string
+	}{
+		{
+			name:   "only required parameter",
+			params: &types.ListRetweetsInput{ID: "test-id"},
+			expect: endpointRoot + "test-id",
+		},
+		{
+			name: "with invalid max_results",
+			params: &types.ListRetweetsInput{
+				ID:         "test-id",
+				MaxResults: types.ListRetweetsMaxResults(101),
+			},
+			expect: endpointRoot + "test-id",
+		},
+		{
+			name: "all query parameters",
+			params: &types.ListRetweetsInput{
+				ID:              "test-id",
+				Expansions:      fields.ExpansionList{"ex"},
+				MaxResults:      types.ListRetweetsMaxResults(20),
+				PaginationToken: "p-token",
+				MediaFields:     fields.MediaFieldList{"mf"},
+				PlaceFields:     fields.PlaceFieldList{"plf"},
+				PollFields:      fields.PollFieldList{"pof"},
+				TweetFields:     fields.TweetFieldList{"tf"},
+				UserFields:      fields.UserFieldList{"uf"},
+			},
+			expect: endpointRoot + "test-id" + "?expansions=ex&max_results=20&media.fields=mf&pagination_token=p-token&place.fields=plf&poll.fields=pof&tweet.fields=tf&user.fields=uf",
+		},
+		{
+			name: "has no required parameter",
+			params: &types.ListRetweetsInput{
+				TweetFields: fields.TweetFieldList{"tf"},
+			},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			ep := c.params.ResolveEndpoint(endpointBase)
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
+
+func Test_ListRepostsOfMeInput_ResolveEndpoint(t *testing.T) {
+	const endpointBase = "test/endpoint"
+	cases := []struct {
+		name   string
+		params *types.ListRepostsOfMeInput
+		expect string
+	}{
+		{
+			name:   "no parameters",
+			params: &types.ListRepostsOfMeInput{},
+			expect: endpointBase,
+		},
+		{
+			name: "with invalid max_results",
+			params: &types.ListRepostsOfMeInput{
+				MaxResults: types.ListRepostsOfMeMaxResults(0),
+			},
+			expect: endpointBase,
+		},
+		{
+			name: "all query parameters",
+			params: &types.ListRepostsOfMeInput{
+				Expansions:      fields.ExpansionList{"ex"},
+				MaxResults:      types.ListRepostsOfMeMaxResults(100),
+				PaginationToken: "p-token",
+				MediaFields:     fields.MediaFieldList{"mf"},
+				PlaceFields:     fields.PlaceFieldList{"plf"},
+				PollFields:      fields.PollFieldList{"pof"},
+				TweetFields:     fields.TweetFieldList{"tf"},
+				UserFields:      fields.UserFieldList{"uf"},
+			},
+			expect: endpointBase + "?expansions=ex&max_results=100&media.fields=mf&pagination_token=p-token&place.fields=plf&poll.fields=pof&tweet.fields=tf&user.fields=uf",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			ep := c.params.ResolveEndpoint(endpointBase)
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
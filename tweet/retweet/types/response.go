@@ -10,6 +10,7 @@ type ListUsersOutput struct {
 		Media  []resources.Media `json:"media,omitempty"`
 		Polls  []resources.Poll  `json:"polls,omitempty"`
 	} `json:"includes,omitempty"`
+	Meta   resources.PaginationMeta `json:"meta"`
 	Errors []resources.PartialError `json:"errors,omitempty"`
 }
 
@@ -17,6 +18,40 @@ func (r *ListUsersOutput) HasPartialError() bool {
 	return !(r.Errors == nil || len(r.Errors) == 0)
 }
 
+type ListRetweetsOutput struct {
+	Data     []resources.Tweet `json:"data"`
+	Includes struct {
+		Users  []resources.User  `json:"users,omitempty"`
+		Tweets []resources.Tweet `json:"tweets,omitempty"`
+		Places []resources.Place `json:"places,omitempty"`
+		Media  []resources.Media `json:"media,omitempty"`
+		Polls  []resources.Poll  `json:"polls,omitempty"`
+	} `json:"includes,omitempty"`
+	Meta   resources.PaginationMeta `json:"meta"`
+	Errors []resources.PartialError `json:"errors,omitempty"`
+}
+
+func (r *ListRetweetsOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+type ListRepostsOfMeOutput struct {
+	Data     []resources.Tweet `json:"data"`
+	Includes struct {
+		Users  []resources.User  `json:"users,omitempty"`
+		Tweets []resources.Tweet `json:"tweets,omitempty"`
+		Places []resources.Place `json:"places,omitempty"`
+		Media  []resources.Media `json:"media,omitempty"`
+		Polls  []resources.Poll  `json:"polls,omitempty"`
+	} `json:"includes,omitempty"`
+	Meta   resources.PaginationMeta `json:"meta"`
+	Errors []resources.PartialError `json:"errors,omitempty"`
+}
+
+func (r *ListRepostsOfMeOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
 type CreateOutput struct {
 	Data struct {
 		Retweeted bool `json:"retweeted"`
@@ -0,0 +1,150 @@
+package thread
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/michimani/gotwi/text"
+)
+
+// DefaultMaxWeightedLength is the maximum weighted length of the text of a Tweet.
+const DefaultMaxWeightedLength = text.MaxWeightedTweetLength
+
+// splitText splits s into chunks whose weighted length is less than or equal to max.
+// It splits at sentence boundaries first, then at word boundaries,
+// and finally at character boundaries for words that are longer than max.
+func splitText(s string, max int) []string {
+	s = strings.TrimSpace(s)
+	if text.WeightedLength(s) <= max {
+		return []string{s}
+	}
+
+	return pack(sentences(s), max, func(sentence string) []string {
+		return pack(words(sentence), max, func(word string) []string {
+			return characters(word, max)
+		})
+	})
+}
+
+// pack concatenates pieces into chunks as long as possible.
+// Pieces that are longer than max by themselves are split by fallback.
+func pack(pieces []string, max int, fallback func(string) []string) []string {
+	chunks := []string{}
+	cur := ""
+	flush := func() {
+		if t := strings.TrimSpace(cur); t != "" {
+			chunks = append(chunks, t)
+		}
+		cur = ""
+	}
+
+	for _, p := range pieces {
+		if text.WeightedLength(strings.TrimSpace(cur+p)) <= max {
+			cur += p
+			continue
+		}
+
+		flush()
+		if text.WeightedLength(strings.TrimSpace(p)) <= max {
+			cur = p
+			continue
+		}
+
+		chunks = append(chunks, fallback(strings.TrimSpace(p))...)
+	}
+	flush()
+
+	return chunks
+}
+
+func isSentenceTerminator(r rune) bool {
+	switch r {
+	case '.', '!', '?', '。', '！', '？':
+		return true
+	}
+	return false
+}
+
+func isFullWidthTerminator(r rune) bool {
+	return r == '。' || r == '！' || r == '？'
+}
+
+// sentences splits s after each sentence terminator and the following spaces.
+// ASCII terminators are treated as boundaries only when followed by a space.
+func sentences(s string) []string {
+	pieces := []string{}
+	start := 0
+	runes := []rune(s)
+	pos := 0
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		pos += utf8.RuneLen(r)
+		if !isSentenceTerminator(r) {
+			continue
+		}
+
+		next := i + 1
+		if next < len(runes) && !unicode.IsSpace(runes[next]) && !isFullWidthTerminator(r) {
+			continue
+		}
+
+		for next < len(runes) && unicode.IsSpace(runes[next]) {
+			pos += utf8.RuneLen(runes[next])
+			next++
+		}
+		pieces = append(pieces, s[start:pos])
+		start = pos
+		i = next - 1
+	}
+
+	if start < len(s) {
+		pieces = append(pieces, s[start:])
+	}
+
+	return pieces
+}
+
+// words splits s after each run of spaces.
+func words(s string) []string {
+	pieces := []string{}
+	start := 0
+	inSpace := false
+	for i, r := range s {
+		if unicode.IsSpace(r) {
+			inSpace = true
+			continue
+		}
+		if inSpace {
+			pieces = append(pieces, s[start:i])
+			start = i
+			inSpace = false
+		}
+	}
+
+	if start < len(s) {
+		pieces = append(pieces, s[start:])
+	}
+
+	return pieces
+}
+
+// characters splits s into chunks whose weighted length is less than or equal to max.
+// It does not split emoji sequences and characters with combining marks.
+func characters(s string, max int) []string {
+	chunks := []string{}
+	var b strings.Builder
+	for _, g := range text.Graphemes(s) {
+		if b.Len() > 0 && text.WeightedLength(b.String()+g) > max {
+			chunks = append(chunks, b.String())
+			b.Reset()
+		}
+		b.WriteString(g)
+	}
+
+	if b.Len() > 0 {
+		chunks = append(chunks, b.String())
+	}
+
+	return chunks
+}
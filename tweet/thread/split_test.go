@@ -0,0 +1,123 @@
+package thread_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/tweet/managetweet/types"
+	"github.com/michimani/gotwi/tweet/thread"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Compose(t *testing.T) {
+	poll := &types.CreateInputPoll{DurationMinutes: gotwi.Int(5), Options: []string{"a", "b"}}
+
+	cases := []struct {
+		name      string
+		segments  []thread.Segment
+		maxLength int
+		expect    []*types.CreateInput
+		wantErr   bool
+	}{
+		{
+			name:     "ok: short segments",
+			segments: []thread.Segment{{Text: "first"}, {Text: "second", MediaIDs: []string{"m1"}}},
+			expect: []*types.CreateInput{
+				{Text: gotwi.String("first")},
+				{Text: gotwi.String("second"), Media: &types.CreateInputMedia{MediaIDs: []string{"m1"}}},
+			},
+		},
+		{
+			name:      "ok: split at sentence boundaries",
+			segments:  []thread.Segment{{Text: "One two. Three four! Five six?", MediaIDs: []string{"m1"}, Poll: poll}},
+			maxLength: 20,
+			expect: []*types.CreateInput{
+				{Text: gotwi.String("One two. Three four!"), Media: &types.CreateInputMedia{MediaIDs: []string{"m1"}}},
+				{Text: gotwi.String("Five six?"), Poll: poll},
+			},
+		},
+		{
+			name:      "ok: split at word boundaries",
+			segments:  []thread.Segment{{Text: "aaaa bbbb cccc dddd"}},
+			maxLength: 10,
+			expect: []*types.CreateInput{
+				{Text: gotwi.String("aaaa bbbb")},
+				{Text: gotwi.String("cccc dddd")},
+			},
+		},
+		{
+			name:      "ok: split long word",
+			segments:  []thread.Segment{{Text: strings.Repeat("a", 12)}},
+			maxLength: 5,
+			expect: []*types.CreateInput{
+				{Text: gotwi.String("aaaaa")},
+				{Text: gotwi.String("aaaaa")},
+				{Text: gotwi.String("aa")},
+			},
+		},
+		{
+			name:      "ok: split long word on emoji sequences",
+			segments:  []thread.Segment{{Text: "aaa👩‍💻🇯🇵é"}},
+			maxLength: 4,
+			expect: []*types.CreateInput{
+				{Text: gotwi.String("aaa")},
+				{Text: gotwi.String("👩‍💻🇯🇵")},
+				{Text: gotwi.String("é")},
+			},
+		},
+		{
+			name:      "ok: CJK characters are counted as 2",
+			segments:  []thread.Segment{{Text: "こんにちは。さようなら。"}},
+			maxLength: 12,
+			expect: []*types.CreateInput{
+				{Text: gotwi.String("こんにちは。")},
+				{Text: gotwi.String("さようなら。")},
+			},
+		},
+		{
+			name:      "ok: URL is counted as 23",
+			segments:  []thread.Segment{{Text: "see https://example.com/a/very/long/path/that/is/longer/than/limit"}},
+			maxLength: 27,
+			expect: []*types.CreateInput{
+				{Text: gotwi.String("see https://example.com/a/very/long/path/that/is/longer/than/limit")},
+			},
+		},
+		{
+			name:     "ok: media only",
+			segments: []thread.Segment{{MediaIDs: []string{"m1"}}},
+			expect: []*types.CreateInput{
+				{Media: &types.CreateInputMedia{MediaIDs: []string{"m1"}}},
+			},
+		},
+		{
+			name:     "ng: empty segment",
+			segments: []thread.Segment{{}},
+			wantErr:  true,
+		},
+		{
+			name:     "ng: media and poll in one Tweet",
+			segments: []thread.Segment{{Text: "short", MediaIDs: []string{"m1"}, Poll: poll}},
+			wantErr:  true,
+		},
+		{
+			name:     "ng: no segments",
+			segments: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			got, err := thread.Compose(c.segments, c.maxLength)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, got)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.Equal(tt, c.expect, got)
+		})
+	}
+}
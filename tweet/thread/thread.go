@@ -0,0 +1,162 @@
+package thread
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/tweet/managetweet"
+	"github.com/michimani/gotwi/tweet/managetweet/types"
+)
+
+// Segment is a part of a thread.
+// A segment whose text is longer than the maximum weighted length is split into multiple Tweets.
+// In that case, media is attached to the first Tweet and poll is attached to the last Tweet.
+// A segment that fits in one Tweet cannot have both media and poll, because a Tweet cannot have both.
+type Segment struct {
+	Text     string
+	MediaIDs []string
+	Poll     *types.CreateInputPoll
+}
+
+// PostInput is struct for posting a thread.
+type PostInput struct {
+	Segments []Segment // required
+
+	// The Tweet ID to which the first Tweet of the thread replies. (optional)
+	InReplyToTweetID string
+
+	// The maximum weighted length of each Tweet. Default is DefaultMaxWeightedLength.
+	MaxWeightedLength int
+}
+
+// PostedTweet is a Tweet that has already been posted as a part of a thread.
+type PostedTweet struct {
+	ID   string
+	Text string
+}
+
+// Thread is the state of a thread.
+// Posted has the Tweets that have been posted in order,
+// and Pending has the Tweets that have not been posted yet.
+type Thread struct {
+	Posted  []PostedTweet
+	Pending []*types.CreateInput
+
+	inReplyToTweetID string
+}
+
+// Compose splits segments into the parameters for each Tweet of a thread.
+// Reply settings are not set to the parameters, because the ID of the previous Tweet is unknown until it is posted.
+func Compose(segments []Segment, maxWeightedLength int) ([]*types.CreateInput, error) {
+	if len(segments) == 0 {
+		return nil, errors.New("segments is empty")
+	}
+
+	if maxWeightedLength <= 0 {
+		maxWeightedLength = DefaultMaxWeightedLength
+	}
+
+	inputs := []*types.CreateInput{}
+	for i, s := range segments {
+		if s.Text == "" && len(s.MediaIDs) == 0 {
+			return nil, fmt.Errorf("segment %d has neither text nor media", i)
+		}
+
+		texts := splitText(s.Text, maxWeightedLength)
+		if len(texts) == 1 && len(s.MediaIDs) > 0 && s.Poll != nil {
+			return nil, fmt.Errorf("segment %d has both media and poll in one Tweet", i)
+		}
+		for j, t := range texts {
+			in := &types.CreateInput{}
+			if t != "" {
+				in.Text = gotwi.String(t)
+			}
+			if j == 0 && len(s.MediaIDs) > 0 {
+				in.Media = &types.CreateInputMedia{MediaIDs: s.MediaIDs}
+			}
+			if j == len(texts)-1 && s.Poll != nil {
+				in.Poll = s.Poll
+			}
+			inputs = append(inputs, in)
+		}
+	}
+
+	return inputs, nil
+}
+
+// Post posts a thread that is composed of segments.
+// Each Tweet is posted as a reply to the previous one.
+// If posting fails mid-way, Post returns the Thread that has the posted and pending Tweets together with the error.
+// The returned Thread can be passed to Resume or Rollback.
+func Post(ctx context.Context, c gotwi.IClient, p *PostInput) (*Thread, error) {
+	if p == nil {
+		return nil, errors.New("PostInput is nil")
+	}
+
+	inputs, err := Compose(p.Segments, p.MaxWeightedLength)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Thread{
+		Posted:           []PostedTweet{},
+		Pending:          inputs,
+		inReplyToTweetID: p.InReplyToTweetID,
+	}
+
+	return Resume(ctx, c, t)
+}
+
+// Resume posts the pending Tweets of the thread.
+func Resume(ctx context.Context, c gotwi.IClient, t *Thread) (*Thread, error) {
+	if t == nil {
+		return nil, errors.New("Thread is nil")
+	}
+
+	for len(t.Pending) > 0 {
+		in := t.Pending[0]
+		if replyTo := t.lastTweetID(); replyTo != "" {
+			in.Reply = &types.CreateInputReply{InReplyToTweetID: replyTo}
+		}
+
+		res, err := managetweet.Create(ctx, c, in)
+		if err != nil {
+			return t, err
+		}
+
+		t.Posted = append(t.Posted, PostedTweet{
+			ID:   gotwi.StringValue(res.Data.ID),
+			Text: gotwi.StringValue(res.Data.Text),
+		})
+		t.Pending = t.Pending[1:]
+	}
+
+	return t, nil
+}
+
+// Rollback deletes the posted Tweets of the thread in reverse order.
+// Deleted Tweets are removed from Posted, so Rollback can be called again if it fails.
+func Rollback(ctx context.Context, c gotwi.IClient, t *Thread) error {
+	if t == nil {
+		return errors.New("Thread is nil")
+	}
+
+	for len(t.Posted) > 0 {
+		last := t.Posted[len(t.Posted)-1]
+		if _, err := managetweet.Delete(ctx, c, &types.DeleteInput{ID: last.ID}); err != nil {
+			return err
+		}
+		t.Posted = t.Posted[:len(t.Posted)-1]
+	}
+
+	return nil
+}
+
+func (t *Thread) lastTweetID() string {
+	if len(t.Posted) > 0 {
+		return t.Posted[len(t.Posted)-1].ID
+	}
+	return t.inReplyToTweetID
+}
@@ -0,0 +1,185 @@
+package thread_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/tweet/managetweet/types"
+	"github.com/michimani/gotwi/tweet/thread"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMockClient returns a client that posts Tweets with sequential IDs.
+// Creating the failAt-th Tweet (1-origin) fails.
+func newMockClient(failAt int, replies *[]string, deleted *[]string) *gotwi.MockGotwiClient {
+	created := 0
+	return gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+		MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+			switch in := p.(type) {
+			case *types.CreateInput:
+				created++
+				if created == failAt {
+					return errors.New("create error")
+				}
+				replyTo := ""
+				if in.Reply != nil {
+					replyTo = in.Reply.InReplyToTweetID
+				}
+				*replies = append(*replies, replyTo)
+				out := i.(*types.CreateOutput)
+				out.Data.ID = gotwi.String(fmt.Sprintf("id%d", created))
+				out.Data.Text = in.Text
+			case *types.DeleteInput:
+				if in.ID == "" {
+					return errors.New("delete error")
+				}
+				*deleted = append(*deleted, in.ID)
+			}
+			return nil
+		},
+	})
+}
+
+func Test_Post(t *testing.T) {
+	cases := []struct {
+		name          string
+		in            *thread.PostInput
+		failAt        int
+		expectPosted  []thread.PostedTweet
+		expectPending int
+		expectReplies []string
+		wantErr       bool
+	}{
+		{
+			name: "ok",
+			in: &thread.PostInput{
+				Segments: []thread.Segment{{Text: "t1"}, {Text: "t2"}, {Text: "t3"}},
+			},
+			expectPosted:  []thread.PostedTweet{{ID: "id1", Text: "t1"}, {ID: "id2", Text: "t2"}, {ID: "id3", Text: "t3"}},
+			expectReplies: []string{"", "id1", "id2"},
+		},
+		{
+			name: "ok: reply to existing tweet",
+			in: &thread.PostInput{
+				Segments:         []thread.Segment{{Text: "t1"}, {Text: "t2"}},
+				InReplyToTweetID: "root",
+			},
+			expectPosted:  []thread.PostedTweet{{ID: "id1", Text: "t1"}, {ID: "id2", Text: "t2"}},
+			expectReplies: []string{"root", "id1"},
+		},
+		{
+			name: "ng: fail mid-way",
+			in: &thread.PostInput{
+				Segments: []thread.Segment{{Text: "t1"}, {Text: "t2"}, {Text: "t3"}},
+			},
+			failAt:        2,
+			expectPosted:  []thread.PostedTweet{{ID: "id1", Text: "t1"}},
+			expectPending: 2,
+			expectReplies: []string{""},
+			wantErr:       true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			replies := []string{}
+			deleted := []string{}
+			mc := newMockClient(c.failAt, &replies, &deleted)
+
+			th, err := thread.Post(context.Background(), mc, c.in)
+			if c.wantErr {
+				assert.Error(tt, err)
+			} else {
+				assert.NoError(tt, err)
+			}
+
+			assert.Equal(tt, c.expectPosted, th.Posted)
+			assert.Len(tt, th.Pending, c.expectPending)
+			assert.Equal(tt, c.expectReplies, replies)
+		})
+	}
+
+	t.Run("ng: input is nil", func(tt *testing.T) {
+		th, err := thread.Post(context.Background(), gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{}), nil)
+		assert.Error(tt, err)
+		assert.Nil(tt, th)
+	})
+}
+
+func Test_Resume(t *testing.T) {
+	replies := []string{}
+	deleted := []string{}
+	mc := newMockClient(2, &replies, &deleted)
+
+	th, err := thread.Post(context.Background(), mc, &thread.PostInput{
+		Segments: []thread.Segment{{Text: "t1"}, {Text: "t2"}, {Text: "t3"}},
+	})
+	assert.Error(t, err)
+
+	th, err = thread.Resume(context.Background(), mc, th)
+	assert.NoError(t, err)
+	assert.Equal(t, []thread.PostedTweet{{ID: "id1", Text: "t1"}, {ID: "id3", Text: "t2"}, {ID: "id4", Text: "t3"}}, th.Posted)
+	assert.Empty(t, th.Pending)
+	assert.Equal(t, []string{"", "id1", "id3"}, replies)
+
+	_, err = thread.Resume(context.Background(), mc, nil)
+	assert.Error(t, err)
+}
+
+func Test_Rollback(t *testing.T) {
+	cases := []struct {
+		name          string
+		thread        *thread.Thread
+		expectDeleted []string
+		expectPosted  []thread.PostedTweet
+		wantErr       bool
+	}{
+		{
+			name: "ok",
+			thread: &thread.Thread{
+				Posted: []thread.PostedTweet{{ID: "id1"}, {ID: "id2"}},
+			},
+			expectDeleted: []string{"id2", "id1"},
+			expectPosted:  []thread.PostedTweet{},
+		},
+		{
+			name: "ng: fail to delete",
+			thread: &thread.Thread{
+				Posted: []thread.PostedTweet{{ID: ""}, {ID: "id2"}},
+			},
+			expectDeleted: []string{"id2"},
+			expectPosted:  []thread.PostedTweet{{ID: ""}},
+			wantErr:       true,
+		},
+		{
+			name:          "ng: nil",
+			thread:        nil,
+			expectDeleted: []string{},
+			wantErr:       true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			replies := []string{}
+			deleted := []string{}
+			mc := newMockClient(0, &replies, &deleted)
+
+			err := thread.Rollback(context.Background(), mc, c.thread)
+			if c.wantErr {
+				assert.Error(tt, err)
+			} else {
+				assert.NoError(tt, err)
+			}
+
+			assert.Equal(tt, c.expectDeleted, deleted)
+			if c.thread != nil {
+				assert.Equal(tt, c.expectPosted, c.thread.Posted)
+			}
+		})
+	}
+}
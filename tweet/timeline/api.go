@@ -2,7 +2,6 @@ package timeline
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/michimani/gotwi"
 	"github.com/michimani/gotwi/tweet/timeline/types"
@@ -18,49 +17,22 @@ const (
 // By default, the most recent ten Tweets are returned per request. Using pagination, the most recent 3,200 Tweets can be retrieved.
 // The Tweets returned by this endpoint count towards the Project-level Tweet cap.
 // https://developer.twitter.com/en/docs/twitter-api/tweets/timelines/api-reference/get-users-id-tweets
-func ListTweets(ctx context.Context, c gotwi.IClient, p *types.ListTweetsInput) (*types.ListTweetsOutput, error) {
-	if p == nil {
-		return nil, fmt.Errorf("parameters is nil")
-	}
-
-	res := &types.ListTweetsOutput{}
-	if err := c.CallAPI(ctx, listTweetsEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func ListTweets(ctx context.Context, c gotwi.IClient, p *types.ListTweetsInput, opts ...gotwi.CallOption) (*types.ListTweetsOutput, error) {
+	return gotwi.Do[*types.ListTweetsOutput](ctx, c, "GET", listTweetsEndpoint, p, opts...)
 }
 
 // Returns Tweets mentioning a single user specified by the requested user ID.
 // By default, the most recent ten Tweets are returned per request. Using pagination, up to the most recent 800 Tweets can be retrieved.
 // The Tweets returned by this endpoint count towards the Project-level Tweet cap.
 // https://developer.twitter.com/en/docs/twitter-api/tweets/timelines/api-reference/get-users-id-mentions
-func ListMentions(ctx context.Context, c gotwi.IClient, p *types.ListMentionsInput) (*types.ListMentionsOutput, error) {
-	if p == nil {
-		return nil, fmt.Errorf("parameters is nil")
-	}
-
-	res := &types.ListMentionsOutput{}
-	if err := c.CallAPI(ctx, listMentionsEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func ListMentions(ctx context.Context, c gotwi.IClient, p *types.ListMentionsInput, opts ...gotwi.CallOption) (*types.ListMentionsOutput, error) {
+	return gotwi.Do[*types.ListMentionsOutput](ctx, c, "GET", listMentionsEndpoint, p, opts...)
 }
 
 // Allows you to retrieve a collection of the most recent Tweets and Retweets
 // posted by you and users you follow. This endpoint can return every Tweet
 // created on a timeline over the last 7 days as well as the most recent 800 regardless of creation date.
 // https://developer.twitter.com/en/docs/twitter-api/tweets/timelines/api-reference/get-users-id-reverse-chronological
-func ListReverseChronological(ctx context.Context, c gotwi.IClient, p *types.ListReverseChronologicalInput) (*types.ListReverseChronologicalOutput, error) {
-	if p == nil {
-		return nil, fmt.Errorf("parameters is nil")
-	}
-
-	res := &types.ListReverseChronologicalOutput{}
-	if err := c.CallAPI(ctx, listReverseChronologicalEndpoint, "GET", p, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func ListReverseChronological(ctx context.Context, c gotwi.IClient, p *types.ListReverseChronologicalInput, opts ...gotwi.CallOption) (*types.ListReverseChronologicalOutput, error) {
+	return gotwi.Do[*types.ListReverseChronologicalOutput](ctx, c, "GET", listReverseChronologicalEndpoint, p, opts...)
 }
@@ -0,0 +1,62 @@
+package tweetlookup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/bulk"
+	"github.com/michimani/gotwi/internal/gotwierrors"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/tweetlookup/types"
+)
+
+// maxBatchSize is the maximum number of IDs for one request.
+const maxBatchSize = 100
+
+// BulkList calls `GET /2/tweets` for every 100 IDs concurrently, and merges the responses.
+// IDs of the input can be more than 100. Other parameters of the input are used for all requests.
+// parallelism is the maximum number of concurrent requests. If it is 0, the default value (4) is used.
+// When the rate limit is exceeded, requests are retried after the rate limit is reset.
+func BulkList(ctx context.Context, c gotwi.IClient, p *types.ListInput, parallelism int) (*types.BulkListOutput, error) {
+	if p == nil {
+		return nil, fmt.Errorf(gotwierrors.ErrorParametersNil, listEndpoint)
+	}
+
+	results, err := bulk.Run(ctx, p.IDs, maxBatchSize, parallelism, func(ctx context.Context, ids []string) (*types.ListOutput, error) {
+		in := &types.ListInput{
+			IDs:         ids,
+			Expansions:  p.Expansions,
+			MediaFields: p.MediaFields,
+			PlaceFields: p.PlaceFields,
+			PollFields:  p.PollFields,
+			TweetFields: p.TweetFields,
+			UserFields:  p.UserFields,
+		}
+		return List(ctx, c, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &types.BulkListOutput{Errors: map[string]resources.PartialError{}}
+	for _, res := range results {
+		out.Data = append(out.Data, res.Data...)
+		out.Includes.Users = append(out.Includes.Users, res.Includes.Users...)
+		out.Includes.Tweets = append(out.Includes.Tweets, res.Includes.Tweets...)
+		out.Includes.Places = append(out.Includes.Places, res.Includes.Places...)
+		out.Includes.Media = append(out.Includes.Media, res.Includes.Media...)
+		out.Includes.Polls = append(out.Includes.Polls, res.Includes.Polls...)
+		for _, e := range res.Errors {
+			out.Errors[bulk.ErrorKey(e)] = e
+		}
+	}
+
+	out.Includes.Users = bulk.UniqueBy(out.Includes.Users, func(u resources.User) *string { return u.ID })
+	out.Includes.Tweets = bulk.UniqueBy(out.Includes.Tweets, func(t resources.Tweet) *string { return t.ID })
+	out.Includes.Places = bulk.UniqueBy(out.Includes.Places, func(p resources.Place) *string { return p.ID })
+	out.Includes.Media = bulk.UniqueBy(out.Includes.Media, func(m resources.Media) *string { return m.MediaKey })
+	out.Includes.Polls = bulk.UniqueBy(out.Includes.Polls, func(p resources.Poll) *string { return p.ID })
+
+	return out, nil
+}
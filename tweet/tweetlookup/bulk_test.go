@@ -0,0 +1,82 @@
+package tweetlookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/tweetlookup/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BulkList(t *testing.T) {
+	ids := []string{}
+	for i := 0; i < 150; i++ {
+		ids = append(ids, fmt.Sprintf("%d", i))
+	}
+
+	cases := []struct {
+		name    string
+		params  *types.ListInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "ok",
+			params: &types.ListInput{IDs: ids},
+		},
+		{
+			name:    "ng: error",
+			params:  &types.ListInput{IDs: ids},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "ng: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					if c.mockErr != nil {
+						return c.mockErr
+					}
+					in := p.(*types.ListInput)
+					out := i.(*types.ListOutput)
+					for _, id := range in.IDs {
+						out.Data = append(out.Data, resources.Tweet{ID: gotwi.String(id), AuthorID: gotwi.String("author")})
+					}
+					out.Errors = []resources.PartialError{{ResourceID: gotwi.String("deleted-" + in.IDs[0])}}
+					out.Includes.Users = []resources.User{{ID: gotwi.String("author")}}
+					out.Includes.Media = []resources.Media{{MediaKey: gotwi.String("media")}}
+					return nil
+				},
+			})
+
+			got, err := BulkList(context.Background(), mockClient, c.params, 2)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, got)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.Len(tt, got.Data, 150)
+			assert.Equal(tt, "0", gotwi.StringValue(got.Data[0].ID))
+			assert.Equal(tt, "149", gotwi.StringValue(got.Data[149].ID))
+			assert.Len(tt, got.Includes.Users, 1)
+			assert.Len(tt, got.Includes.Media, 1)
+			assert.Len(tt, got.Errors, 2)
+			assert.Contains(tt, got.Errors, "deleted-0")
+			assert.Contains(tt, got.Errors, "deleted-100")
+		})
+	}
+}
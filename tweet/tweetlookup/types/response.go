@@ -33,3 +33,22 @@ type GetOutput struct {
 func (r *GetOutput) HasPartialError() bool {
 	return !(r.Errors == nil || len(r.Errors) == 0)
 }
+
+// BulkListOutput is struct for the merged responses of `GET /2/tweets` for all batches of IDs.
+type BulkListOutput struct {
+	Data     []resources.Tweet
+	Includes struct {
+		Users  []resources.User
+		Tweets []resources.Tweet
+		Places []resources.Place
+		Media  []resources.Media
+		Polls  []resources.Poll
+	}
+
+	// Partial errors (e.g. not found, not authorized) keyed by the requested ID.
+	Errors map[string]resources.PartialError
+}
+
+func (r *BulkListOutput) HasPartialError() bool {
+	return len(r.Errors) > 0
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 
 	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/connection"
+	connectiontypes "github.com/michimani/gotwi/connection/types"
 	"github.com/michimani/gotwi/tweet/volumestream/types"
 )
 
 const (
-	sampleStreamEndpoint = "https://api.twitter.com/2/tweets/sample/stream"
+	sampleStreamEndpoint   = "https://api.twitter.com/2/tweets/sample/stream"
+	firehoseStreamEndpoint = "https://api.twitter.com/2/tweets/firehose/stream"
+	sample10StreamEndpoint = "https://api.twitter.com/2/tweets/sample10/stream"
 )
 
 // Streams about 1% of all Tweets in real-time.
@@ -16,7 +20,41 @@ const (
 // https://developer.twitter.com/en/docs/twitter-api/tweets/volume-streams/api-reference/get-tweets-sample-stream
 func SampleStream(ctx context.Context, c *gotwi.Client, p *types.SampleStreamInput) (*gotwi.StreamClient[*types.SampleStreamOutput], error) {
 	tc := gotwi.NewTypedClient[*types.SampleStreamOutput](c)
-	s, err := tc.CallStreamAPI(ctx, sampleStreamEndpoint, "GET", p)
+	connect := func(ctx context.Context) (*gotwi.StreamClient[*types.SampleStreamOutput], error) {
+		return tc.CallStreamAPI(ctx, sampleStreamEndpoint, "GET", p)
+	}
+
+	if p != nil && p.TerminateStaleConnections {
+		return connection.ConnectTerminatingStale(ctx, c, connectiontypes.EndpointIDSampleStream, connect)
+	}
+
+	s, err := connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Streams 100% of all public Tweets in real-time. The stream is split into 20 partitions,
+// and the partition parameter is required. Use FirehoseStreamPartitions to connect all partitions at once.
+// https://docs.x.com/x-api/posts/firehose-stream
+func FirehoseStream(ctx context.Context, c *gotwi.Client, p *types.FirehoseStreamInput) (*gotwi.StreamClient[*types.FirehoseStreamOutput], error) {
+	tc := gotwi.NewTypedClient[*types.FirehoseStreamOutput](c)
+	s, err := tc.CallStreamAPI(ctx, firehoseStreamEndpoint, "GET", p)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Streams about 10% of all public Tweets in real-time. The stream is split into 2 partitions,
+// and the partition parameter is required. Use Sample10StreamPartitions to connect all partitions at once.
+// https://docs.x.com/x-api/posts/sample-10-stream
+func Sample10Stream(ctx context.Context, c *gotwi.Client, p *types.Sample10StreamInput) (*gotwi.StreamClient[*types.Sample10StreamOutput], error) {
+	tc := gotwi.NewTypedClient[*types.Sample10StreamOutput](c)
+	s, err := tc.CallStreamAPI(ctx, sample10StreamEndpoint, "GET", p)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,224 @@
+package volumestream
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/connection"
+	connectiontypes "github.com/michimani/gotwi/connection/types"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/tweet/volumestream/types"
+)
+
+const (
+	DefaultReconnectBackoff    = time.Duration(1) * time.Second
+	DefaultMaxReconnectBackoff = time.Duration(320) * time.Second
+)
+
+// ErrPartitionDisconnected is the error of a PartitionEvent when the stream of a partition ended without an error.
+var ErrPartitionDisconnected = errors.New("stream of the partition was disconnected")
+
+// PartitionEvent is a message received from one partition of a stream.
+// If Err is not nil, the partition was disconnected or failed to connect, and it will be reconnected.
+type PartitionEvent[T util.Response] struct {
+	Partition int
+	Data      T
+	Err       error
+}
+
+type MergePartitionsInput[T util.Response] struct {
+	// Partitions is the list of partition numbers to connect. Required.
+	Partitions []int
+	// Connect opens the stream of the given partition. Required.
+	Connect func(ctx context.Context, partition int) (*gotwi.StreamClient[T], error)
+	// ReconnectBackoff is the wait before the first reconnection. It doubles on each consecutive failure.
+	ReconnectBackoff time.Duration
+	// MaxReconnectBackoff is the upper limit of the wait before reconnection.
+	MaxReconnectBackoff time.Duration
+	// BufferSize is the buffer size of the returned channel.
+	BufferSize int
+	// TerminateStale is called once before connecting the partitions if it is not nil,
+	// e.g. to terminate the connections left by a crashed consumer with connection.TerminateActiveConnections.
+	// Connect must not terminate the connections by itself, because it would also terminate the other partitions.
+	TerminateStale func(ctx context.Context) error
+}
+
+// MergePartitions connects all partitions concurrently and merges their messages into one channel.
+// Each partition is reconnected independently with exponential backoff when it is disconnected.
+// The channel is closed after ctx is done and all partitions are stopped.
+func MergePartitions[T util.Response](ctx context.Context, in *MergePartitionsInput[T]) (<-chan PartitionEvent[T], error) {
+	if in == nil {
+		return nil, errors.New("MergePartitionsInput is nil")
+	}
+	if len(in.Partitions) == 0 {
+		return nil, errors.New("MergePartitionsInput.Partitions is empty")
+	}
+	if in.Connect == nil {
+		return nil, errors.New("MergePartitionsInput.Connect is nil")
+	}
+
+	if in.TerminateStale != nil {
+		if err := in.TerminateStale(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	backoff := in.ReconnectBackoff
+	if backoff <= 0 {
+		backoff = DefaultReconnectBackoff
+	}
+	maxBackoff := in.MaxReconnectBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxReconnectBackoff
+	}
+
+	ch := make(chan PartitionEvent[T], in.BufferSize)
+	wg := sync.WaitGroup{}
+	for _, partition := range in.Partitions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runPartition(ctx, partition, in.Connect, backoff, maxBackoff, ch)
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+func runPartition[T util.Response](
+	ctx context.Context,
+	partition int,
+	connect func(ctx context.Context, partition int) (*gotwi.StreamClient[T], error),
+	initialBackoff, maxBackoff time.Duration,
+	ch chan<- PartitionEvent[T],
+) {
+	send := func(e PartitionEvent[T]) bool {
+		select {
+		case ch <- e:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	backoff := initialBackoff
+	for ctx.Err() == nil {
+		s, err := connect(ctx, partition)
+		if err == nil {
+			received := false
+			for s.Receive() {
+				t, err := s.Read()
+				if err != nil || reflect.ValueOf(t).IsZero() {
+					// Skip keep-alive signals and malformed lines.
+					continue
+				}
+				if !received {
+					// The connection is healthy again, so start over the backoff.
+					received = true
+					backoff = initialBackoff
+				}
+				if !send(PartitionEvent[T]{Partition: partition, Data: t}) {
+					break
+				}
+			}
+			err = s.Err()
+			s.Stop()
+			if err == nil {
+				err = ErrPartitionDisconnected
+			}
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if !send(PartitionEvent[T]{Partition: partition, Err: err}) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+type PartitionsOption struct {
+	ReconnectBackoff    time.Duration
+	MaxReconnectBackoff time.Duration
+	BufferSize          int
+	// TerminateStaleConnections terminates the active connections of the endpoint once before connecting the partitions,
+	// so that a restarted consumer can take over the stream without waiting for the connections to time out.
+	TerminateStaleConnections bool
+}
+
+// FirehoseStreamPartitions connects all 20 partitions of the firehose stream and merges them into one channel.
+// The Partition of p is ignored.
+func FirehoseStreamPartitions(ctx context.Context, c *gotwi.Client, p *types.FirehoseStreamInput, opt *PartitionsOption) (<-chan PartitionEvent[*types.FirehoseStreamOutput], error) {
+	if p == nil {
+		return nil, errors.New("FirehoseStreamInput is nil")
+	}
+
+	return MergePartitions(ctx, mergePartitionsInput(c, connectiontypes.EndpointIDFirehoseStream, types.FirehoseStreamPartitions, opt,
+		func(ctx context.Context, partition int) (*gotwi.StreamClient[*types.FirehoseStreamOutput], error) {
+			pp := *p
+			pp.Partition = partition
+			return FirehoseStream(ctx, c, &pp)
+		}))
+}
+
+// Sample10StreamPartitions connects both partitions of the 10% sample stream and merges them into one channel.
+// The Partition of p is ignored.
+func Sample10StreamPartitions(ctx context.Context, c *gotwi.Client, p *types.Sample10StreamInput, opt *PartitionsOption) (<-chan PartitionEvent[*types.Sample10StreamOutput], error) {
+	if p == nil {
+		return nil, errors.New("Sample10StreamInput is nil")
+	}
+
+	return MergePartitions(ctx, mergePartitionsInput(c, connectiontypes.EndpointIDSample10Stream, types.Sample10StreamPartitions, opt,
+		func(ctx context.Context, partition int) (*gotwi.StreamClient[*types.Sample10StreamOutput], error) {
+			pp := *p
+			pp.Partition = partition
+			return Sample10Stream(ctx, c, &pp)
+		}))
+}
+
+func mergePartitionsInput[T util.Response](
+	c gotwi.IClient,
+	endpointID connectiontypes.EndpointID,
+	partitions int,
+	opt *PartitionsOption,
+	connect func(ctx context.Context, partition int) (*gotwi.StreamClient[T], error),
+) *MergePartitionsInput[T] {
+	in := &MergePartitionsInput[T]{
+		Partitions: make([]int, 0, partitions),
+		Connect:    connect,
+	}
+	for i := 1; i <= partitions; i++ {
+		in.Partitions = append(in.Partitions, i)
+	}
+	if opt != nil {
+		in.ReconnectBackoff = opt.ReconnectBackoff
+		in.MaxReconnectBackoff = opt.MaxReconnectBackoff
+		in.BufferSize = opt.BufferSize
+		if opt.TerminateStaleConnections {
+			in.TerminateStale = func(ctx context.Context) error {
+				_, err := connection.TerminateActiveConnections(ctx, c, endpointID)
+				return err
+			}
+		}
+	}
+
+	return in
+}
@@ -0,0 +1,166 @@
+package volumestream_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/tweet/volumestream"
+	"github.com/michimani/gotwi/tweet/volumestream/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MergePartitions(t *testing.T) {
+	asst := assert.New(t)
+
+	mu := sync.Mutex{}
+	requests := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		partition := r.URL.Query().Get("partition")
+		mu.Lock()
+		requests[partition]++
+		n := requests[partition]
+		mu.Unlock()
+
+		// The first connection of partition 2 fails, and it is reconnected.
+		if partition == "2" && n == 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"title":"Service Unavailable"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "{\"data\":{\"id\":\"%s-%d\"}}\r\n\r\n", partition, n)
+	}))
+	defer ts.Close()
+
+	c, err := gotwi.NewClientWithAccessToken(&gotwi.NewClientWithAccessTokenInput{AccessToken: "test-token"})
+	asst.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := volumestream.MergePartitions(ctx, &volumestream.MergePartitionsInput[*types.FirehoseStreamOutput]{
+		Partitions: []int{1, 2},
+		Connect: func(ctx context.Context, partition int) (*gotwi.StreamClient[*types.FirehoseStreamOutput], error) {
+			tc := gotwi.NewTypedClient[*types.FirehoseStreamOutput](c)
+			return tc.CallStreamAPI(ctx, ts.URL, "GET", &types.FirehoseStreamInput{Partition: partition})
+		},
+		ReconnectBackoff:    time.Millisecond,
+		MaxReconnectBackoff: time.Duration(10) * time.Millisecond,
+	})
+	asst.NoError(err)
+
+	received := map[string]bool{}
+	failed := map[int]bool{}
+	for e := range ch {
+		if e.Err != nil {
+			failed[e.Partition] = true
+		} else {
+			received[*e.Data.Data.ID] = true
+		}
+
+		// Partition 1 is reconnected after the first stream ends,
+		// and partition 2 is reconnected after the first connection fails.
+		if received["1-2"] && received["2-2"] {
+			cancel()
+		}
+	}
+
+	asst.True(received["1-1"])
+	asst.False(received["2-1"])
+	asst.True(failed[1])
+	asst.True(failed[2])
+}
+
+func Test_MergePartitions_InvalidInput(t *testing.T) {
+	connect := func(ctx context.Context, partition int) (*gotwi.StreamClient[*types.FirehoseStreamOutput], error) {
+		return nil, nil
+	}
+
+	cases := []struct {
+		name string
+		in   *volumestream.MergePartitionsInput[*types.FirehoseStreamOutput]
+	}{
+		{
+			name: "nil",
+			in:   nil,
+		},
+		{
+			name: "no partitions",
+			in:   &volumestream.MergePartitionsInput[*types.FirehoseStreamOutput]{Connect: connect},
+		},
+		{
+			name: "no connect function",
+			in:   &volumestream.MergePartitionsInput[*types.FirehoseStreamOutput]{Partitions: []int{1}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			ch, err := volumestream.MergePartitions(context.Background(), c.in)
+			assert.Error(tt, err)
+			assert.Nil(tt, ch)
+		})
+	}
+}
+
+func Test_MergePartitions_TerminateStale(t *testing.T) {
+	asst := assert.New(t)
+
+	var mu sync.Mutex
+	events := []string{}
+	record := func(e string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := volumestream.MergePartitions(ctx, &volumestream.MergePartitionsInput[*types.FirehoseStreamOutput]{
+		Partitions: []int{1, 2},
+		Connect: func(ctx context.Context, partition int) (*gotwi.StreamClient[*types.FirehoseStreamOutput], error) {
+			record("connect")
+			cancel()
+			return nil, errors.New("error")
+		},
+		TerminateStale: func(ctx context.Context) error {
+			record("terminate")
+			return nil
+		},
+	})
+	asst.NoError(err)
+	for range ch {
+	}
+
+	// terminated once before any partition connects
+	mu.Lock()
+	defer mu.Unlock()
+	if asst.NotEmpty(events) {
+		asst.Equal("terminate", events[0])
+		asst.NotContains(events[1:], "terminate")
+	}
+
+	// the partitions are not connected if the termination fails
+	ch, err = volumestream.MergePartitions(context.Background(), &volumestream.MergePartitionsInput[*types.FirehoseStreamOutput]{
+		Partitions: []int{1},
+		Connect: func(ctx context.Context, partition int) (*gotwi.StreamClient[*types.FirehoseStreamOutput], error) {
+			t.Error("connected")
+			return nil, nil
+		},
+		TerminateStale: func(ctx context.Context) error {
+			return errors.New("terminate error")
+		},
+	})
+	asst.Error(err)
+	asst.Nil(ch)
+}
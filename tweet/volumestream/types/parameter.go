@@ -3,6 +3,7 @@ package types
 import (
 	"io"
 	"strconv"
+	"time"
 
 	"github.com/michimani/gotwi/fields"
 	"github.com/michimani/gotwi/internal/util"
@@ -29,6 +30,11 @@ type SampleStreamInput struct {
 	PollFields      fields.PollFieldList
 	TweetFields     fields.TweetFieldList
 	UserFields      fields.UserFieldList
+
+	// TerminateStaleConnections terminates the active connections of the endpoint and reconnects
+	// when the connection fails because the stream is at its connection limit (TooManyConnections).
+	// Do not set it on redundant connections, because they would terminate each other.
+	TerminateStaleConnections bool
 }
 
 var getQueryParameters = map[string]struct{}{
@@ -79,3 +85,156 @@ func (p *SampleStreamInput) ParameterMap() map[string]string {
 
 	return m
 }
+
+var partitionedStreamQueryParameters = map[string]struct{}{
+	"partition":        {},
+	"backfill_minutes": {},
+	"start_time":       {},
+	"end_time":         {},
+	"expansions":       {},
+	"media.fields":     {},
+	"place.fields":     {},
+	"poll.fields":      {},
+	"tweet.fields":     {},
+	"user.fields":      {},
+}
+
+type FirehoseStreamInput struct {
+	accessToken string
+
+	// Query parameters
+	Partition       int // required: 1 to 20
+	BackfillMinutes SampleStreamBackfillMinutes
+	StartTime       *time.Time
+	EndTime         *time.Time
+	Expansions      fields.ExpansionList
+	MediaFields     fields.MediaFieldList
+	PlaceFields     fields.PlaceFieldList
+	PollFields      fields.PollFieldList
+	TweetFields     fields.TweetFieldList
+	UserFields      fields.UserFieldList
+}
+
+// FirehoseStreamPartitions is the number of partitions of the firehose stream.
+const FirehoseStreamPartitions = 20
+
+func (p *FirehoseStreamInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *FirehoseStreamInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *FirehoseStreamInput) ResolveEndpoint(endpointBase string) string {
+	if p == nil || p.Partition < 1 || p.Partition > FirehoseStreamPartitions {
+		return ""
+	}
+
+	endpoint := endpointBase
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, partitionedStreamQueryParameters)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func (p *FirehoseStreamInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *FirehoseStreamInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+	m = fields.SetFieldsParams(m, p.Expansions, p.MediaFields, p.PlaceFields, p.PollFields, p.TweetFields, p.UserFields)
+
+	if p.Partition > 0 {
+		m["partition"] = strconv.Itoa(p.Partition)
+	}
+
+	if p.BackfillMinutes.Valid() {
+		m["backfill_minutes"] = p.BackfillMinutes.String()
+	}
+
+	if p.StartTime != nil {
+		m["start_time"] = p.StartTime.Format(time.RFC3339)
+	}
+
+	if p.EndTime != nil {
+		m["end_time"] = p.EndTime.Format(time.RFC3339)
+	}
+
+	return m
+}
+
+type Sample10StreamInput struct {
+	accessToken string
+
+	// Query parameters
+	Partition       int // required: 1 to 2
+	BackfillMinutes SampleStreamBackfillMinutes
+	StartTime       *time.Time
+	EndTime         *time.Time
+	Expansions      fields.ExpansionList
+	MediaFields     fields.MediaFieldList
+	PlaceFields     fields.PlaceFieldList
+	PollFields      fields.PollFieldList
+	TweetFields     fields.TweetFieldList
+	UserFields      fields.UserFieldList
+}
+
+// Sample10StreamPartitions is the number of partitions of the 10% sample stream.
+const Sample10StreamPartitions = 2
+
+func (p *Sample10StreamInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *Sample10StreamInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *Sample10StreamInput) ResolveEndpoint(endpointBase string) string {
+	if p == nil || p.Partition < 1 || p.Partition > Sample10StreamPartitions {
+		return ""
+	}
+
+	endpoint := endpointBase
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, partitionedStreamQueryParameters)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func (p *Sample10StreamInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *Sample10StreamInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+	m = fields.SetFieldsParams(m, p.Expansions, p.MediaFields, p.PlaceFields, p.PollFields, p.TweetFields, p.UserFields)
+
+	if p.Partition > 0 {
+		m["partition"] = strconv.Itoa(p.Partition)
+	}
+
+	if p.BackfillMinutes.Valid() {
+		m["backfill_minutes"] = p.BackfillMinutes.String()
+	}
+
+	if p.StartTime != nil {
+		m["start_time"] = p.StartTime.Format(time.RFC3339)
+	}
+
+	if p.EndTime != nil {
+		m["end_time"] = p.EndTime.Format(time.RFC3339)
+	}
+
+	return m
+}
@@ -2,6 +2,7 @@ package types_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/michimani/gotwi/fields"
 	"github.com/michimani/gotwi/tweet/volumestream/types"
@@ -154,3 +155,91 @@ func Test_SampleStreamInput_Body(t *testing.T) {
 		})
 	}
 }
+
+func Test_FirehoseStreamInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint/"
+	startTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		name   string
+		params *types.FirehoseStreamInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.FirehoseStreamInput{Partition: 20},
+			expect: endpoint + "?partition=20",
+		},
+		{
+			name: "all query parameters",
+			params: &types.FirehoseStreamInput{
+				Partition:       1,
+				BackfillMinutes: types.SampleStreamBackfillMinutes(3),
+				StartTime:       &startTime,
+				EndTime:         &startTime,
+				Expansions:      fields.ExpansionList{"ex"},
+				MediaFields:     fields.MediaFieldList{"mf"},
+				PlaceFields:     fields.PlaceFieldList{"plf"},
+				PollFields:      fields.PollFieldList{"pof"},
+				UserFields:      fields.UserFieldList{"uf"},
+				TweetFields:     fields.TweetFieldList{"tf"},
+			},
+			expect: endpoint + "?backfill_minutes=3&end_time=2026-01-02T03%3A04%3A05Z&expansions=ex&media.fields=mf&partition=1&place.fields=plf&poll.fields=pof&start_time=2026-01-02T03%3A04%3A05Z&tweet.fields=tf&user.fields=uf",
+		},
+		{
+			name:   "partition is out of range",
+			params: &types.FirehoseStreamInput{Partition: 21},
+			expect: "",
+		},
+		{
+			name:   "partition is not set",
+			params: &types.FirehoseStreamInput{},
+			expect: "",
+		},
+		{
+			name:   "nil",
+			params: nil,
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			ep := c.params.ResolveEndpoint(endpoint)
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
+
+func Test_Sample10StreamInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint/"
+
+	cases := []struct {
+		name   string
+		params *types.Sample10StreamInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.Sample10StreamInput{Partition: 2, BackfillMinutes: types.SampleStreamBackfillMinutes(1)},
+			expect: endpoint + "?backfill_minutes=1&partition=2",
+		},
+		{
+			name:   "partition is out of range",
+			params: &types.Sample10StreamInput{Partition: 3},
+			expect: "",
+		},
+		{
+			name:   "nil",
+			params: nil,
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			ep := c.params.ResolveEndpoint(endpoint)
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
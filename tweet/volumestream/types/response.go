@@ -17,3 +17,35 @@ type SampleStreamOutput struct {
 func (r *SampleStreamOutput) HasPartialError() bool {
 	return !(r.Errors == nil || len(r.Errors) == 0)
 }
+
+type FirehoseStreamOutput struct {
+	Data     resources.Tweet `json:"data"`
+	Includes struct {
+		Users  []resources.User  `json:"users,omitempty"`
+		Tweets []resources.Tweet `json:"tweets,omitempty"`
+		Places []resources.Place `json:"places,omitempty"`
+		Media  []resources.Media `json:"media,omitempty"`
+		Polls  []resources.Poll  `json:"polls,omitempty"`
+	} `json:"includes,omitempty"`
+	Errors []resources.PartialError `json:"errors,omitempty"`
+}
+
+func (r *FirehoseStreamOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+type Sample10StreamOutput struct {
+	Data     resources.Tweet `json:"data"`
+	Includes struct {
+		Users  []resources.User  `json:"users,omitempty"`
+		Tweets []resources.Tweet `json:"tweets,omitempty"`
+		Places []resources.Place `json:"places,omitempty"`
+		Media  []resources.Media `json:"media,omitempty"`
+		Polls  []resources.Poll  `json:"polls,omitempty"`
+	} `json:"includes,omitempty"`
+	Errors []resources.PartialError `json:"errors,omitempty"`
+}
+
+func (r *Sample10StreamOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
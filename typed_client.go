@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/michimani/gotwi/internal/util"
 	"github.com/michimani/gotwi/resources"
@@ -11,6 +12,9 @@ import (
 
 type TypedClient[T util.Response] struct {
 	Client               *http.Client
+	streamIdleTimeout    time.Duration
+	gzip                 bool
+	transferMetrics      *TransferMetrics
 	accessToken          string
 	authenticationMethod AuthenticationMethod
 	oauthToken           string
@@ -24,7 +28,10 @@ func NewTypedClient[T util.Response](c *Client) *TypedClient[T] {
 	}
 
 	return &TypedClient[T]{
-		Client:               c.Client,
+		Client:               c.streamHTTPClient(),
+		streamIdleTimeout:    c.streamIdleTimeout,
+		gzip:                 c.gzip,
+		transferMetrics:      c.transferMetrics,
 		accessToken:          c.AccessToken(),
 		authenticationMethod: c.AuthenticationMethod(),
 		oauthToken:           c.OAuthToken(),
@@ -69,6 +76,12 @@ func (c *TypedClient[T]) AuthenticationMethod() AuthenticationMethod {
 	return c.authenticationMethod
 }
 
+// TransferMetrics returns the byte counts of response bodies received with gzip enabled.
+// It is shared with the Client that the TypedClient was created from.
+func (c *TypedClient[T]) TransferMetrics() *TransferMetrics {
+	return c.transferMetrics
+}
+
 func (c *TypedClient[T]) OAuthToken() string {
 	return c.oauthToken
 }
@@ -94,6 +107,10 @@ func (c *TypedClient[T]) CallStreamAPI(ctx context.Context, endpoint, method str
 		return nil, wrapWithAPIErr(non200err)
 	}
 
+	if c.streamIdleTimeout > 0 {
+		res.Body = newIdleTimeoutReader(res.Body, c.streamIdleTimeout)
+	}
+
 	s, err := newStreamClient[T](res)
 	if err != nil {
 		return nil, err
@@ -103,11 +120,19 @@ func (c *TypedClient[T]) CallStreamAPI(ctx context.Context, endpoint, method str
 }
 
 func (c *TypedClient[T]) ExecStream(req *http.Request) (*http.Response, *resources.Non2XXError, error) {
+	if c.gzip {
+		setAcceptEncoding(req)
+	}
+
 	res, err := c.Client.Do(req)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	if c.gzip {
+		decodeResponseBody(res, c.transferMetrics)
+	}
+
 	if _, ok := okCodes[res.StatusCode]; !ok {
 		defer res.Body.Close()
 		non200err, err := resolveNon2XXResponse(res)
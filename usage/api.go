@@ -0,0 +1,19 @@
+package usage
+
+import (
+	"context"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/usage/types"
+)
+
+const (
+	getTweetsEndpoint = "https://api.twitter.com/2/usage/tweets"
+)
+
+// Returns the Post consumption of the Project, such as the daily usage, the cap and the day the cap resets.
+// The client must use OAuth 2.0 App-only (Bearer token).
+// https://docs.x.com/x-api/usage/get-usage
+func GetTweets(ctx context.Context, c gotwi.IClient, p *types.GetTweetsInput, opts ...gotwi.CallOption) (*types.GetTweetsOutput, error) {
+	return gotwi.Do[*types.GetTweetsOutput](ctx, c, "GET", getTweetsEndpoint, p, opts...)
+}
@@ -0,0 +1,7 @@
+package usage
+
+import "time"
+
+func ExportSetNow(g *Guard, now func() time.Time) {
+	g.now = now
+}
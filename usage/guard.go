@@ -0,0 +1,324 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/usage/types"
+)
+
+const (
+	DefaultRefreshInterval = time.Duration(15) * time.Minute
+	// DefaultRefreshRetryBackoff is the wait before consulting the usage endpoint again after a failure.
+	// It doubles on each consecutive failure, up to the refresh interval.
+	DefaultRefreshRetryBackoff = time.Duration(1) * time.Minute
+
+	// defaultEstimate is the number of Posts assumed for a read without max_results,
+	// which is the default page size of the search and timelines endpoints.
+	defaultEstimate = 10
+)
+
+// ErrBudgetExceeded is returned by Guard when a read is projected to exceed the budget.
+var ErrBudgetExceeded = errors.New("usage: post read budget exceeded")
+
+// DefaultReadEndpoints returns the endpoints counted by Guard by default:
+// search, timelines, Post and user lookups (including the bulk lookups), communities and trends.
+// The endpoints are the ones before resolving the path parameters, as passed to gotwi.IClient.CallAPI.
+func DefaultReadEndpoints() []string {
+	return []string{
+		"https://api.twitter.com/2/tweets/search/recent",
+		"https://api.twitter.com/2/tweets/search/all",
+		"https://api.twitter.com/2/users/:id/tweets",
+		"https://api.twitter.com/2/users/:id/mentions",
+		"https://api.twitter.com/2/users/:id/timelines/reverse_chronological",
+		"https://api.twitter.com/2/tweets",
+		"https://api.twitter.com/2/tweets/:id",
+		"https://api.twitter.com/2/users",
+		"https://api.twitter.com/2/users/:id",
+		"https://api.twitter.com/2/users/by",
+		"https://api.twitter.com/2/users/by/username/:username",
+		"https://api.twitter.com/2/users/search",
+		"https://api.twitter.com/2/communities/:id",
+		"https://api.twitter.com/2/communities/search",
+		"https://api.twitter.com/2/trends/by/woeid/:woeid",
+		"https://api.twitter.com/2/users/personalized_trends",
+	}
+}
+
+type GuardMode int
+
+const (
+	// GuardModeRefuse makes Guard return ErrBudgetExceeded without calling the API.
+	GuardModeRefuse GuardMode = iota
+	// GuardModeWarn makes Guard call OnWarn and the API.
+	GuardModeWarn
+)
+
+type NewGuardInput struct {
+	// Client is the client to call the API with. Required.
+	Client gotwi.IClient
+	// UsageClient is the client to call the usage endpoint with, which requires OAuth 2.0 App-only.
+	// It may be nil if Client uses OAuth 2.0 bearer token, and then Client is used.
+	UsageClient gotwi.IClient
+	// Budget is the number of Posts that may be read in the current cap period. Required.
+	Budget int64
+	// RefreshInterval is the interval to consult the usage endpoint. The default is DefaultRefreshInterval.
+	RefreshInterval time.Duration
+	Mode            GuardMode
+	// OnWarn is called when a read is projected to exceed the budget in GuardModeWarn.
+	OnWarn func(ctx context.Context, s Status)
+	// OnRefreshError is called when consulting the usage endpoint fails before a read.
+	// The read is checked with the local count, and the usage endpoint is consulted again after a backoff.
+	OnRefreshError func(ctx context.Context, err error)
+	// ReadEndpoints are the endpoints whose results are counted, e.g. append(usage.DefaultReadEndpoints(), endpoint).
+	// Only GET requests are counted. The default is DefaultReadEndpoints().
+	ReadEndpoints []string
+}
+
+// Status is the consumption known by Guard.
+type Status struct {
+	// Used is the project usage reported by the usage endpoint plus the Posts read since then.
+	Used int64
+	// Projected is Used plus the estimated number of Posts of the read being checked.
+	Projected int64
+	Budget    int64
+	// Cap is the project cap reported by the usage endpoint. It is 0 until the usage endpoint is consulted.
+	Cap         int64
+	RefreshedAt time.Time
+}
+
+// Guard is a gotwi.IClient that counts the resources returned by the read endpoints (see DefaultReadEndpoints)
+// and refuses or warns on reads projected to exceed the budget.
+// The local count is replaced with the usage endpoint's value on every refresh.
+// If the refresh fails, Guard keeps counting locally and retries with exponential backoff.
+type Guard struct {
+	gotwi.IClient
+	usageClient     gotwi.IClient
+	budget          int64
+	refreshInterval time.Duration
+	mode            GuardMode
+	onWarn          func(ctx context.Context, s Status)
+	onRefreshError  func(ctx context.Context, err error)
+	readEndpoints   map[string]struct{}
+	now             func() time.Time
+
+	mu              sync.Mutex
+	reported        int64
+	counted         int64
+	cap             int64
+	refreshedAt     time.Time
+	refreshFailures int
+	retryAt         time.Time
+}
+
+func NewGuard(in *NewGuardInput) (*Guard, error) {
+	if in == nil {
+		return nil, errors.New("NewGuardInput is nil")
+	}
+	if in.Client == nil {
+		return nil, errors.New("NewGuardInput.Client is nil")
+	}
+	if in.Budget <= 0 {
+		return nil, errors.New("NewGuardInput.Budget must be greater than 0")
+	}
+	if in.UsageClient == nil && in.Client.AuthenticationMethod() != gotwi.AuthenMethodOAuth2BearerToken {
+		return nil, errors.New("NewGuardInput.UsageClient is required because the usage endpoint requires OAuth 2.0 App-only")
+	}
+
+	g := &Guard{
+		IClient:         in.Client,
+		usageClient:     in.UsageClient,
+		budget:          in.Budget,
+		refreshInterval: in.RefreshInterval,
+		mode:            in.Mode,
+		onWarn:          in.OnWarn,
+		onRefreshError:  in.OnRefreshError,
+		readEndpoints:   map[string]struct{}{},
+		now:             time.Now,
+	}
+	readEndpoints := in.ReadEndpoints
+	if readEndpoints == nil {
+		readEndpoints = DefaultReadEndpoints()
+	}
+	for _, e := range readEndpoints {
+		g.readEndpoints[e] = struct{}{}
+	}
+	if g.usageClient == nil {
+		g.usageClient = in.Client
+	}
+	if g.refreshInterval <= 0 {
+		g.refreshInterval = DefaultRefreshInterval
+	}
+
+	return g, nil
+}
+
+func (g *Guard) CallAPI(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+	if _, ok := g.readEndpoints[endpoint]; !ok || method != http.MethodGet {
+		return g.IClient.CallAPI(ctx, endpoint, method, p, i)
+	}
+
+	g.refreshIfStale(ctx)
+
+	s := g.status(estimate(endpoint, p))
+	if s.Projected > s.Budget {
+		if g.mode == GuardModeRefuse {
+			return fmt.Errorf("%w: used=%d projected=%d budget=%d", ErrBudgetExceeded, s.Used, s.Projected, s.Budget)
+		}
+		if g.onWarn != nil {
+			g.onWarn(ctx, s)
+		}
+	}
+
+	if err := g.IClient.CallAPI(ctx, endpoint, method, p, i); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	g.counted += int64(countData(i))
+	g.mu.Unlock()
+
+	return nil
+}
+
+// Status returns the consumption known by Guard.
+func (g *Guard) Status() Status {
+	return g.status(0)
+}
+
+// Refresh consults the usage endpoint and replaces the local count with the project usage.
+func (g *Guard) Refresh(ctx context.Context) error {
+	res, err := GetTweets(ctx, g.usageClient, &types.GetTweetsInput{
+		UsageFields: fields.UsageFieldList{fields.UsageFieldProjectUsage, fields.UsageFieldProjectCap},
+	})
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.reported = parseCount(res.Data.ProjectUsage)
+	g.cap = parseCount(res.Data.ProjectCap)
+	g.counted = 0
+	g.refreshedAt = g.now()
+	g.refreshFailures = 0
+	g.retryAt = time.Time{}
+
+	return nil
+}
+
+// refreshIfStale refreshes the usage if the refresh interval has passed.
+// On failure, it reports the error to OnRefreshError and backs off, so the reads go on with the local count.
+func (g *Guard) refreshIfStale(ctx context.Context) {
+	g.mu.Lock()
+	t := g.now()
+	stale := t.Sub(g.refreshedAt) >= g.refreshInterval && !t.Before(g.retryAt)
+	g.mu.Unlock()
+
+	if !stale {
+		return
+	}
+
+	err := g.Refresh(ctx)
+	if err == nil {
+		return
+	}
+
+	g.mu.Lock()
+	g.refreshFailures++
+	backoff := DefaultRefreshRetryBackoff
+	for i := 1; i < g.refreshFailures && backoff < g.refreshInterval; i++ {
+		backoff *= 2
+	}
+	g.retryAt = t.Add(min(backoff, g.refreshInterval))
+	g.mu.Unlock()
+
+	if g.onRefreshError != nil {
+		g.onRefreshError(ctx, err)
+	}
+}
+
+func (g *Guard) status(estimated int64) Status {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	used := g.reported + g.counted
+	return Status{
+		Used:        used,
+		Projected:   used + estimated,
+		Budget:      g.budget,
+		Cap:         g.cap,
+		RefreshedAt: g.refreshedAt,
+	}
+}
+
+// estimate returns the number of resources a read may return.
+func estimate(endpoint string, p util.Parameters) int64 {
+	pm := p.ParameterMap()
+	for _, name := range []string{"max_results", "max_trends"} {
+		if mr, err := strconv.ParseInt(pm[name], 10, 64); err == nil && mr > 0 {
+			return mr
+		}
+	}
+	for _, name := range []string{"ids", "usernames"} {
+		if list := pm[name]; list != "" {
+			return int64(strings.Count(list, ",") + 1)
+		}
+	}
+
+	// a lookup by a path parameter, e.g. /2/tweets/:id, except the trends of a location, which are a list
+	if strings.HasPrefix(path.Base(endpoint), ":") && !strings.Contains(endpoint, "/trends/") {
+		return 1
+	}
+
+	return defaultEstimate
+}
+
+// countData returns the number of resources in the Data field of a response.
+func countData(i util.Response) int {
+	v := reflect.ValueOf(i)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return 0
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return 0
+	}
+
+	data := v.FieldByName("Data")
+	switch {
+	case !data.IsValid():
+		return 0
+	case data.Kind() == reflect.Slice:
+		return data.Len()
+	case data.IsZero():
+		return 0
+	default:
+		return 1
+	}
+}
+
+func parseCount(s *string) int64 {
+	if s == nil {
+		return 0
+	}
+	n, err := strconv.ParseInt(*s, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
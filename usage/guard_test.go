@@ -0,0 +1,239 @@
+package usage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/managetweet"
+	managetweettypes "github.com/michimani/gotwi/tweet/managetweet/types"
+	"github.com/michimani/gotwi/tweet/searchtweet"
+	searchtweettypes "github.com/michimani/gotwi/tweet/searchtweet/types"
+	"github.com/michimani/gotwi/tweet/tweetlookup"
+	tweetlookuptypes "github.com/michimani/gotwi/tweet/tweetlookup/types"
+	"github.com/michimani/gotwi/usage"
+	"github.com/michimani/gotwi/usage/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func bearerToken() gotwi.AuthenticationMethod {
+	return gotwi.AuthenMethodOAuth2BearerToken
+}
+
+func newGuardMockClient(projectUsage string, tweets int, calls *int) *gotwi.MockGotwiClient {
+	return gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+		MockAuthenticationMethod: bearerToken,
+		MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+			switch res := i.(type) {
+			case *types.GetTweetsOutput:
+				res.Data.ProjectUsage = &projectUsage
+				return nil
+			case *searchtweettypes.ListRecentOutput:
+				res.Data = make([]resources.Tweet, tweets)
+			case *tweetlookuptypes.GetOutput:
+				id := "1"
+				res.Data.ID = &id
+			}
+			*calls++
+			return nil
+		},
+	})
+}
+
+func Test_Guard(t *testing.T) {
+	asst := assert.New(t)
+
+	calls := 0
+	g, err := usage.NewGuard(&usage.NewGuardInput{
+		Client: newGuardMockClient("80", 10, &calls),
+		Budget: 100,
+	})
+	asst.NoError(err)
+
+	// 80 + 10 (default estimate) <= 100
+	_, err = searchtweet.ListRecent(context.Background(), g, &searchtweettypes.ListRecentInput{Query: "q"})
+	asst.NoError(err)
+	asst.Equal(int64(90), g.Status().Used)
+
+	// 90 + 1 <= 100
+	_, err = tweetlookup.Get(context.Background(), g, &tweetlookuptypes.GetInput{ID: "1"})
+	asst.NoError(err)
+	asst.Equal(int64(91), g.Status().Used)
+
+	// 91 + 10 > 100
+	_, err = searchtweet.ListRecent(context.Background(), g, &searchtweettypes.ListRecentInput{Query: "q"})
+	asst.ErrorIs(err, usage.ErrBudgetExceeded)
+	asst.Equal(2, calls)
+
+	// other endpoints are not guarded
+	_, err = managetweet.Create(context.Background(), g, &managetweettypes.CreateInput{})
+	asst.NoError(err)
+	asst.Equal(3, calls)
+
+	// refreshing replaces the local count
+	asst.NoError(g.Refresh(context.Background()))
+	asst.Equal(int64(80), g.Status().Used)
+}
+
+func Test_Guard_Warn(t *testing.T) {
+	asst := assert.New(t)
+
+	calls := 0
+	warned := []usage.Status{}
+	g, err := usage.NewGuard(&usage.NewGuardInput{
+		Client: newGuardMockClient("95", 20, &calls),
+		Budget: 100,
+		Mode:   usage.GuardModeWarn,
+		OnWarn: func(ctx context.Context, s usage.Status) {
+			warned = append(warned, s)
+		},
+	})
+	asst.NoError(err)
+
+	_, err = searchtweet.ListRecent(context.Background(), g, &searchtweettypes.ListRecentInput{Query: "q", MaxResults: 20})
+	asst.NoError(err)
+	asst.Equal(1, calls)
+	asst.Len(warned, 1)
+	asst.Equal(int64(115), warned[0].Projected)
+	asst.Equal(int64(115), g.Status().Used)
+}
+
+func Test_Guard_RefreshError(t *testing.T) {
+	asst := assert.New(t)
+
+	current := time.Unix(1700000000, 0)
+
+	refreshes := 0
+	usageClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+		MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+			refreshes++
+			return errors.New("error")
+		},
+	})
+
+	calls := 0
+	refreshErrs := []error{}
+	g, err := usage.NewGuard(&usage.NewGuardInput{
+		Client:      newGuardMockClient("0", 10, &calls),
+		UsageClient: usageClient,
+		Budget:      25,
+		OnRefreshError: func(ctx context.Context, err error) {
+			refreshErrs = append(refreshErrs, err)
+		},
+	})
+	asst.NoError(err)
+	usage.ExportSetNow(g, func() time.Time { return current })
+
+	// the read goes on with the local count, and the usage endpoint is not consulted again until the backoff
+	for range 2 {
+		_, err = searchtweet.ListRecent(context.Background(), g, &searchtweettypes.ListRecentInput{Query: "q"})
+		asst.NoError(err)
+	}
+	asst.Equal(2, calls)
+	asst.Equal(1, refreshes)
+	asst.Len(refreshErrs, 1)
+	asst.Equal(int64(20), g.Status().Used)
+
+	// the budget is still enforced with the local count: 20 + 10 > 25
+	_, err = searchtweet.ListRecent(context.Background(), g, &searchtweettypes.ListRecentInput{Query: "q"})
+	asst.ErrorIs(err, usage.ErrBudgetExceeded)
+	asst.Equal(1, refreshes)
+
+	// retried after the backoff, which doubles on the consecutive failure
+	current = current.Add(usage.DefaultRefreshRetryBackoff)
+	_, _ = tweetlookup.Get(context.Background(), g, &tweetlookuptypes.GetInput{ID: "1"})
+	asst.Equal(2, refreshes)
+
+	current = current.Add(usage.DefaultRefreshRetryBackoff)
+	_, _ = tweetlookup.Get(context.Background(), g, &tweetlookuptypes.GetInput{ID: "1"})
+	asst.Equal(2, refreshes)
+
+	current = current.Add(usage.DefaultRefreshRetryBackoff)
+	_, _ = tweetlookup.Get(context.Background(), g, &tweetlookuptypes.GetInput{ID: "1"})
+	asst.Equal(3, refreshes)
+	asst.Len(refreshErrs, 3)
+}
+
+func Test_Guard_ReadEndpoints(t *testing.T) {
+	asst := assert.New(t)
+
+	calls := 0
+	g, err := usage.NewGuard(&usage.NewGuardInput{
+		Client:        newGuardMockClient("0", 10, &calls),
+		Budget:        100,
+		ReadEndpoints: []string{"https://api.twitter.com/2/tweets/search/recent"},
+	})
+	asst.NoError(err)
+
+	_, err = searchtweet.ListRecent(context.Background(), g, &searchtweettypes.ListRecentInput{Query: "q"})
+	asst.NoError(err)
+	asst.Equal(int64(10), g.Status().Used)
+
+	// not in the read endpoints
+	_, err = tweetlookup.Get(context.Background(), g, &tweetlookuptypes.GetInput{ID: "1"})
+	asst.NoError(err)
+	asst.Equal(int64(10), g.Status().Used)
+	asst.Equal(2, calls)
+
+	asst.Contains(usage.DefaultReadEndpoints(), "https://api.twitter.com/2/communities/search")
+}
+
+func Test_NewGuard(t *testing.T) {
+	mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{MockAuthenticationMethod: bearerToken})
+	userContextClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+		MockAuthenticationMethod: func() gotwi.AuthenticationMethod { return gotwi.AuthenMethodOAuth1UserContext },
+	})
+
+	cases := []struct {
+		name    string
+		in      *usage.NewGuardInput
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			in:   &usage.NewGuardInput{Client: mockClient, Budget: 1},
+		},
+		{
+			name: "ok: user context client with usage client",
+			in:   &usage.NewGuardInput{Client: userContextClient, UsageClient: mockClient, Budget: 1},
+		},
+		{
+			name:    "error: user context client without usage client",
+			in:      &usage.NewGuardInput{Client: userContextClient, Budget: 1},
+			wantErr: true,
+		},
+		{
+			name:    "error: no budget",
+			in:      &usage.NewGuardInput{Client: mockClient},
+			wantErr: true,
+		},
+		{
+			name:    "error: no client",
+			in:      &usage.NewGuardInput{Budget: 1},
+			wantErr: true,
+		},
+		{
+			name:    "error: nil",
+			in:      nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			g, err := usage.NewGuard(c.in)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, g)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, g)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/internal/util"
+)
+
+type GetTweetsDays int
+
+func (d GetTweetsDays) Valid() bool {
+	return d >= 1 && d <= 90
+}
+
+func (d GetTweetsDays) String() string {
+	return strconv.Itoa(int(d))
+}
+
+type GetTweetsInput struct {
+	accessToken string
+
+	// Query parameters
+	Days        GetTweetsDays
+	UsageFields fields.UsageFieldList
+}
+
+var getTweetsQueryParameters = map[string]struct{}{
+	"days":         {},
+	"usage.fields": {},
+}
+
+func (p *GetTweetsInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *GetTweetsInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *GetTweetsInput) ResolveEndpoint(endpointBase string) string {
+	endpoint := endpointBase
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, getTweetsQueryParameters)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func (p *GetTweetsInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *GetTweetsInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+	m = fields.SetFieldsParams(m, p.UsageFields)
+
+	if p.Days.Valid() {
+		m["days"] = p.Days.String()
+	}
+
+	return m
+}
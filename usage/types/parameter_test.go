@@ -0,0 +1,44 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/usage/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetTweetsInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint"
+
+	cases := []struct {
+		name   string
+		params *types.GetTweetsInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.GetTweetsInput{},
+			expect: endpoint,
+		},
+		{
+			name: "all query parameters",
+			params: &types.GetTweetsInput{
+				Days:        30,
+				UsageFields: fields.UsageFieldList{fields.UsageFieldProjectCap, fields.UsageFieldProjectUsage},
+			},
+			expect: endpoint + "?days=30&usage.fields=project_cap%2Cproject_usage",
+		},
+		{
+			name:   "invalid days",
+			params: &types.GetTweetsInput{Days: 91},
+			expect: endpoint,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, c.params.ResolveEndpoint(endpoint))
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package types
+
+import "github.com/michimani/gotwi/resources"
+
+type GetTweetsOutput struct {
+	Data   resources.Usage          `json:"data"`
+	Errors []resources.PartialError `json:"errors"`
+}
+
+func (r *GetTweetsOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
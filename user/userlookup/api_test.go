@@ -311,3 +311,64 @@ func Test_GetMe(t *testing.T) {
 		})
 	}
 }
+
+func Test_Search(t *testing.T) {
+	cases := []struct {
+		name    string
+		client  gotwi.IClient
+		params  *types.SearchInput
+		wantErr bool
+	}{
+		{
+			name: "success",
+			client: gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return nil
+				},
+			}),
+			params: &types.SearchInput{
+				Query: "gopher",
+			},
+			wantErr: false,
+		},
+		{
+			name: "error",
+			client: gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return fmt.Errorf("CallAPI error")
+				},
+			}),
+			params: &types.SearchInput{
+				Query: "gopher",
+			},
+			wantErr: true,
+		},
+		{
+			name: "error: params is nil",
+			client: gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					return nil
+				},
+			}),
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			ctx := context.Background()
+			res, err := Search(ctx, c.client, c.params)
+
+			if c.wantErr {
+				asst.Error(err)
+				asst.Nil(res)
+				return
+			}
+
+			asst.NoError(err)
+			asst.NotNil(res)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package userlookup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/bulk"
+	"github.com/michimani/gotwi/internal/gotwierrors"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/user/userlookup/types"
+)
+
+// maxBatchSize is the maximum number of IDs or usernames for one request.
+const maxBatchSize = 100
+
+// BulkList calls `GET /2/users` for every 100 IDs concurrently, and merges the responses.
+// IDs of the input can be more than 100. Other parameters of the input are used for all requests.
+// parallelism is the maximum number of concurrent requests. If it is 0, the default value (4) is used.
+// When the rate limit is exceeded, requests are retried after the rate limit is reset.
+func BulkList(ctx context.Context, c gotwi.IClient, p *types.ListInput, parallelism int) (*types.BulkListOutput, error) {
+	if p == nil {
+		return nil, fmt.Errorf(gotwierrors.ErrorParametersNil, listEndpoint)
+	}
+
+	results, err := bulk.Run(ctx, p.IDs, maxBatchSize, parallelism, func(ctx context.Context, ids []string) (*types.ListOutput, error) {
+		in := &types.ListInput{
+			IDs:         ids,
+			Expansions:  p.Expansions,
+			TweetFields: p.TweetFields,
+			UserFields:  p.UserFields,
+		}
+		return List(ctx, c, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &types.BulkListOutput{Errors: map[string]resources.PartialError{}}
+	for _, res := range results {
+		out.Data = append(out.Data, res.Data...)
+		out.Includes.Tweets = append(out.Includes.Tweets, res.Includes.Tweets...)
+		for _, e := range res.Errors {
+			out.Errors[bulk.ErrorKey(e)] = e
+		}
+	}
+	out.Includes.Tweets = bulk.UniqueBy(out.Includes.Tweets, func(t resources.Tweet) *string { return t.ID })
+
+	return out, nil
+}
+
+// BulkListByUsernames calls `GET /2/users/by` for every 100 usernames concurrently, and merges the responses.
+// Usernames of the input can be more than 100. Other parameters of the input are used for all requests.
+// parallelism is the maximum number of concurrent requests. If it is 0, the default value (4) is used.
+// When the rate limit is exceeded, requests are retried after the rate limit is reset.
+func BulkListByUsernames(ctx context.Context, c gotwi.IClient, p *types.ListByUsernamesInput, parallelism int) (*types.BulkListByUsernamesOutput, error) {
+	if p == nil {
+		return nil, fmt.Errorf(gotwierrors.ErrorParametersNil, listByUsernamesEndpoint)
+	}
+
+	results, err := bulk.Run(ctx, p.Usernames, maxBatchSize, parallelism, func(ctx context.Context, usernames []string) (*types.ListByUsernamesOutput, error) {
+		in := &types.ListByUsernamesInput{
+			Usernames:   usernames,
+			Expansions:  p.Expansions,
+			TweetFields: p.TweetFields,
+			UserFields:  p.UserFields,
+		}
+		return ListByUsernames(ctx, c, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &types.BulkListByUsernamesOutput{Errors: map[string]resources.PartialError{}}
+	for _, res := range results {
+		out.Data = append(out.Data, res.Data...)
+		out.Includes.Tweets = append(out.Includes.Tweets, res.Includes.Tweets...)
+		for _, e := range res.Errors {
+			out.Errors[bulk.ErrorKey(e)] = e
+		}
+	}
+	out.Includes.Tweets = bulk.UniqueBy(out.Includes.Tweets, func(t resources.Tweet) *string { return t.ID })
+
+	return out, nil
+}
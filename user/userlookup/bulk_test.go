@@ -0,0 +1,125 @@
+package userlookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/user/userlookup/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func ids(n int) []string {
+	s := []string{}
+	for i := 0; i < n; i++ {
+		s = append(s, fmt.Sprintf("%d", i))
+	}
+	return s
+}
+
+func Test_BulkList(t *testing.T) {
+	cases := []struct {
+		name        string
+		params      *types.ListInput
+		mockErr     error
+		expectCalls int
+		expectUsers int
+		wantErr     bool
+	}{
+		{
+			name:        "ok: more than 100 IDs",
+			params:      &types.ListInput{IDs: ids(250), UserFields: fields.UserFieldList{fields.UserFieldCreatedAt}},
+			expectCalls: 3,
+			expectUsers: 249,
+		},
+		{
+			name:        "ok: duplicated IDs are requested once",
+			params:      &types.ListInput{IDs: append(ids(100), ids(100)...)},
+			expectCalls: 1,
+			expectUsers: 99,
+		},
+		{
+			name:    "ng: error",
+			params:  &types.ListInput{IDs: ids(10)},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "ng: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			calls := 0
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					if c.mockErr != nil {
+						return c.mockErr
+					}
+					in := p.(*types.ListInput)
+					assert.LessOrEqual(tt, len(in.IDs), 100)
+					assert.Equal(tt, c.params.UserFields, in.UserFields)
+
+					out := i.(*types.ListOutput)
+					for _, id := range in.IDs {
+						if id == "0" {
+							out.Errors = append(out.Errors, resources.PartialError{Value: gotwi.String(id), Title: gotwi.String("Not Found Error")})
+							continue
+						}
+						out.Data = append(out.Data, resources.User{ID: gotwi.String(id)})
+					}
+					out.Includes.Tweets = []resources.Tweet{{ID: gotwi.String("pinned")}}
+					calls++
+					return nil
+				},
+			})
+
+			got, err := BulkList(context.Background(), mockClient, c.params, 1)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, got)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.Equal(tt, c.expectCalls, calls)
+			assert.Len(tt, got.Data, c.expectUsers)
+			assert.Len(tt, got.Includes.Tweets, 1)
+			assert.True(tt, got.HasPartialError())
+			assert.Equal(tt, "Not Found Error", gotwi.StringValue(got.Errors["0"].Title))
+		})
+	}
+}
+
+func Test_BulkListByUsernames(t *testing.T) {
+	var calls int32
+	mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+		MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+			in := p.(*types.ListByUsernamesInput)
+			out := i.(*types.ListByUsernamesOutput)
+			for _, u := range in.Usernames {
+				out.Data = append(out.Data, resources.User{Username: gotwi.String(u)})
+			}
+			atomic.AddInt32(&calls, 1)
+			return nil
+		},
+	})
+
+	got, err := BulkListByUsernames(context.Background(), mockClient, &types.ListByUsernamesInput{Usernames: ids(201)}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), calls)
+	assert.Len(t, got.Data, 201)
+	assert.False(t, got.HasPartialError())
+
+	_, err = BulkListByUsernames(context.Background(), mockClient, nil, 0)
+	assert.Error(t, err)
+}
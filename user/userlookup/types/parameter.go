@@ -3,6 +3,7 @@ package types
 import (
 	"io"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/michimani/gotwi/fields"
@@ -282,3 +283,81 @@ func (p *GetMeInput) ParameterMap() map[string]string {
 	m = fields.SetFieldsParams(m, p.Expansions, p.TweetFields, p.UserFields)
 	return m
 }
+
+type SearchMaxResults int
+
+func (m SearchMaxResults) Valid() bool {
+	return m > 0 && m <= 1000
+}
+
+func (m SearchMaxResults) String() string {
+	return strconv.Itoa(int(m))
+}
+
+// SearchInput is struct for requesting `GET /2/users/search`.
+// more information: https://docs.x.com/x-api/users/search-users
+type SearchInput struct {
+	accessToken string
+
+	// Query parameters
+	Query       string // required
+	MaxResults  SearchMaxResults
+	NextToken   string
+	Expansions  fields.ExpansionList
+	TweetFields fields.TweetFieldList
+	UserFields  fields.UserFieldList
+}
+
+var searchQueryParameters = map[string]struct{}{
+	"query":        {},
+	"max_results":  {},
+	"next_token":   {},
+	"expansions":   {},
+	"tweet.fields": {},
+	"user.fields":  {},
+}
+
+func (p *SearchInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *SearchInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *SearchInput) ResolveEndpoint(endpointBase string) string {
+	if p.Query == "" {
+		return ""
+	}
+
+	endpoint := endpointBase
+
+	pm := p.ParameterMap()
+	if len(pm) > 0 {
+		qs := util.QueryString(pm, searchQueryParameters)
+		endpoint += "?" + qs
+	}
+
+	return endpoint
+}
+
+func (p *SearchInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *SearchInput) ParameterMap() map[string]string {
+	m := map[string]string{}
+	m["query"] = p.Query
+
+	if p.MaxResults.Valid() {
+		m["max_results"] = p.MaxResults.String()
+	}
+
+	if p.NextToken != "" {
+		m["next_token"] = p.NextToken
+	}
+
+	m = fields.SetFieldsParams(m, p.Expansions, p.TweetFields, p.UserFields)
+
+	return m
+}
@@ -578,3 +578,83 @@ func Test_GetMeInput_Body(t *testing.T) {
 		})
 	}
 }
+
+func Test_SearchInput_ResolveEndpoint(t *testing.T) {
+	const endpointBase = "test/endpoint"
+	cases := []struct {
+		name   string
+		params *types.SearchInput
+		expect string
+	}{
+		{
+			name:   "normal: only required parameter",
+			params: &types.SearchInput{Query: "gopher"},
+			expect: endpointBase + "?query=gopher",
+		},
+		{
+			name: "normal: with max_results and next_token",
+			params: &types.SearchInput{
+				Query:      "gopher",
+				MaxResults: 500,
+				NextToken:  "token",
+			},
+			expect: endpointBase + "?max_results=500&next_token=token&query=gopher",
+		},
+		{
+			name: "normal: invalid max_results",
+			params: &types.SearchInput{
+				Query:      "gopher",
+				MaxResults: 1001,
+			},
+			expect: endpointBase + "?query=gopher",
+		},
+		{
+			name: "normal: all query parameters",
+			params: &types.SearchInput{
+				Query:       "go lang",
+				MaxResults:  10,
+				NextToken:   "token",
+				Expansions:  fields.ExpansionList{"ex"},
+				UserFields:  fields.UserFieldList{"uf"},
+				TweetFields: fields.TweetFieldList{"tf"},
+			},
+			expect: endpointBase + "?expansions=ex&max_results=10&next_token=token&query=go+lang&tweet.fields=tf&user.fields=uf",
+		},
+		{
+			name:   "abnormal: query is empty",
+			params: &types.SearchInput{},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			ep := c.params.ResolveEndpoint(endpointBase)
+			assert.Equal(tt, c.expect, ep)
+		})
+	}
+}
+
+func Test_SearchInput_Body(t *testing.T) {
+	cases := []struct {
+		name   string
+		params *types.SearchInput
+	}{
+		{
+			name:   "empty params",
+			params: &types.SearchInput{},
+		},
+		{
+			name:   "some params",
+			params: &types.SearchInput{Query: "gopher"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			r, err := c.params.Body()
+			assert.NoError(tt, err)
+			assert.Nil(tt, r)
+		})
+	}
+}
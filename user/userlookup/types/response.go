@@ -71,3 +71,48 @@ type GetMeOutput struct {
 func (r *GetMeOutput) HasPartialError() bool {
 	return !(r.Errors == nil || len(r.Errors) == 0)
 }
+
+// SearchOutput is struct for response of `GET /2/users/search`.
+// more information: https://docs.x.com/x-api/users/search-users
+type SearchOutput struct {
+	Data     []resources.User `json:"data"`
+	Includes struct {
+		Tweets []resources.Tweet `json:"tweets"`
+	} `json:"includes"`
+	Meta   resources.PaginationMeta `json:"meta"`
+	Errors []resources.PartialError `json:"errors"`
+}
+
+func (r *SearchOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+// BulkListOutput is struct for the merged responses of `GET /2/users` for all batches of IDs.
+type BulkListOutput struct {
+	Data     []resources.User
+	Includes struct {
+		Tweets []resources.Tweet
+	}
+
+	// Partial errors (e.g. not found, suspended) keyed by the requested ID.
+	Errors map[string]resources.PartialError
+}
+
+func (r *BulkListOutput) HasPartialError() bool {
+	return len(r.Errors) > 0
+}
+
+// BulkListByUsernamesOutput is struct for the merged responses of `GET /2/users/by` for all batches of usernames.
+type BulkListByUsernamesOutput struct {
+	Data     []resources.User
+	Includes struct {
+		Tweets []resources.Tweet
+	}
+
+	// Partial errors (e.g. not found, suspended) keyed by the requested username.
+	Errors map[string]resources.PartialError
+}
+
+func (r *BulkListByUsernamesOutput) HasPartialError() bool {
+	return len(r.Errors) > 0
+}
@@ -192,3 +192,39 @@ func Test_UserLookupMe_HasPartialError(t *testing.T) {
 		})
 	}
 }
+
+func Test_UserSearch_HasPartialError(t *testing.T) {
+	var errorTitle string = "test partical error"
+	cases := []struct {
+		name   string
+		res    *types.SearchOutput
+		expect bool
+	}{
+		{
+			name: "has partical error",
+			res: &types.SearchOutput{
+				Errors: []resources.PartialError{
+					{Title: &errorTitle},
+				}},
+			expect: true,
+		},
+		{
+			name: "has no partical error",
+			res: &types.SearchOutput{
+				Errors: []resources.PartialError{}},
+			expect: false,
+		},
+		{
+			name:   "partical error is nil",
+			res:    &types.SearchOutput{},
+			expect: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			hpe := c.res.HasPartialError()
+			assert.Equal(tt, c.expect, hpe)
+		})
+	}
+}
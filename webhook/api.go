@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/webhook/types"
+)
+
+const (
+	createWebhookEndpoint      = "https://api.twitter.com/2/webhooks"
+	listWebhooksEndpoint       = "https://api.twitter.com/2/webhooks"
+	deleteWebhookEndpoint      = "https://api.twitter.com/2/webhooks/:webhook_id"
+	validateWebhookEndpoint    = "https://api.twitter.com/2/webhooks/:webhook_id"
+	createSubscriptionEndpoint = "https://api.twitter.com/2/account_activity/webhooks/:webhook_id/subscriptions/all"
+	getSubscriptionEndpoint    = "https://api.twitter.com/2/account_activity/webhooks/:webhook_id/subscriptions/all"
+	listSubscriptionsEndpoint  = "https://api.twitter.com/2/account_activity/webhooks/:webhook_id/subscriptions/all/list"
+	deleteSubscriptionEndpoint = "https://api.twitter.com/2/account_activity/webhooks/:webhook_id/subscriptions/:user_id/all"
+)
+
+// Registers a webhook URL. A CRC request is sent to the URL, so the Handler must already be serving it.
+// https://docs.x.com/x-api/webhooks/create-webhook
+func CreateWebhook(ctx context.Context, c gotwi.IClient, p *types.CreateWebhookInput, opts ...gotwi.CallOption) (*types.CreateWebhookOutput, error) {
+	return gotwi.Do[*types.CreateWebhookOutput](ctx, c, "POST", createWebhookEndpoint, p, opts...)
+}
+
+// Returns the webhooks registered for the App.
+// https://docs.x.com/x-api/webhooks/get-webhook
+func ListWebhooks(ctx context.Context, c gotwi.IClient, p *types.ListWebhooksInput, opts ...gotwi.CallOption) (*types.ListWebhooksOutput, error) {
+	return gotwi.Do[*types.ListWebhooksOutput](ctx, c, "GET", listWebhooksEndpoint, p, opts...)
+}
+
+// Deletes a webhook. The subscriptions of the webhook are also deleted.
+// https://docs.x.com/x-api/webhooks/delete-webhook
+func DeleteWebhook(ctx context.Context, c gotwi.IClient, p *types.DeleteWebhookInput, opts ...gotwi.CallOption) (*types.DeleteWebhookOutput, error) {
+	return gotwi.Do[*types.DeleteWebhookOutput](ctx, c, "DELETE", deleteWebhookEndpoint, p, opts...)
+}
+
+// Triggers a CRC request to the webhook. It re-enables a webhook that was marked invalid.
+// https://docs.x.com/x-api/webhooks/validate-webhook
+func ValidateWebhook(ctx context.Context, c gotwi.IClient, p *types.ValidateWebhookInput, opts ...gotwi.CallOption) (*types.ValidateWebhookOutput, error) {
+	return gotwi.Do[*types.ValidateWebhookOutput](ctx, c, "PUT", validateWebhookEndpoint, p, opts...)
+}
+
+// Subscribes the authenticating user to the webhook.
+// The client must use OAuth 1.0a User Context of the user to subscribe.
+// https://docs.x.com/x-api/account-activity/create-subscription
+func CreateSubscription(ctx context.Context, c gotwi.IClient, p *types.CreateSubscriptionInput, opts ...gotwi.CallOption) (*types.CreateSubscriptionOutput, error) {
+	return gotwi.Do[*types.CreateSubscriptionOutput](ctx, c, "POST", createSubscriptionEndpoint, p, opts...)
+}
+
+// Returns whether the authenticating user is subscribed to the webhook.
+// The client must use OAuth 1.0a User Context of the user.
+// https://docs.x.com/x-api/account-activity/validate-subscription
+func GetSubscription(ctx context.Context, c gotwi.IClient, p *types.GetSubscriptionInput, opts ...gotwi.CallOption) (*types.GetSubscriptionOutput, error) {
+	return gotwi.Do[*types.GetSubscriptionOutput](ctx, c, "GET", getSubscriptionEndpoint, p, opts...)
+}
+
+// Returns the users subscribed to the webhook.
+// https://docs.x.com/x-api/account-activity/get-subscriptions
+func ListSubscriptions(ctx context.Context, c gotwi.IClient, p *types.ListSubscriptionsInput, opts ...gotwi.CallOption) (*types.ListSubscriptionsOutput, error) {
+	return gotwi.Do[*types.ListSubscriptionsOutput](ctx, c, "GET", listSubscriptionsEndpoint, p, opts...)
+}
+
+// Unsubscribes the user from the webhook.
+// https://docs.x.com/x-api/account-activity/delete-subscription
+func DeleteSubscription(ctx context.Context, c gotwi.IClient, p *types.DeleteSubscriptionInput, opts ...gotwi.CallOption) (*types.DeleteSubscriptionOutput, error) {
+	return gotwi.Do[*types.DeleteSubscriptionOutput](ctx, c, "DELETE", deleteSubscriptionEndpoint, p, opts...)
+}
@@ -0,0 +1,105 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/internal/util"
+	"github.com/michimani/gotwi/webhook"
+	"github.com/michimani/gotwi/webhook/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateWebhook(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *types.CreateWebhookInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			params: &types.CreateWebhookInput{URL: "https://example.com/webhook"},
+		},
+		{
+			name:    "error",
+			params:  &types.CreateWebhookInput{URL: "https://example.com/webhook"},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "error: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					assert.Equal(tt, "POST", method)
+					return c.mockErr
+				},
+			})
+
+			res, err := webhook.CreateWebhook(context.Background(), mockClient, c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, res)
+		})
+	}
+}
+
+func Test_CreateSubscription(t *testing.T) {
+	cases := []struct {
+		name    string
+		params  *types.CreateSubscriptionInput
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			params: &types.CreateSubscriptionInput{WebhookID: "1"},
+		},
+		{
+			name:    "error",
+			params:  &types.CreateSubscriptionInput{WebhookID: "1"},
+			mockErr: errors.New("error"),
+			wantErr: true,
+		},
+		{
+			name:    "error: params is nil",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			mockClient := gotwi.NewMockGotwiClientWithFunc(gotwi.MockFuncInput{
+				MockCallAPI: func(ctx context.Context, endpoint, method string, p util.Parameters, i util.Response) error {
+					assert.Equal(tt, "POST", method)
+					return c.mockErr
+				},
+			})
+
+			res, err := webhook.CreateSubscription(context.Background(), mockClient, c.params)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, res)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, res)
+		})
+	}
+}
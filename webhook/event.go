@@ -0,0 +1,302 @@
+package webhook
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/michimani/gotwi/resources"
+)
+
+// Event is a payload delivered to the webhook by the Account Activity API.
+// A payload contains events of one type, so only one of the slices is set.
+type Event struct {
+	ForUserID           string
+	UserHasBlocked      bool
+	TweetCreateEvents   []TweetCreateEvent
+	TweetDeleteEvents   []TweetDeleteEvent
+	FavoriteEvents      []FavoriteEvent
+	FollowEvents        []RelationshipEvent
+	BlockEvents         []RelationshipEvent
+	MuteEvents          []RelationshipEvent
+	DirectMessageEvents []DirectMessageEvent
+}
+
+type TweetCreateEvent struct {
+	Tweet  resources.Tweet
+	Author resources.User
+}
+
+type TweetDeleteEvent struct {
+	TweetID   string
+	UserID    string
+	DeletedAt time.Time
+}
+
+type FavoriteEvent struct {
+	ID        string
+	CreatedAt time.Time
+	Tweet     resources.Tweet
+	Author    resources.User
+	User      resources.User
+}
+
+type RelationshipEventType string
+
+const (
+	RelationshipEventTypeFollow   RelationshipEventType = "follow"
+	RelationshipEventTypeUnfollow RelationshipEventType = "unfollow"
+	RelationshipEventTypeBlock    RelationshipEventType = "block"
+	RelationshipEventTypeUnblock  RelationshipEventType = "unblock"
+	RelationshipEventTypeMute     RelationshipEventType = "mute"
+	RelationshipEventTypeUnmute   RelationshipEventType = "unmute"
+)
+
+// RelationshipEvent is a follow, block or mute event, and its reverse.
+// Source is the user who took the action, and Target is the user who was followed, blocked or muted.
+type RelationshipEvent struct {
+	Type      RelationshipEventType
+	CreatedAt time.Time
+	Source    resources.User
+	Target    resources.User
+}
+
+type DirectMessageEvent struct {
+	ID          string
+	CreatedAt   time.Time
+	SenderID    string
+	RecipientID string
+	Text        string
+	Sender      *resources.User
+	Recipient   *resources.User
+}
+
+// The Account Activity API delivers objects in the v1.1 format.
+// They are decoded with the following types and converted to the resources types.
+
+type rawEvent struct {
+	ForUserID           string                 `json:"for_user_id"`
+	UserHasBlocked      bool                   `json:"user_has_blocked"`
+	TweetCreateEvents   []legacyTweet          `json:"tweet_create_events"`
+	TweetDeleteEvents   []rawTweetDeleteEvent  `json:"tweet_delete_events"`
+	FavoriteEvents      []rawFavoriteEvent     `json:"favorite_events"`
+	FollowEvents        []rawRelationshipEvent `json:"follow_events"`
+	BlockEvents         []rawRelationshipEvent `json:"block_events"`
+	MuteEvents          []rawRelationshipEvent `json:"mute_events"`
+	DirectMessageEvents []rawDirectMessage     `json:"direct_message_events"`
+	Users               map[string]legacyUser  `json:"users"`
+}
+
+type rawTweetDeleteEvent struct {
+	Status struct {
+		ID     string `json:"id"`
+		UserID string `json:"user_id"`
+	} `json:"status"`
+	TimestampMS string `json:"timestamp_ms"`
+}
+
+type rawFavoriteEvent struct {
+	ID              string      `json:"id"`
+	TimestampMS     json.Number `json:"timestamp_ms"`
+	FavoritedStatus legacyTweet `json:"favorited_status"`
+	User            legacyUser  `json:"user"`
+}
+
+type rawRelationshipEvent struct {
+	Type             string     `json:"type"`
+	CreatedTimestamp string     `json:"created_timestamp"`
+	Source           legacyUser `json:"source"`
+	Target           legacyUser `json:"target"`
+}
+
+type rawDirectMessage struct {
+	Type             string `json:"type"`
+	ID               string `json:"id"`
+	CreatedTimestamp string `json:"created_timestamp"`
+	MessageCreate    struct {
+		Target struct {
+			RecipientID string `json:"recipient_id"`
+		} `json:"target"`
+		SenderID    string `json:"sender_id"`
+		MessageData struct {
+			Text string `json:"text"`
+		} `json:"message_data"`
+	} `json:"message_create"`
+}
+
+type legacyTweet struct {
+	IDStr                string     `json:"id_str"`
+	Text                 string     `json:"text"`
+	CreatedAt            string     `json:"created_at"`
+	InReplyToStatusIDStr *string    `json:"in_reply_to_status_id_str"`
+	InReplyToUserIDStr   *string    `json:"in_reply_to_user_id_str"`
+	QuotedStatusIDStr    *string    `json:"quoted_status_id_str"`
+	Lang                 *string    `json:"lang"`
+	Source               *string    `json:"source"`
+	User                 legacyUser `json:"user"`
+	ExtendedTweet        *struct {
+		FullText string `json:"full_text"`
+	} `json:"extended_tweet"`
+	RetweetedStatus *struct {
+		IDStr string `json:"id_str"`
+	} `json:"retweeted_status"`
+}
+
+type legacyUser struct {
+	IDStr           string  `json:"id_str"`
+	Name            string  `json:"name"`
+	ScreenName      string  `json:"screen_name"`
+	CreatedAt       string  `json:"created_at"`
+	Description     *string `json:"description"`
+	Location        *string `json:"location"`
+	URL             *string `json:"url"`
+	Protected       *bool   `json:"protected"`
+	Verified        *bool   `json:"verified"`
+	ProfileImageURL *string `json:"profile_image_url_https"`
+}
+
+func (t legacyTweet) toResource() resources.Tweet {
+	text := t.Text
+	if t.ExtendedTweet != nil && t.ExtendedTweet.FullText != "" {
+		text = t.ExtendedTweet.FullText
+	}
+
+	r := resources.Tweet{
+		ID:              stringPtr(t.IDStr),
+		Text:            &text,
+		AuthorID:        stringPtr(t.User.IDStr),
+		CreatedAt:       parseLegacyTime(t.CreatedAt),
+		InReplyToUserID: t.InReplyToUserIDStr,
+		Lang:            t.Lang,
+		Source:          t.Source,
+	}
+
+	if t.InReplyToStatusIDStr != nil {
+		r.ReferencedTweets = append(r.ReferencedTweets, referencedTweet("replied_to", *t.InReplyToStatusIDStr))
+	}
+	if t.QuotedStatusIDStr != nil {
+		r.ReferencedTweets = append(r.ReferencedTweets, referencedTweet("quoted", *t.QuotedStatusIDStr))
+	}
+	if t.RetweetedStatus != nil {
+		r.ReferencedTweets = append(r.ReferencedTweets, referencedTweet("retweeted", t.RetweetedStatus.IDStr))
+	}
+
+	return r
+}
+
+func (u legacyUser) toResource() resources.User {
+	return resources.User{
+		ID:              stringPtr(u.IDStr),
+		Name:            stringPtr(u.Name),
+		Username:        stringPtr(u.ScreenName),
+		CreatedAt:       parseLegacyTime(u.CreatedAt),
+		Description:     u.Description,
+		Location:        u.Location,
+		URL:             u.URL,
+		Protected:       u.Protected,
+		Verified:        u.Verified,
+		ProfileImageURL: u.ProfileImageURL,
+	}
+}
+
+func (r *rawEvent) toEvent() *Event {
+	e := &Event{
+		ForUserID:      r.ForUserID,
+		UserHasBlocked: r.UserHasBlocked,
+	}
+
+	for _, t := range r.TweetCreateEvents {
+		e.TweetCreateEvents = append(e.TweetCreateEvents, TweetCreateEvent{
+			Tweet:  t.toResource(),
+			Author: t.User.toResource(),
+		})
+	}
+
+	for _, d := range r.TweetDeleteEvents {
+		e.TweetDeleteEvents = append(e.TweetDeleteEvents, TweetDeleteEvent{
+			TweetID:   d.Status.ID,
+			UserID:    d.Status.UserID,
+			DeletedAt: parseTimestampMS(d.TimestampMS),
+		})
+	}
+
+	for _, f := range r.FavoriteEvents {
+		e.FavoriteEvents = append(e.FavoriteEvents, FavoriteEvent{
+			ID:        f.ID,
+			CreatedAt: parseTimestampMS(f.TimestampMS.String()),
+			Tweet:     f.FavoritedStatus.toResource(),
+			Author:    f.FavoritedStatus.User.toResource(),
+			User:      f.User.toResource(),
+		})
+	}
+
+	e.FollowEvents = toRelationshipEvents(r.FollowEvents)
+	e.BlockEvents = toRelationshipEvents(r.BlockEvents)
+	e.MuteEvents = toRelationshipEvents(r.MuteEvents)
+
+	for _, dm := range r.DirectMessageEvents {
+		if dm.Type != "message_create" {
+			continue
+		}
+
+		m := DirectMessageEvent{
+			ID:          dm.ID,
+			CreatedAt:   parseTimestampMS(dm.CreatedTimestamp),
+			SenderID:    dm.MessageCreate.SenderID,
+			RecipientID: dm.MessageCreate.Target.RecipientID,
+			Text:        dm.MessageCreate.MessageData.Text,
+		}
+		if u, ok := r.Users[m.SenderID]; ok {
+			ur := u.toResource()
+			m.Sender = &ur
+		}
+		if u, ok := r.Users[m.RecipientID]; ok {
+			ur := u.toResource()
+			m.Recipient = &ur
+		}
+		e.DirectMessageEvents = append(e.DirectMessageEvents, m)
+	}
+
+	return e
+}
+
+func toRelationshipEvents(raw []rawRelationshipEvent) []RelationshipEvent {
+	var events []RelationshipEvent
+	for _, r := range raw {
+		events = append(events, RelationshipEvent{
+			Type:      RelationshipEventType(r.Type),
+			CreatedAt: parseTimestampMS(r.CreatedTimestamp),
+			Source:    r.Source.toResource(),
+			Target:    r.Target.toResource(),
+		})
+	}
+
+	return events
+}
+
+func referencedTweet(typ, id string) resources.ReferencedTweet {
+	return resources.ReferencedTweet{Type: &typ, ID: &id}
+}
+
+func stringPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func parseLegacyTime(s string) *time.Time {
+	t, err := time.Parse(time.RubyDate, s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+func parseTimestampMS(s string) time.Time {
+	ms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
@@ -0,0 +1,231 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/michimani/gotwi"
+)
+
+const (
+	SignatureHeader = "x-twitter-webhooks-signature"
+	signaturePrefix = "sha256="
+
+	// maxPayloadSize is the limit of the request body. A payload is far smaller than this.
+	maxPayloadSize = 10 << 20
+)
+
+type NewHandlerInput struct {
+	// ConsumerSecret is the API key secret of the App that the webhook is registered with.
+	// If it is empty, the value of the GOTWI_API_KEY_SECRET environment variable is used.
+	ConsumerSecret string
+	// OnError is called when a request is rejected or its payload can not be decoded. Optional.
+	OnError func(r *http.Request, err error)
+}
+
+// Handler is an http.Handler that receives events from the Account Activity API.
+// It answers CRC challenges on GET, and validates and dispatches events on POST.
+// Register event handlers before the Handler starts serving.
+type Handler struct {
+	consumerSecret []byte
+	onError        func(r *http.Request, err error)
+
+	onEvent         []func(ctx context.Context, e *Event)
+	onTweetCreate   []func(ctx context.Context, forUserID string, e TweetCreateEvent)
+	onTweetDelete   []func(ctx context.Context, forUserID string, e TweetDeleteEvent)
+	onFavorite      []func(ctx context.Context, forUserID string, e FavoriteEvent)
+	onFollow        []func(ctx context.Context, forUserID string, e RelationshipEvent)
+	onBlock         []func(ctx context.Context, forUserID string, e RelationshipEvent)
+	onMute          []func(ctx context.Context, forUserID string, e RelationshipEvent)
+	onDirectMessage []func(ctx context.Context, forUserID string, e DirectMessageEvent)
+}
+
+var (
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	ErrMissingCRCToken  = errors.New("webhook: crc_token is required")
+)
+
+func NewHandler(in *NewHandlerInput) (*Handler, error) {
+	if in == nil {
+		return nil, errors.New("NewHandlerInput is nil")
+	}
+
+	secret := in.ConsumerSecret
+	if secret == "" {
+		secret = os.Getenv(gotwi.APIKeySecretEnvName)
+	}
+	if secret == "" {
+		return nil, errors.New("consumer secret is required")
+	}
+
+	return &Handler{
+		consumerSecret: []byte(secret),
+		onError:        in.OnError,
+	}, nil
+}
+
+// OnEvent registers fn that is called with every payload, before the handlers of each event type.
+func (h *Handler) OnEvent(fn func(ctx context.Context, e *Event)) {
+	h.onEvent = append(h.onEvent, fn)
+}
+
+func (h *Handler) OnTweetCreate(fn func(ctx context.Context, forUserID string, e TweetCreateEvent)) {
+	h.onTweetCreate = append(h.onTweetCreate, fn)
+}
+
+func (h *Handler) OnTweetDelete(fn func(ctx context.Context, forUserID string, e TweetDeleteEvent)) {
+	h.onTweetDelete = append(h.onTweetDelete, fn)
+}
+
+func (h *Handler) OnFavorite(fn func(ctx context.Context, forUserID string, e FavoriteEvent)) {
+	h.onFavorite = append(h.onFavorite, fn)
+}
+
+func (h *Handler) OnFollow(fn func(ctx context.Context, forUserID string, e RelationshipEvent)) {
+	h.onFollow = append(h.onFollow, fn)
+}
+
+func (h *Handler) OnBlock(fn func(ctx context.Context, forUserID string, e RelationshipEvent)) {
+	h.onBlock = append(h.onBlock, fn)
+}
+
+func (h *Handler) OnMute(fn func(ctx context.Context, forUserID string, e RelationshipEvent)) {
+	h.onMute = append(h.onMute, fn)
+}
+
+func (h *Handler) OnDirectMessage(fn func(ctx context.Context, forUserID string, e DirectMessageEvent)) {
+	h.onDirectMessage = append(h.onDirectMessage, fn)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.serveCRC(w, r)
+	case http.MethodPost:
+		h.serveEvent(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+type crcResponse struct {
+	ResponseToken string `json:"response_token"`
+}
+
+// serveCRC answers a Challenge-Response Check with the HMAC-SHA256 of crc_token.
+func (h *Handler) serveCRC(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("crc_token")
+	if token == "" {
+		h.reject(w, r, http.StatusBadRequest, ErrMissingCRCToken)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(crcResponse{ResponseToken: CRCResponseToken(h.consumerSecret, token)})
+}
+
+func (h *Handler) serveEvent(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		h.reject(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !ValidSignature(h.consumerSecret, body, r.Header.Get(SignatureHeader)) {
+		h.reject(w, r, http.StatusUnauthorized, ErrInvalidSignature)
+		return
+	}
+
+	raw := &rawEvent{}
+	if err := json.Unmarshal(body, raw); err != nil {
+		h.reject(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	h.dispatch(r.Context(), raw.toEvent())
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) dispatch(ctx context.Context, e *Event) {
+	for _, fn := range h.onEvent {
+		fn(ctx, e)
+	}
+	for _, ev := range e.TweetCreateEvents {
+		for _, fn := range h.onTweetCreate {
+			fn(ctx, e.ForUserID, ev)
+		}
+	}
+	for _, ev := range e.TweetDeleteEvents {
+		for _, fn := range h.onTweetDelete {
+			fn(ctx, e.ForUserID, ev)
+		}
+	}
+	for _, ev := range e.FavoriteEvents {
+		for _, fn := range h.onFavorite {
+			fn(ctx, e.ForUserID, ev)
+		}
+	}
+	for _, ev := range e.FollowEvents {
+		for _, fn := range h.onFollow {
+			fn(ctx, e.ForUserID, ev)
+		}
+	}
+	for _, ev := range e.BlockEvents {
+		for _, fn := range h.onBlock {
+			fn(ctx, e.ForUserID, ev)
+		}
+	}
+	for _, ev := range e.MuteEvents {
+		for _, fn := range h.onMute {
+			fn(ctx, e.ForUserID, ev)
+		}
+	}
+	for _, ev := range e.DirectMessageEvents {
+		for _, fn := range h.onDirectMessage {
+			fn(ctx, e.ForUserID, ev)
+		}
+	}
+}
+
+func (h *Handler) reject(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.onError != nil {
+		h.onError(r, err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// CRCResponseToken returns the response_token for a Challenge-Response Check.
+func CRCResponseToken(consumerSecret []byte, crcToken string) string {
+	return signaturePrefix + base64.StdEncoding.EncodeToString(sign(consumerSecret, []byte(crcToken)))
+}
+
+// ValidSignature reports whether signature, the value of the x-twitter-webhooks-signature header,
+// is the HMAC-SHA256 of body.
+func ValidSignature(consumerSecret, body []byte, signature string) bool {
+	encoded, ok := strings.CutPrefix(signature, signaturePrefix)
+	if !ok {
+		return false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(decoded, sign(consumerSecret, body))
+}
+
+func sign(key, message []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(message)
+	return mac.Sum(nil)
+}
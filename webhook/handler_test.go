@@ -0,0 +1,269 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/michimani/gotwi/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "test-consumer-secret"
+
+func testSignature(body string) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(body))
+	return "sha256=" + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func Test_NewHandler(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      *webhook.NewHandlerInput
+		env     string
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			in:   &webhook.NewHandlerInput{ConsumerSecret: testSecret},
+		},
+		{
+			name: "ok: from environment variable",
+			in:   &webhook.NewHandlerInput{},
+			env:  testSecret,
+		},
+		{
+			name:    "error: no consumer secret",
+			in:      &webhook.NewHandlerInput{},
+			wantErr: true,
+		},
+		{
+			name:    "error: nil",
+			in:      nil,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			tt.Setenv("GOTWI_API_KEY_SECRET", c.env)
+
+			h, err := webhook.NewHandler(c.in)
+			if c.wantErr {
+				assert.Error(tt, err)
+				assert.Nil(tt, h)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.NotNil(tt, h)
+		})
+	}
+}
+
+func Test_Handler_CRC(t *testing.T) {
+	cases := []struct {
+		name         string
+		query        string
+		expectStatus int
+		expectToken  string
+	}{
+		{
+			name:         "ok",
+			query:        "?crc_token=test-crc-token",
+			expectStatus: http.StatusOK,
+			expectToken:  testSignature("test-crc-token"),
+		},
+		{
+			name:         "error: no crc_token",
+			query:        "",
+			expectStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+			h, _ := webhook.NewHandler(&webhook.NewHandlerInput{ConsumerSecret: testSecret})
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhook"+c.query, nil))
+
+			asst.Equal(c.expectStatus, rec.Code)
+			if c.expectToken == "" {
+				return
+			}
+
+			res := map[string]string{}
+			asst.NoError(json.NewDecoder(rec.Body).Decode(&res))
+			asst.Equal(c.expectToken, res["response_token"])
+		})
+	}
+}
+
+func Test_Handler_Event(t *testing.T) {
+	tweetCreate := `{
+		"for_user_id": "100",
+		"tweet_create_events": [{
+			"id": 1,
+			"id_str": "1",
+			"text": "hello",
+			"created_at": "Mon Jan 02 15:04:05 +0000 2026",
+			"in_reply_to_status_id_str": "2",
+			"user": {"id": 200, "id_str": "200", "name": "Name", "screen_name": "name"}
+		}]
+	}`
+	follow := `{
+		"for_user_id": "100",
+		"follow_events": [{
+			"type": "follow",
+			"created_timestamp": "1767366245000",
+			"source": {"id_str": "200", "screen_name": "source"},
+			"target": {"id_str": "100", "screen_name": "target"}
+		}]
+	}`
+	directMessage := `{
+		"for_user_id": "100",
+		"direct_message_events": [{
+			"type": "message_create",
+			"id": "3",
+			"created_timestamp": "1767366245000",
+			"message_create": {
+				"target": {"recipient_id": "100"},
+				"sender_id": "200",
+				"message_data": {"text": "hi"}
+			}
+		}],
+		"users": {"200": {"id_str": "200", "screen_name": "sender"}}
+	}`
+
+	cases := []struct {
+		name         string
+		body         string
+		signature    string
+		expectStatus int
+		expect       func(asst *assert.Assertions, events []any)
+	}{
+		{
+			name:         "ok: tweet create",
+			body:         tweetCreate,
+			signature:    testSignature(tweetCreate),
+			expectStatus: http.StatusOK,
+			expect: func(asst *assert.Assertions, events []any) {
+				asst.Len(events, 1)
+				e := events[0].(webhook.TweetCreateEvent)
+				asst.Equal("1", *e.Tweet.ID)
+				asst.Equal("hello", *e.Tweet.Text)
+				asst.Equal("200", *e.Tweet.AuthorID)
+				asst.Equal(time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC), e.Tweet.CreatedAt.UTC())
+				asst.Equal("replied_to", *e.Tweet.ReferencedTweets[0].Type)
+				asst.Equal("name", *e.Author.Username)
+			},
+		},
+		{
+			name:         "ok: follow",
+			body:         follow,
+			signature:    testSignature(follow),
+			expectStatus: http.StatusOK,
+			expect: func(asst *assert.Assertions, events []any) {
+				asst.Len(events, 1)
+				e := events[0].(webhook.RelationshipEvent)
+				asst.Equal(webhook.RelationshipEventTypeFollow, e.Type)
+				asst.Equal("source", *e.Source.Username)
+				asst.Equal("target", *e.Target.Username)
+				asst.Equal(int64(1767366245000), e.CreatedAt.UnixMilli())
+			},
+		},
+		{
+			name:         "ok: direct message",
+			body:         directMessage,
+			signature:    testSignature(directMessage),
+			expectStatus: http.StatusOK,
+			expect: func(asst *assert.Assertions, events []any) {
+				asst.Len(events, 1)
+				e := events[0].(webhook.DirectMessageEvent)
+				asst.Equal("hi", e.Text)
+				asst.Equal("200", e.SenderID)
+				asst.Equal("sender", *e.Sender.Username)
+				asst.Nil(e.Recipient)
+			},
+		},
+		{
+			name:         "error: invalid signature",
+			body:         follow,
+			signature:    testSignature("other body"),
+			expectStatus: http.StatusUnauthorized,
+			expect: func(asst *assert.Assertions, events []any) {
+				asst.Empty(events)
+			},
+		},
+		{
+			name:         "error: no signature",
+			body:         follow,
+			signature:    "",
+			expectStatus: http.StatusUnauthorized,
+			expect: func(asst *assert.Assertions, events []any) {
+				asst.Empty(events)
+			},
+		},
+		{
+			name:         "error: invalid json",
+			body:         "{",
+			signature:    testSignature("{"),
+			expectStatus: http.StatusBadRequest,
+			expect: func(asst *assert.Assertions, events []any) {
+				asst.Empty(events)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			asst := assert.New(tt)
+
+			var errs []error
+			h, _ := webhook.NewHandler(&webhook.NewHandlerInput{
+				ConsumerSecret: testSecret,
+				OnError:        func(r *http.Request, err error) { errs = append(errs, err) },
+			})
+
+			events := []any{}
+			h.OnTweetCreate(func(ctx context.Context, forUserID string, e webhook.TweetCreateEvent) {
+				asst.Equal("100", forUserID)
+				events = append(events, e)
+			})
+			h.OnFollow(func(ctx context.Context, forUserID string, e webhook.RelationshipEvent) {
+				events = append(events, e)
+			})
+			h.OnDirectMessage(func(ctx context.Context, forUserID string, e webhook.DirectMessageEvent) {
+				events = append(events, e)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(c.body))
+			req.Header.Set("x-twitter-webhooks-signature", c.signature)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			asst.Equal(c.expectStatus, rec.Code)
+			asst.Equal(c.expectStatus != http.StatusOK, len(errs) > 0)
+			c.expect(asst, events)
+		})
+	}
+}
+
+func Test_Handler_MethodNotAllowed(t *testing.T) {
+	h, _ := webhook.NewHandler(&webhook.NewHandlerInput{ConsumerSecret: testSecret})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/webhook", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,247 @@
+package types
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// CreateWebhookInput is struct for the parameters
+// that used for calling POST /2/webhooks API.
+type CreateWebhookInput struct {
+	accessToken string
+
+	// JSON body parameter
+	URL string `json:"url"` // required
+}
+
+func (p *CreateWebhookInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *CreateWebhookInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *CreateWebhookInput) ResolveEndpoint(endpointBase string) string {
+	return endpointBase
+}
+
+func (p *CreateWebhookInput) Body() (io.Reader, error) {
+	json, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.NewReader(string(json)), nil
+}
+
+func (p *CreateWebhookInput) ParameterMap() map[string]string {
+	return map[string]string{}
+}
+
+type ListWebhooksInput struct {
+	accessToken string
+}
+
+func (p *ListWebhooksInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *ListWebhooksInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *ListWebhooksInput) ResolveEndpoint(endpointBase string) string {
+	return endpointBase
+}
+
+func (p *ListWebhooksInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *ListWebhooksInput) ParameterMap() map[string]string {
+	return map[string]string{}
+}
+
+type DeleteWebhookInput struct {
+	accessToken string
+
+	// Path parameter
+	WebhookID string // required
+}
+
+func (p *DeleteWebhookInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *DeleteWebhookInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *DeleteWebhookInput) ResolveEndpoint(endpointBase string) string {
+	return resolveWebhookID(endpointBase, p.WebhookID)
+}
+
+func (p *DeleteWebhookInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *DeleteWebhookInput) ParameterMap() map[string]string {
+	return map[string]string{}
+}
+
+type ValidateWebhookInput struct {
+	accessToken string
+
+	// Path parameter
+	WebhookID string // required
+}
+
+func (p *ValidateWebhookInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *ValidateWebhookInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *ValidateWebhookInput) ResolveEndpoint(endpointBase string) string {
+	return resolveWebhookID(endpointBase, p.WebhookID)
+}
+
+func (p *ValidateWebhookInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *ValidateWebhookInput) ParameterMap() map[string]string {
+	return map[string]string{}
+}
+
+type CreateSubscriptionInput struct {
+	accessToken string
+
+	// Path parameter
+	WebhookID string // required
+}
+
+func (p *CreateSubscriptionInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *CreateSubscriptionInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *CreateSubscriptionInput) ResolveEndpoint(endpointBase string) string {
+	return resolveWebhookID(endpointBase, p.WebhookID)
+}
+
+func (p *CreateSubscriptionInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *CreateSubscriptionInput) ParameterMap() map[string]string {
+	return map[string]string{}
+}
+
+type GetSubscriptionInput struct {
+	accessToken string
+
+	// Path parameter
+	WebhookID string // required
+}
+
+func (p *GetSubscriptionInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *GetSubscriptionInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *GetSubscriptionInput) ResolveEndpoint(endpointBase string) string {
+	return resolveWebhookID(endpointBase, p.WebhookID)
+}
+
+func (p *GetSubscriptionInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *GetSubscriptionInput) ParameterMap() map[string]string {
+	return map[string]string{}
+}
+
+type ListSubscriptionsInput struct {
+	accessToken string
+
+	// Path parameter
+	WebhookID string // required
+}
+
+func (p *ListSubscriptionsInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *ListSubscriptionsInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *ListSubscriptionsInput) ResolveEndpoint(endpointBase string) string {
+	return resolveWebhookID(endpointBase, p.WebhookID)
+}
+
+func (p *ListSubscriptionsInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *ListSubscriptionsInput) ParameterMap() map[string]string {
+	return map[string]string{}
+}
+
+type DeleteSubscriptionInput struct {
+	accessToken string
+
+	// Path parameters
+	WebhookID string // required
+	UserID    string // required
+}
+
+func (p *DeleteSubscriptionInput) SetAccessToken(token string) {
+	p.accessToken = token
+}
+
+func (p *DeleteSubscriptionInput) AccessToken() string {
+	return p.accessToken
+}
+
+func (p *DeleteSubscriptionInput) ResolveEndpoint(endpointBase string) string {
+	if p.UserID == "" {
+		return ""
+	}
+
+	endpoint := resolveWebhookID(endpointBase, p.WebhookID)
+	if endpoint == "" {
+		return ""
+	}
+
+	return strings.Replace(endpoint, ":user_id", url.QueryEscape(p.UserID), 1)
+}
+
+func (p *DeleteSubscriptionInput) Body() (io.Reader, error) {
+	return nil, nil
+}
+
+func (p *DeleteSubscriptionInput) ParameterMap() map[string]string {
+	return map[string]string{}
+}
+
+func resolveWebhookID(endpointBase, webhookID string) string {
+	if webhookID == "" {
+		return ""
+	}
+
+	escaped := url.QueryEscape(webhookID)
+	return strings.Replace(endpointBase, ":webhook_id", escaped, 1)
+}
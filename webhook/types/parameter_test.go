@@ -0,0 +1,78 @@
+package types_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/michimani/gotwi/webhook/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateWebhookInput_Body(t *testing.T) {
+	p := &types.CreateWebhookInput{URL: "https://example.com/webhook"}
+	r, err := p.Body()
+	assert.NoError(t, err)
+
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"url":"https://example.com/webhook"}`, string(b))
+}
+
+func Test_DeleteWebhookInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/endpoint/:webhook_id"
+
+	cases := []struct {
+		name   string
+		params *types.DeleteWebhookInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.DeleteWebhookInput{WebhookID: "wid"},
+			expect: "test/endpoint/wid",
+		},
+		{
+			name:   "webhook id is empty",
+			params: &types.DeleteWebhookInput{},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, c.params.ResolveEndpoint(endpoint))
+		})
+	}
+}
+
+func Test_DeleteSubscriptionInput_ResolveEndpoint(t *testing.T) {
+	const endpoint = "test/webhooks/:webhook_id/subscriptions/:user_id/all"
+
+	cases := []struct {
+		name   string
+		params *types.DeleteSubscriptionInput
+		expect string
+	}{
+		{
+			name:   "ok",
+			params: &types.DeleteSubscriptionInput{WebhookID: "wid", UserID: "uid"},
+			expect: "test/webhooks/wid/subscriptions/uid/all",
+		},
+		{
+			name:   "webhook id is empty",
+			params: &types.DeleteSubscriptionInput{UserID: "uid"},
+			expect: "",
+		},
+		{
+			name:   "user id is empty",
+			params: &types.DeleteSubscriptionInput{WebhookID: "wid"},
+			expect: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert.Equal(tt, c.expect, c.params.ResolveEndpoint(endpoint))
+		})
+	}
+}
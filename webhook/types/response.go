@@ -0,0 +1,84 @@
+package types
+
+import "github.com/michimani/gotwi/resources"
+
+type CreateWebhookOutput struct {
+	Data resources.WebhookConfig `json:"data"`
+}
+
+func (r *CreateWebhookOutput) HasPartialError() bool {
+	return false
+}
+
+type ListWebhooksOutput struct {
+	Data   []resources.WebhookConfig `json:"data"`
+	Meta   resources.PaginationMeta  `json:"meta"`
+	Errors []resources.PartialError  `json:"errors"`
+}
+
+func (r *ListWebhooksOutput) HasPartialError() bool {
+	return !(r.Errors == nil || len(r.Errors) == 0)
+}
+
+type DeleteWebhookOutput struct {
+	Data struct {
+		Deleted *bool `json:"deleted"`
+	} `json:"data"`
+}
+
+func (r *DeleteWebhookOutput) HasPartialError() bool {
+	return false
+}
+
+type ValidateWebhookOutput struct {
+	Data struct {
+		Attempted *bool `json:"attempted"`
+	} `json:"data"`
+}
+
+func (r *ValidateWebhookOutput) HasPartialError() bool {
+	return false
+}
+
+type CreateSubscriptionOutput struct {
+	Data struct {
+		Subscribed *bool `json:"subscribed"`
+	} `json:"data"`
+}
+
+func (r *CreateSubscriptionOutput) HasPartialError() bool {
+	return false
+}
+
+type GetSubscriptionOutput struct {
+	Data struct {
+		Subscribed *bool `json:"subscribed"`
+	} `json:"data"`
+}
+
+func (r *GetSubscriptionOutput) HasPartialError() bool {
+	return false
+}
+
+type ListSubscriptionsOutput struct {
+	Data struct {
+		ApplicationID *string                         `json:"application_id"`
+		WebhookID     *string                         `json:"webhook_id"`
+		WebhookURL    *string                         `json:"webhook_url"`
+		Subscriptions []resources.WebhookSubscription `json:"subscriptions"`
+	} `json:"data"`
+}
+
+func (r *ListSubscriptionsOutput) HasPartialError() bool {
+	return false
+}
+
+type DeleteSubscriptionOutput struct {
+	Data struct {
+		Subscribed *bool `json:"subscribed"`
+	} `json:"data"`
+}
+
+func (r *DeleteSubscriptionOutput) HasPartialError() bool {
+	return false
+}